@@ -0,0 +1,301 @@
+package adapters
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"transaction-processor/internal/domain/model"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// maxExtractedSize limits the bytes written when decompressing or extracting a file
+	maxExtractedSize = 256 << 20
+	// maxContainerDepth limits how many compressed or archived layers are unwrapped, e.g. 2 for .tar.gz
+	maxContainerDepth = 3
+)
+
+// ErrExtractedSizeExceeded is returned when a compressed or archived file expands beyond maxExtractedSize
+var ErrExtractedSizeExceeded = errors.New("extracted size limit exceeded")
+
+// containerFormat identifies a compressed or archived file
+type containerFormat string
+
+const (
+	containerNone containerFormat = ""
+	containerGzip containerFormat = "gzip"
+	containerZstd containerFormat = "zstd"
+	containerZip  containerFormat = "zip"
+	containerTar  containerFormat = "tar"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	zipMagic  = []byte("PK\x03\x04")
+	tarMagic  = []byte("ustar")
+)
+
+// archiveEntry is a file extracted from an archive
+type archiveEntry struct {
+	name string
+	path string
+}
+
+// detectContainer identifies compressed and archived files by their magic bytes
+func detectContainer(head []byte) containerFormat {
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		return containerGzip
+	case bytes.HasPrefix(head, zstdMagic):
+		return containerZstd
+	case bytes.HasPrefix(head, zipMagic):
+		return containerZip
+	case len(head) >= 262 && bytes.Equal(head[257:262], tarMagic):
+		return containerTar
+	default:
+		return containerNone
+	}
+}
+
+// decompress writes the decompressed content of a gzip or zstd file into a temporary directory,
+// naming it after the compressed file without its compression extension. The returned function
+// removes the temporary directory.
+func decompress(filePath string, format containerFormat) (string, func(), error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader
+	switch format {
+	case containerGzip:
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return "", nil, fmt.Errorf("error reading gzip file: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case containerZstd:
+		zstdReader, err := zstd.NewReader(file)
+		if err != nil {
+			return "", nil, fmt.Errorf("error reading zstd file: %w", err)
+		}
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		return "", nil, fmt.Errorf("unsupported compression format: %s", format)
+	}
+
+	dir, err := os.MkdirTemp("", "statement-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	target := filepath.Join(dir, decompressedName(filepath.Base(filePath)))
+	if _, err := extractFile(target, reader, maxExtractedSize); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("error decompressing %s file: %w", format, err)
+	}
+
+	return target, cleanup, nil
+}
+
+// extractArchive writes the regular files of a zip or tar archive into a temporary directory.
+// Directories and hidden files, such as macOS resource forks, are skipped. The returned function
+// removes the temporary directory.
+func extractArchive(filePath string, format containerFormat) ([]archiveEntry, func(), error) {
+	dir, err := os.MkdirTemp("", "statement-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	var entries []archiveEntry
+	remaining := int64(maxExtractedSize)
+
+	// Entries are extracted into numbered directories so names from different folders cannot collide
+	// and names with path elements cannot escape the temporary directory
+	extract := func(name string, reader io.Reader) error {
+		if isHiddenEntry(name) {
+			return nil
+		}
+
+		entryDir := filepath.Join(dir, strconv.Itoa(len(entries)))
+		if err := os.Mkdir(entryDir, 0o700); err != nil {
+			return err
+		}
+		target := filepath.Join(entryDir, path.Base(name))
+		written, err := extractFile(target, reader, remaining)
+		if err != nil {
+			return fmt.Errorf("error extracting %s: %w", name, err)
+		}
+		remaining -= written
+
+		entries = append(entries, archiveEntry{name: name, path: target})
+		return nil
+	}
+
+	switch format {
+	case containerZip:
+		err = extractZip(filePath, extract)
+	case containerTar:
+		err = extractTar(filePath, extract)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s", format)
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return entries, cleanup, nil
+}
+
+// extractZip calls extract with each regular file of a zip archive
+func extractZip(filePath string, extract func(name string, reader io.Reader) error) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading zip file: %w", err)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("error reading zip entry %s: %w", file.Name, err)
+		}
+		err = extract(file.Name, reader)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractTar calls extract with each regular file of a tar archive
+func extractTar(filePath string, extract func(name string, reader io.Reader) error) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading tar file: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := extract(header.Name, reader); err != nil {
+			return err
+		}
+	}
+}
+
+// extractFile copies at most limit bytes from reader into a new file
+func extractFile(target string, reader io.Reader, limit int64) (int64, error) {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, io.LimitReader(reader, limit+1))
+	if err != nil {
+		return written, err
+	}
+	if written > limit {
+		return written, ErrExtractedSizeExceeded
+	}
+
+	return written, nil
+}
+
+// decompressedName removes the compression extension of a file name, e.g. statements.tar.gz becomes
+// statements.tar and statements.tgz becomes statements.tar
+func decompressedName(name string) string {
+	extension := filepath.Ext(name)
+	switch strings.ToLower(extension) {
+	case ".gz", ".gzip", ".zst", ".zstd":
+		return strings.TrimSuffix(name, extension)
+	case ".tgz":
+		return strings.TrimSuffix(name, extension) + ".tar"
+	default:
+		return name
+	}
+}
+
+// isHiddenEntry reports whether an archive entry is a hidden file or in a hidden folder
+func isHiddenEntry(name string) bool {
+	for _, element := range strings.Split(name, "/") {
+		if strings.HasPrefix(element, ".") || element == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// newArchiveStatement aggregates the statements of the files of an archive. The transactions of every
+// file are merged, while the declared balances are kept per part unless the archive holds a single file.
+func newArchiveStatement(parts []*model.Statement) *model.Statement {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	statement := &model.Statement{
+		AccountID: parts[0].AccountID,
+		Currency:  parts[0].Currency,
+		Parts:     parts,
+	}
+	for _, part := range parts {
+		statement.Transactions = append(statement.Transactions, part.Transactions...)
+		for _, mismatch := range part.BalanceMismatches {
+			statement.BalanceMismatches = append(statement.BalanceMismatches, part.Source+": "+mismatch)
+		}
+		if part.AccountID != statement.AccountID {
+			statement.AccountID = ""
+		}
+		if part.Currency != statement.Currency {
+			statement.Currency = ""
+		}
+	}
+
+	return statement
+}
+
+// namespaceArchiveIDs prefixes the ID of every transaction of the parts of an archive with the source of
+// its part. Files commonly number their transactions from the start, so without it the transactions of
+// different files sharing an ID would be stored as versions of the same transaction.
+func namespaceArchiveIDs(statement *model.Statement) {
+	for _, part := range statement.Parts {
+		for _, tx := range part.Transactions {
+			tx.ID = part.Source + ":" + tx.ID
+		}
+	}
+}
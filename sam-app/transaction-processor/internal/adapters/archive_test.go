@@ -0,0 +1,251 @@
+package adapters
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+const archiveCSVContent = "Id,Date,Transaction\n0,7/15,+60.5\n1,7/28,-10.3\n"
+
+// archiveFile is a file added to a test archive
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+func gzipContent(t *testing.T, content []byte) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(content); err != nil {
+		t.Fatalf("Failed to write gzip content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func zstdContent(t *testing.T, content []byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("Failed to create zstd encoder: %v", err)
+	}
+	defer encoder.Close()
+	return encoder.EncodeAll(content, nil)
+}
+
+func zipContent(t *testing.T, files ...archiveFile) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range files {
+		entry, err := writer.Create(file.name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry: %v", err)
+		}
+		if _, err := entry.Write(file.content); err != nil {
+			t.Fatalf("Failed to write zip entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close zip writer: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func tarContent(t *testing.T, files ...archiveFile) []byte {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(file.name, "/") {
+			header = &tar.Header{Name: file.name, Mode: 0755, Typeflag: tar.TypeDir}
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		if _, err := writer.Write(file.content); err != nil {
+			t.Fatalf("Failed to write tar entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close tar writer: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestReaderRegistry_ReadArchivedStatements(t *testing.T) {
+	tempDir := t.TempDir()
+	registry := NewDefaultReaderRegistry()
+
+	writeFile := func(t *testing.T, name string, content []byte) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		return filePath
+	}
+
+	camtContent := []byte(strings.Replace(camtStatementTemplate, "CLOSING", "3379.50", 1))
+	csvContent := []byte(archiveCSVContent)
+
+	t.Run("gzip file", func(t *testing.T) {
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "transactions.csv.gz", gzipContent(t, csvContent)))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 2)
+		assert.Empty(t, statement.Parts)
+	})
+
+	t.Run("zstd file keeps declared balances", func(t *testing.T) {
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "statement.xml.zst", zstdContent(t, camtContent)))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 3)
+		assert.Equal(t, 3379.5, statement.ClosingBalance.Amount)
+	})
+
+	t.Run("compressed file detected without extension", func(t *testing.T) {
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "upload", gzipContent(t, camtContent)))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 3)
+	})
+
+	t.Run("zip archive reads each entry with its own reader", func(t *testing.T) {
+		content := zipContent(t,
+			archiveFile{name: "january/statement.xml", content: camtContent},
+			archiveFile{name: "january/card.csv", content: csvContent},
+			archiveFile{name: "__MACOSX/january/._card.csv", content: []byte("resource fork")},
+			archiveFile{name: "january/.DS_Store", content: []byte("finder")},
+		)
+
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "statements.zip", content))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 5)
+		assert.Nil(t, statement.ClosingBalance)
+		if assert.Len(t, statement.Parts, 2) {
+			assert.Equal(t, "january/statement.xml", statement.Parts[0].Source)
+			assert.Len(t, statement.Parts[0].Transactions, 3)
+			assert.Equal(t, 3379.5, statement.Parts[0].ClosingBalance.Amount)
+			assert.Equal(t, "january/card.csv", statement.Parts[1].Source)
+			assert.Len(t, statement.Parts[1].Transactions, 2)
+		}
+	})
+
+	t.Run("tar.gz archive", func(t *testing.T) {
+		content := gzipContent(t, tarContent(t,
+			archiveFile{name: "statements/"},
+			archiveFile{name: "statements/checking.csv", content: csvContent},
+			archiveFile{name: "statements/savings.csv", content: csvContent},
+		))
+
+		transactions, err := registry.ReadTransactions(context.Background(), writeFile(t, "statements.tgz", content))
+		if err != nil {
+			t.Fatalf("ReadTransactions failed: %v", err)
+		}
+		assert.Len(t, transactions, 4)
+	})
+
+	t.Run("transaction IDs are namespaced by entry", func(t *testing.T) {
+		content := zipContent(t,
+			archiveFile{name: "checking.csv", content: csvContent},
+			archiveFile{name: "savings.csv", content: csvContent},
+		)
+
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "colliding.zip", content))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		var ids []string
+		for _, tx := range statement.Transactions {
+			ids = append(ids, tx.ID)
+		}
+		assert.Equal(t, []string{"checking.csv:0", "checking.csv:1", "savings.csv:0", "savings.csv:1"}, ids)
+		assert.Equal(t, "savings.csv:0", statement.Parts[1].Transactions[0].ID)
+	})
+
+	t.Run("nested archive parts are flattened", func(t *testing.T) {
+		inner := zipContent(t,
+			archiveFile{name: "checking.csv", content: csvContent},
+			archiveFile{name: "savings.csv", content: csvContent},
+		)
+		content := zipContent(t,
+			archiveFile{name: "bank.zip", content: inner},
+			archiveFile{name: "card.csv.gz", content: gzipContent(t, csvContent)},
+		)
+
+		statement, err := registry.ReadStatement(context.Background(), writeFile(t, "nested.zip", content))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		var sources []string
+		for _, part := range statement.Parts {
+			sources = append(sources, part.Source)
+		}
+		assert.Equal(t, []string{"bank.zip/checking.csv", "bank.zip/savings.csv", "card.csv.gz"}, sources)
+		assert.Len(t, statement.Transactions, 6)
+		assert.Equal(t, "bank.zip/savings.csv:0", statement.Transactions[2].ID)
+	})
+
+	t.Run("unsupported entry names the entry", func(t *testing.T) {
+		content := zipContent(t,
+			archiveFile{name: "card.csv", content: csvContent},
+			archiveFile{name: "notes.txt", content: []byte("hello")},
+		)
+
+		_, err := registry.ReadStatement(context.Background(), writeFile(t, "mixed.zip", content))
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat), "expected ErrUnsupportedFormat, got %v", err)
+		assert.Contains(t, err.Error(), "archive entry notes.txt")
+	})
+
+	t.Run("archive without statement files", func(t *testing.T) {
+		content := zipContent(t, archiveFile{name: ".hidden.csv", content: csvContent})
+
+		_, err := registry.ReadStatement(context.Background(), writeFile(t, "empty.zip", content))
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat), "expected ErrUnsupportedFormat, got %v", err)
+	})
+
+	t.Run("too many nested layers", func(t *testing.T) {
+		content := csvContent
+		for i := 0; i <= maxContainerDepth; i++ {
+			content = gzipContent(t, content)
+		}
+
+		_, err := registry.ReadStatement(context.Background(), writeFile(t, "layers.gz", content))
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat), "expected ErrUnsupportedFormat, got %v", err)
+	})
+}
+
+func TestExtractFile_SizeLimit(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "large.csv")
+
+	_, err := extractFile(target, strings.NewReader(archiveCSVContent), 10)
+	assert.True(t, errors.Is(err, ErrExtractedSizeExceeded), "expected ErrExtractedSizeExceeded, got %v", err)
+
+	written, err := extractFile(filepath.Join(filepath.Dir(target), "small.csv"), strings.NewReader(archiveCSVContent), 1024)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(archiveCSVContent)), written)
+}
+
+func TestDecompressedName(t *testing.T) {
+	assert.Equal(t, "statements.tar", decompressedName("statements.tar.gz"))
+	assert.Equal(t, "statements.tar", decompressedName("statements.tgz"))
+	assert.Equal(t, "statement.xml", decompressedName("statement.xml.zst"))
+	assert.Equal(t, "upload", decompressedName("upload"))
+}
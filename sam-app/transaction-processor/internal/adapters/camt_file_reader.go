@@ -0,0 +1,359 @@
+package adapters
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// CAMTFileReader implements the FileReader and StatementReader ports for ISO 20022 camt.053
+// bank to customer statements. Elements are matched by local name, so every camt.053.001 version
+// is supported.
+type CAMTFileReader struct{}
+
+// NewCAMTFileReader creates a new CAMTFileReader
+func NewCAMTFileReader() *CAMTFileReader {
+	return &CAMTFileReader{}
+}
+
+// camtDocument is the subset of a camt.053 document mapped into statements
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID       string                  `xml:"Id"`
+	IBAN     string                  `xml:"Acct>Id>IBAN"`
+	OtherID  string                  `xml:"Acct>Id>Othr>Id"`
+	Currency string                  `xml:"Acct>Ccy"`
+	Balances []camtBalance           `xml:"Bal"`
+	Summary  camtTransactionsSummary `xml:"TxsSummry"`
+	Entries  []camtEntry             `xml:"Ntry"`
+}
+
+// camtTransactionsSummary holds the number and sum of the credit and debit entries of a statement
+type camtTransactionsSummary struct {
+	Credit camtNumberAndSum `xml:"TtlCdtNtries"`
+	Debit  camtNumberAndSum `xml:"TtlDbtNtries"`
+}
+
+type camtNumberAndSum struct {
+	Count string `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtBalance struct {
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	EntryRef            string                  `xml:"NtryRef"`
+	Amount              camtAmount              `xml:"Amt"`
+	Indicator           string                  `xml:"CdtDbtInd"`
+	Status              camtEntryStatus         `xml:"Sts"`
+	BookingDate         camtDate                `xml:"BookgDt"`
+	ValueDate           camtDate                `xml:"ValDt"`
+	ServicerRef         string                  `xml:"AcctSvcrRef"`
+	Domain              string                  `xml:"BkTxCd>Domn>Cd"`
+	Proprietary         string                  `xml:"BkTxCd>Prtry>Cd"`
+	Details             []camtTransactionDetail `xml:"NtryDtls>TxDtls"`
+	AdditionalEntryInfo string                  `xml:"AddtlNtryInf"`
+}
+
+type camtTransactionDetail struct {
+	EndToEndID   string   `xml:"Refs>EndToEndId"`
+	ServicerRef  string   `xml:"Refs>AcctSvcrRef"`
+	Unstructured []string `xml:"RmtInf>Ustrd"`
+	CreditorRef  string   `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtEntryStatus holds the entry status, which is a code in camt.053.001.02 to .07 and an
+// aggregate with a Cd element in later versions
+type camtEntryStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// ReadTransactions reads transactions from a camt.053 file
+func (r *CAMTFileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := r.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads a camt.053 file and validates the declared opening and closing balances of
+// each statement against its booked entries, unless balances are lenient in ctx. Files with several statements, usually consecutive
+// days of the same account, are merged keeping the opening balance of the first one and the closing
+// balance of the last one.
+func (r *CAMTFileReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	var document camtDocument
+	if err := xml.NewDecoder(file).Decode(&document); err != nil {
+		return nil, fmt.Errorf("error decoding camt.053 XML: %w", err)
+	}
+	if len(document.Statements) == 0 {
+		return nil, fmt.Errorf("invalid camt.053 format, no Stmt element found")
+	}
+
+	statements := make([]*model.Statement, 0, len(document.Statements))
+	for _, stmt := range document.Statements {
+		statement, err := stmt.toStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := statement.ValidateBalances(); err != nil {
+			if !ports.LenientBalances(ctx) {
+				return nil, fmt.Errorf("statement %s: %w", stmt.ID, err)
+			}
+			statement.BalanceMismatches = append(statement.BalanceMismatches, fmt.Sprintf("statement %s: %v", stmt.ID, err))
+		}
+		statements = append(statements, statement)
+	}
+
+	return model.MergeStatements(statements)
+}
+
+// toStatement maps a camt.053 statement and its entries. Informational entries are skipped.
+func (s camtStatement) toStatement() (*model.Statement, error) {
+	statement := &model.Statement{
+		AccountID: s.IBAN,
+		Currency:  s.Currency,
+	}
+	if statement.AccountID == "" {
+		statement.AccountID = s.OtherID
+	}
+
+	for _, balance := range s.Balances {
+		declared, err := balance.toDeclaredBalance()
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: %w", s.ID, err)
+		}
+
+		switch balance.Type {
+		case "OPBD", "PRCD":
+			statement.OpeningBalance = declared
+		case "CLBD":
+			statement.ClosingBalance = declared
+		}
+	}
+
+	controlTotals, err := s.Summary.toControlTotals()
+	if err != nil {
+		return nil, fmt.Errorf("statement %s: %w", s.ID, err)
+	}
+	statement.ControlTotals = controlTotals
+
+	for i, entry := range s.Entries {
+		status, ok := entry.transactionStatus()
+		if !ok {
+			continue
+		}
+
+		tx, err := entry.toTransaction(fmt.Sprintf("%s-%d", s.ID, i+1))
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: %w", s.ID, err)
+		}
+		tx.Status = status
+
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+
+	return statement, nil
+}
+
+// toControlTotals maps the credit and debit entry totals of the summary. It returns nil when the
+// statement has no summary.
+func (s camtTransactionsSummary) toControlTotals() (*model.ControlTotals, error) {
+	if s.Credit.Count == "" && s.Debit.Count == "" {
+		return nil, nil
+	}
+
+	creditCount, creditSum, err := s.Credit.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credit entries summary: %w", err)
+	}
+	debitCount, debitSum, err := s.Debit.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid debit entries summary: %w", err)
+	}
+
+	return &model.ControlTotals{
+		Count:       creditCount + debitCount,
+		TotalCredit: creditSum,
+		TotalDebit:  debitSum,
+	}, nil
+}
+
+// parse returns the number and sum of entries, which are zero when missing
+func (n camtNumberAndSum) parse() (int, float64, error) {
+	var count int
+	var sum float64
+	var err error
+	if value := strings.TrimSpace(n.Count); value != "" {
+		if count, err = strconv.Atoi(value); err != nil {
+			return 0, 0, fmt.Errorf("invalid number of entries: %s", n.Count)
+		}
+	}
+	if value := strings.TrimSpace(n.Sum); value != "" {
+		if sum, err = strconv.ParseFloat(value, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid sum: %s", n.Sum)
+		}
+	}
+	return count, sum, nil
+}
+
+// toDeclaredBalance maps a booked balance, which is negative when its indicator is DBIT
+func (b camtBalance) toDeclaredBalance() (*model.DeclaredBalance, error) {
+	amount, err := b.Amount.parse(b.Indicator)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s balance: %w", b.Type, err)
+	}
+
+	date, err := b.Date.parse()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s balance: %w", b.Type, err)
+	}
+
+	return &model.DeclaredBalance{Amount: amount, Date: date}, nil
+}
+
+// transactionStatus maps the entry status. It returns false for informational entries.
+func (e camtEntry) transactionStatus() (model.TransactionStatus, bool) {
+	code := strings.TrimSpace(e.Status.Code)
+	if code == "" {
+		code = strings.TrimSpace(e.Status.Text)
+	}
+
+	switch code {
+	case "INFO":
+		return "", false
+	case "PDNG", "FUTR":
+		return model.TransactionStatusPending, true
+	default:
+		return model.TransactionStatusPosted, true
+	}
+}
+
+// toTransaction maps an entry into a transaction. The ID is the entry reference, falling back to
+// the account servicer reference and then to the position of the entry in the statement.
+func (e camtEntry) toTransaction(fallbackID string) (*model.Transaction, error) {
+	amount, err := e.Amount.parse(e.Indicator)
+	if err != nil {
+		return nil, fmt.Errorf("error creating transaction %s: %w", fallbackID, err)
+	}
+
+	bookingDate, err := e.BookingDate.parse()
+	if err != nil {
+		return nil, fmt.Errorf("error creating transaction %s: %w", fallbackID, err)
+	}
+
+	tx := &model.Transaction{
+		ID:        firstNonEmpty(e.EntryRef, e.ServicerRef, fallbackID),
+		Date:      time.Date(bookingDate.Year(), bookingDate.Month(), bookingDate.Day(), 0, 0, 0, 0, time.UTC),
+		Amount:    math.Abs(amount),
+		IsCredit:  amount >= 0,
+		Type:      firstNonEmpty(e.Domain, e.Proprietary),
+		Reference: e.ServicerRef,
+	}
+
+	if e.ValueDate.Date != "" || e.ValueDate.DateTime != "" {
+		valueDate, err := e.ValueDate.parse()
+		if err != nil {
+			return nil, fmt.Errorf("error creating transaction %s: %w", tx.ID, err)
+		}
+		tx.ValueDate = time.Date(valueDate.Year(), valueDate.Month(), valueDate.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	var remittance []string
+	for _, detail := range e.Details {
+		if tx.Reference == "" {
+			tx.Reference = firstNonEmpty(detail.ServicerRef, detail.EndToEndID)
+		}
+		for _, line := range detail.Unstructured {
+			if line = strings.TrimSpace(line); line != "" {
+				remittance = append(remittance, line)
+			}
+		}
+		if ref := strings.TrimSpace(detail.CreditorRef); ref != "" {
+			remittance = append(remittance, ref)
+		}
+	}
+	if len(remittance) > 0 {
+		tx.Description = strings.Join(remittance, " ")
+	} else {
+		tx.Description = strings.TrimSpace(e.AdditionalEntryInfo)
+	}
+
+	return tx, nil
+}
+
+// parse returns the amount, negative when the credit/debit indicator is DBIT
+func (a camtAmount) parse(indicator string) (float64, error) {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(a.Value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", a.Value)
+	}
+
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return amount, nil
+	case "DBIT":
+		return -amount, nil
+	default:
+		return 0, fmt.Errorf("invalid credit/debit indicator: %s", indicator)
+	}
+}
+
+// parse returns the date, or the date and time when only DtTm is set
+func (d camtDate) parse() (time.Time, error) {
+	if value := strings.TrimSpace(d.Date); value != "" {
+		return time.Parse("2006-01-02", value)
+	}
+
+	value := strings.TrimSpace(d.DateTime)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("missing date")
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Parse("2006-01-02T15:04:05", value)
+}
+
+// firstNonEmpty returns the first non empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const camtStatementTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG1</MsgId><CreDtTm>2025-01-31T18:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT-2025-01</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-01-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">CLOSING</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2025-01-31</Dt></Dt>
+      </Bal>
+      <TxsSummry>
+        <TtlNtries><NbOfNtries>3</NbOfNtries></TtlNtries>
+        <TtlCdtNtries><NbOfNtries>1</NbOfNtries><Sum>2500.00</Sum></TtlCdtNtries>
+        <TtlDbtNtries><NbOfNtries>2</NbOfNtries><Sum>160.50</Sum></TtlDbtNtries>
+      </TxsSummry>
+      <Ntry>
+        <NtryRef>E1</NtryRef>
+        <Amt Ccy="EUR">2500.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2025-01-03</Dt></BookgDt>
+        <ValDt><Dt>2025-01-04</Dt></ValDt>
+        <AcctSvcrRef>BANKREF1</AcctSvcrRef>
+        <BkTxCd><Domn><Cd>PMNT</Cd></Domn></BkTxCd>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>E2E-1</EndToEndId></Refs>
+          <RmtInf><Ustrd>Salary</Ustrd><Ustrd>January 2025</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">120.50</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><DtTm>2025-01-10T09:30:00+01:00</DtTm></BookgDt>
+        <AddtlNtryInf>Card payment</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">40.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2025-01-30</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">1.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>INFO</Sts>
+        <BookgDt><Dt>2025-01-30</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestCAMTFileReader_ReadStatement(t *testing.T) {
+	tempDir := t.TempDir()
+	reader := NewCAMTFileReader()
+
+	writeStatement := func(t *testing.T, name, closing string) string {
+		filePath := filepath.Join(tempDir, name)
+		content := strings.Replace(camtStatementTemplate, "CLOSING", closing, 1)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test camt.053 file: %v", err)
+		}
+		return filePath
+	}
+
+	t.Run("valid statement", func(t *testing.T) {
+		filePath := writeStatement(t, "statement.xml", "3379.50")
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		assert.Equal(t, "DE89370400440532013000", statement.AccountID)
+		assert.Equal(t, "EUR", statement.Currency)
+		assert.Equal(t, 1000.0, statement.OpeningBalance.Amount)
+		assert.Equal(t, 3379.5, statement.ClosingBalance.Amount)
+		assert.Equal(t, &model.ControlTotals{Count: 3, TotalCredit: 2500, TotalDebit: 160.5}, statement.ControlTotals)
+		if len(statement.Transactions) != 3 {
+			t.Fatalf("Expected 3 transactions, got %d", len(statement.Transactions))
+		}
+
+		salary := statement.Transactions[0]
+		assert.Equal(t, "E1", salary.ID)
+		assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), salary.Date)
+		assert.Equal(t, time.Date(2025, time.January, 4, 0, 0, 0, 0, time.UTC), salary.ValueDate)
+		assert.Equal(t, 2500.0, salary.Amount)
+		assert.True(t, salary.IsCredit)
+		assert.Equal(t, "BANKREF1", salary.Reference)
+		assert.Equal(t, "PMNT", salary.Type)
+		assert.Equal(t, "Salary January 2025", salary.Description)
+		assert.True(t, salary.IsPosted())
+
+		card := statement.Transactions[1]
+		assert.Equal(t, "STMT-2025-01-2", card.ID)
+		assert.Equal(t, 120.5, card.Amount)
+		assert.False(t, card.IsCredit)
+		assert.Equal(t, "Card payment", card.Description)
+
+		// Pending entries are read but not part of the booked balance
+		assert.True(t, statement.Transactions[2].IsPending())
+	})
+
+	t.Run("closing balance mismatch", func(t *testing.T) {
+		filePath := writeStatement(t, "mismatch.xml", "3339.50")
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.True(t, errors.Is(err, model.ErrBalanceMismatch), "expected ErrBalanceMismatch, got %v", err)
+
+		// With lenient balances the statement is read and the mismatch recorded
+		statement, err := reader.ReadStatement(ports.WithLenientBalances(context.Background()), filePath)
+		assert.NoError(t, err)
+		assert.Len(t, statement.Transactions, 3)
+		assert.Len(t, statement.BalanceMismatches, 1)
+	})
+
+	t.Run("invalid XML", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "invalid.xml")
+		if err := os.WriteFile(filePath, []byte("Id,Date,Transaction"), 0644); err != nil {
+			t.Fatalf("Failed to write test camt.053 file: %v", err)
+		}
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("non-existent file", func(t *testing.T) {
+		_, err := reader.ReadTransactions(context.Background(), filepath.Join(tempDir, "non_existent.xml"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/ianaindex"
+	textunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+// lookupCSVEncoding returns the encoding with an IANA name or alias, e.g. utf-8, utf-16le, windows-1252
+// or iso-8859-1. An empty name returns nil, meaning the encoding is detected.
+func lookupCSVEncoding(name string) (encoding.Encoding, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("unsupported encoding %s", name)
+	}
+	return enc, nil
+}
+
+// newCSVDecoder returns a reader decoding a CSV file into UTF-8 without byte order mark. Files with a
+// UTF-8 or UTF-16 byte order mark are decoded accordingly, whatever the encoding. Otherwise the named
+// encoding is used or, when there is none, the file is read as UTF-8 when the whole of it is valid UTF-8
+// and as Windows-1252, a superset of the printable Latin-1 characters, when it is not.
+func newCSVDecoder(reader io.ReadSeeker, encodingName string) (io.Reader, error) {
+	enc, err := lookupCSVEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+
+	if enc == nil {
+		if enc, err = sniffCSVEncoding(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+	return transform.NewReader(buffered, textunicode.BOMOverride(enc.NewDecoder())), nil
+}
+
+// sniffCSVEncoding detects the encoding of a CSV file and rewinds it. Files whose start is valid UTF-8
+// are validated to the end, so a Windows-1252 character past the start does not decode as garbage.
+func sniffCSVEncoding(reader io.ReadSeeker) (encoding.Encoding, error) {
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+	head, err := buffered.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	enc := detectCSVEncoding(head, len(head) == sniffSize)
+	if enc == textunicode.UTF8 && !hasUnicodeBOM(head) && len(head) == sniffSize {
+		valid, err := validUTF8(buffered)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			enc = charmap.Windows1252
+		}
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error rewinding file: %w", err)
+	}
+	return enc, nil
+}
+
+// detectCSVEncoding picks UTF-8 for content with a byte order mark or valid UTF-8, and Windows-1252 otherwise
+func detectCSVEncoding(head []byte, truncated bool) encoding.Encoding {
+	if hasUnicodeBOM(head) {
+		return textunicode.UTF8
+	}
+
+	// A full head may end in the middle of a multi-byte character
+	if truncated {
+		head = trimIncompleteRune(head)
+	}
+	if utf8.Valid(head) {
+		return textunicode.UTF8
+	}
+	return charmap.Windows1252
+}
+
+// hasUnicodeBOM reports whether content starts with a UTF-8 or UTF-16 byte order mark
+func hasUnicodeBOM(content []byte) bool {
+	return bytes.HasPrefix(content, utf8BOM) || bytes.HasPrefix(content, utf16LEBOM) || bytes.HasPrefix(content, utf16BEBOM)
+}
+
+// validUTF8 reports whether the rest of a reader is valid UTF-8, reading it a chunk at a time. A character
+// cut off by the end is ignored, as sniffed heads of files end anywhere.
+func validUTF8(reader io.Reader) (bool, error) {
+	buffer := make([]byte, sniffSize+utf8.UTFMax)
+	carried := 0
+	for {
+		n, err := reader.Read(buffer[carried:])
+		chunk := buffer[:carried+n]
+
+		// A character split across reads is checked with the next chunk
+		complete := trimIncompleteRune(chunk)
+		if !utf8.Valid(complete) {
+			return false, nil
+		}
+		carried = copy(buffer, chunk[len(complete):])
+
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("error reading file: %w", err)
+		}
+	}
+}
+
+// trimIncompleteRune drops the multi-byte character content ends in the middle of, if any
+func trimIncompleteRune(content []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(content); i++ {
+		if utf8.RuneStart(content[len(content)-i]) {
+			if !utf8.FullRune(content[len(content)-i:]) {
+				return content[:len(content)-i]
+			}
+			break
+		}
+	}
+	return content
+}
+
+// decodeCSVHead decodes the start of a CSV file for sniffing, ignoring undecodable trailing bytes
+func decodeCSVHead(head []byte, encodingName string) []byte {
+	reader, err := newCSVDecoder(bytes.NewReader(head), encodingName)
+	if err != nil {
+		return head
+	}
+	decoded, _ := io.ReadAll(reader)
+	return decoded
+}
+
+// normalizeCSVValue trims a field and collapses runs of whitespace, including non-breaking spaces,
+// into a single space. Zero-width characters and stray byte order marks are removed.
+func normalizeCSVValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case '\ufeff', '\u200b', '\u200c', '\u200d', '\u2060':
+			return -1
+		}
+		return r
+	}, value)
+	return strings.Join(strings.FieldsFunc(value, unicode.IsSpace), " ")
+}
+
+// csvHeaderEqual reports whether a header name matches a mapped column name, ignoring case and whitespace
+func csvHeaderEqual(header, name string) bool {
+	return strings.EqualFold(normalizeCSVValue(header), normalizeCSVValue(name))
+}
@@ -1,23 +1,52 @@
 package adapters
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 	"transaction-processor/internal/domain/model"
 )
 
-// CSVFileReader implements the FileReader port for CSV files
-type CSVFileReader struct{}
+// CSVFileReader implements the FileReader and StatementReader ports for CSV files
+type CSVFileReader struct {
+	profile CSVProfile
+}
 
-// NewCSVFileReader creates a new CSVFileReader
+// NewCSVFileReader creates a new CSVFileReader for the Id,Date,Transaction format
 func NewCSVFileReader() *CSVFileReader {
-	return &CSVFileReader{}
+	return NewCSVFileReaderWithProfile(DefaultCSVProfile())
+}
+
+// NewCSVFileReaderWithProfile creates a new CSVFileReader using the column mapping and dialect of a profile
+func NewCSVFileReaderWithProfile(profile CSVProfile) *CSVFileReader {
+	return &CSVFileReader{
+		profile: profile,
+	}
+}
+
+// csvColumnIndexes holds the position of each mapped column, or -1 when it is not in the file
+type csvColumnIndexes struct {
+	id, date, amount, debit, credit, category, description, status int
 }
 
 // ReadTransactions reads transactions from a CSV file
-func (r *CSVFileReader) ReadTransactions(filePath string) ([]*model.Transaction, error) {
+func (r *CSVFileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := r.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads the transactions of a CSV file and the control totals of its trailer record, if
+// the profile has one and the file ends with it
+func (r *CSVFileReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
 	// Open the file
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -25,23 +54,46 @@ func (r *CSVFileReader) ReadTransactions(filePath string) ([]*model.Transaction,
 	}
 	defer file.Close()
 
-	// Create a CSV reader
-	reader := csv.NewReader(file)
+	// Decode the file into UTF-8, dropping any byte order mark
+	source, err := newCSVDecoder(file, r.profile.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CSV file: %w", err)
+	}
+
+	// Create a CSV reader. encoding/csv only supports double quotes, so other quote characters are
+	// swapped with double quotes while reading and swapped back in each field.
+	quote := r.profile.Quote
+	if quote != "" && quote != `"` {
+		source = &quoteSwapReader{reader: source, quote: quote[0]}
+	}
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	if r.profile.Delimiter != "" {
+		reader.Comma = []rune(r.profile.Delimiter)[0]
+	}
 
 	// Read the header
-	header, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	var header []string
+	if !r.profile.NoHeader {
+		header, err = reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV header: %w", err)
+		}
 	}
 
-	// Validate header
-	if len(header) < 3 || header[0] != "Id" || header[1] != "Date" || header[2] != "Transaction" {
-		return nil, fmt.Errorf("invalid CSV format, expected header: Id,Date,Transaction")
+	// Locate the columns
+	columns, err := r.locateColumns(header)
+	if err != nil {
+		return nil, err
 	}
 
-	// Read transactions
-	var transactions []*model.Transaction
-	for {
+	// Read transactions until the end of the file or its trailer
+	statement := &model.Statement{}
+	for row := 0; ; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped reading CSV file at record %d: %w", row+1, err)
+		}
+
 		record, err := reader.Read()
 		if err == io.EOF {
 			break
@@ -49,20 +101,267 @@ func (r *CSVFileReader) ReadTransactions(filePath string) ([]*model.Transaction,
 		if err != nil {
 			return nil, fmt.Errorf("error reading CSV record: %w", err)
 		}
+		if quote != "" && quote != `"` {
+			for i := range record {
+				record[i] = swapQuotes(record[i], quote[0])
+			}
+		}
 
-		// Validate record
-		if len(record) < 3 {
-			return nil, fmt.Errorf("invalid CSV record format, expected at least 3 fields")
+		if r.isTrailer(record) {
+			if statement.ControlTotals, err = r.parseTrailer(record); err != nil {
+				return nil, fmt.Errorf("error reading CSV trailer: %w", err)
+			}
+			if _, err := reader.Read(); err != io.EOF {
+				return nil, fmt.Errorf("invalid CSV file, the trailer must be the last record")
+			}
+			break
 		}
 
 		// Create transaction
-		tx, err := model.NewTransaction(record[0], record[1], record[2])
+		tx, err := r.newTransaction(record, columns, row)
 		if err != nil {
 			return nil, fmt.Errorf("error creating transaction: %w", err)
 		}
 
-		transactions = append(transactions, tx)
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+
+	return statement, nil
+}
+
+// isTrailer reports whether a record is the trailer of the file
+func (r *CSVFileReader) isTrailer(record []string) bool {
+	return r.profile.Trailer != "" && len(record) > 0 && strings.EqualFold(normalizeCSVValue(record[0]), r.profile.Trailer)
+}
+
+// parseTrailer reads the number of transactions and the credit and debit totals of a trailer record
+func (r *CSVFileReader) parseTrailer(record []string) (*model.ControlTotals, error) {
+	if len(record) < 4 {
+		return nil, fmt.Errorf("expected the number of transactions and the credit and debit totals")
+	}
+
+	count, err := strconv.Atoi(normalizeCSVValue(record[1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid number of transactions: %s", record[1])
+	}
+	credit, err := r.parseAmount(normalizeCSVValue(record[2]))
+	if err != nil {
+		return nil, err
+	}
+	debit, err := r.parseAmount(normalizeCSVValue(record[3]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ControlTotals{Count: count, TotalCredit: math.Abs(credit), TotalDebit: math.Abs(debit)}, nil
+}
+
+// locateColumns finds the position of the mapped columns, matching header names regardless of case
+// and whitespace. The ID, date and amount columns are required, while the category, description and
+// status columns are skipped when they are not in the header.
+func (r *CSVFileReader) locateColumns(header []string) (csvColumnIndexes, error) {
+	mapping := r.profile.Columns
+	locate := func(column CSVColumn, required bool) (int, error) {
+		if column.Index != nil {
+			return *column.Index, nil
+		}
+		if column.Name == "" {
+			return -1, nil
+		}
+		for i, name := range header {
+			if csvHeaderEqual(name, column.Name) {
+				return i, nil
+			}
+		}
+		if required {
+			return -1, fmt.Errorf("invalid CSV format, missing column %s in header", column.Name)
+		}
+		return -1, nil
+	}
+
+	var columns csvColumnIndexes
+	var err error
+	for _, c := range []struct {
+		index    *int
+		column   CSVColumn
+		required bool
+	}{
+		{&columns.id, mapping.ID, true},
+		{&columns.date, mapping.Date, true},
+		{&columns.amount, mapping.Amount, true},
+		{&columns.debit, mapping.Debit, true},
+		{&columns.credit, mapping.Credit, true},
+		{&columns.category, mapping.Category, false},
+		{&columns.description, mapping.Description, false},
+		{&columns.status, mapping.Status, false},
+	} {
+		if *c.index, err = locate(c.column, c.required); err != nil {
+			return csvColumnIndexes{}, err
+		}
 	}
 
-	return transactions, nil
+	return columns, nil
+}
+
+// newTransaction maps a record into a transaction. Rows are numbered from 0 when there is no ID column.
+func (r *CSVFileReader) newTransaction(record []string, columns csvColumnIndexes, row int) (*model.Transaction, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return normalizeCSVValue(record[i])
+	}
+
+	// Validate record
+	for _, i := range []int{columns.id, columns.date, columns.amount} {
+		if i >= len(record) {
+			return nil, fmt.Errorf("invalid CSV record format, expected at least %d fields", i+1)
+		}
+	}
+
+	id := field(columns.id)
+	if columns.id < 0 {
+		id = strconv.Itoa(row)
+	}
+
+	date, err := r.parseDate(field(columns.date))
+	if err != nil {
+		return nil, err
+	}
+
+	var amount float64
+	if columns.amount >= 0 {
+		amount, err = r.parseAmount(field(columns.amount))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		debit, credit := field(columns.debit), field(columns.credit)
+		if debit == "" && credit == "" {
+			return nil, fmt.Errorf("missing debit and credit amounts for transaction %s", id)
+		}
+		if debit != "" {
+			parsed, err := r.parseAmount(debit)
+			if err != nil {
+				return nil, err
+			}
+			amount -= math.Abs(parsed)
+		}
+		if credit != "" {
+			parsed, err := r.parseAmount(credit)
+			if err != nil {
+				return nil, err
+			}
+			amount += math.Abs(parsed)
+		}
+	}
+
+	tx := &model.Transaction{
+		ID:          id,
+		Date:        date,
+		Amount:      math.Abs(amount),
+		IsCredit:    amount >= 0,
+		Category:    field(columns.category),
+		Description: field(columns.description),
+	}
+
+	if value := field(columns.status); value != "" {
+		status, err := model.ParseTransactionStatus(value)
+		if err != nil {
+			return nil, err
+		}
+		tx.Status = status
+	}
+
+	return tx, nil
+}
+
+// parseAmount parses an amount with the thousands and decimal separators of the profile
+func (r *CSVFileReader) parseAmount(value string) (float64, error) {
+	normalized := value
+	if r.profile.ThousandsSeparator != "" {
+		normalized = strings.ReplaceAll(normalized, r.profile.ThousandsSeparator, "")
+	}
+	if r.profile.DecimalSeparator != "" && r.profile.DecimalSeparator != "." {
+		normalized = strings.Replace(normalized, r.profile.DecimalSeparator, ".", 1)
+	}
+
+	amount, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", value)
+	}
+	return amount, nil
+}
+
+// parseDate parses a date with the Go layout of the profile. Layouts without a year, such as the
+// default MM/DD format, use the current year.
+func (r *CSVFileReader) parseDate(value string) (time.Time, error) {
+	layout := r.profile.DateFormat
+	if layout == "" {
+		layout = DefaultCSVProfile().DateFormat
+	}
+
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+	}
+	if date.Year() == 0 {
+		date = time.Date(time.Now().Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return date, nil
+}
+
+// sniff reports whether the first line of a file, once decoded, holds the header of the profile. Files
+// without header, or whose date column is located by position, cannot be sniffed.
+func (r *CSVFileReader) sniff(head []byte) bool {
+	if r.profile.NoHeader || r.profile.Columns.Date.Name == "" {
+		return false
+	}
+
+	line, _, _ := strings.Cut(strings.TrimSpace(string(decodeCSVHead(head, r.profile.Encoding))), "\n")
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.LazyQuotes = true
+	if r.profile.Delimiter != "" {
+		reader.Comma = []rune(r.profile.Delimiter)[0]
+	}
+	header, err := reader.Read()
+	if err != nil {
+		return false
+	}
+
+	_, err = r.locateColumns(header)
+	return err == nil
+}
+
+// quoteSwapReader swaps a quote character with double quotes
+type quoteSwapReader struct {
+	reader io.Reader
+	quote  byte
+}
+
+func (q *quoteSwapReader) Read(p []byte) (int, error) {
+	n, err := q.reader.Read(p)
+	for i := 0; i < n; i++ {
+		switch p[i] {
+		case q.quote:
+			p[i] = '"'
+		case '"':
+			p[i] = q.quote
+		}
+	}
+	return n, err
+}
+
+// swapQuotes restores the quote characters swapped by quoteSwapReader in a field
+func swapQuotes(value string, quote byte) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '"':
+			return rune(quote)
+		case rune(quote):
+			return '"'
+		}
+		return r
+	}, value)
 }
@@ -1,10 +1,15 @@
 package adapters
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+	"transaction-processor/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCSVFileReader_ReadTransactions(t *testing.T) {
@@ -29,7 +34,7 @@ func TestCSVFileReader_ReadTransactions(t *testing.T) {
 	reader := NewCSVFileReader()
 
 	// Call the method under test
-	transactions, err := reader.ReadTransactions(testFilePath)
+	transactions, err := reader.ReadTransactions(context.Background(), testFilePath)
 	if err != nil {
 		t.Fatalf("ReadTransactions failed: %v", err)
 	}
@@ -72,17 +77,245 @@ func TestCSVFileReader_ReadTransactions(t *testing.T) {
 		invalidFilePath := filepath.Join(tempDir, "invalid_header.csv")
 		os.WriteFile(invalidFilePath, []byte(invalidCsvContent), 0644)
 
-		_, err := reader.ReadTransactions(invalidFilePath)
+		_, err := reader.ReadTransactions(context.Background(), invalidFilePath)
 		if err == nil {
 			t.Error("Expected an error for invalid header, got nil")
 		}
 	})
 
+	// --- Test Case: Optional category column ---
+	t.Run("category column", func(t *testing.T) {
+		categoryCsvContent := `Id,Date,Transaction,Category
+0,7/15,+60.5,Salary
+1,7/28,-10.3,`
+		categoryFilePath := filepath.Join(tempDir, "category.csv")
+		os.WriteFile(categoryFilePath, []byte(categoryCsvContent), 0644)
+
+		transactions, err := reader.ReadTransactions(context.Background(), categoryFilePath)
+		if err != nil {
+			t.Fatalf("ReadTransactions failed: %v", err)
+		}
+		if transactions[0].GetCategory() != "Salary" {
+			t.Errorf("Expected category 'Salary', got '%s'", transactions[0].GetCategory())
+		}
+		if transactions[1].GetCategory() != "Uncategorized" {
+			t.Errorf("Expected category 'Uncategorized', got '%s'", transactions[1].GetCategory())
+		}
+	})
+
+	// --- Test Case: Optional status column ---
+	t.Run("status column", func(t *testing.T) {
+		statusCsvContent := `Id,Date,Transaction,Status
+0,7/15,-60.5,Pending
+1,7/28,-10.3,`
+		statusFilePath := filepath.Join(tempDir, "status.csv")
+		os.WriteFile(statusFilePath, []byte(statusCsvContent), 0644)
+
+		transactions, err := reader.ReadTransactions(context.Background(), statusFilePath)
+		if err != nil {
+			t.Fatalf("ReadTransactions failed: %v", err)
+		}
+		if !transactions[0].IsPending() {
+			t.Errorf("Expected first transaction to be pending, got '%s'", transactions[0].GetStatus())
+		}
+		if !transactions[1].IsPosted() {
+			t.Errorf("Expected second transaction to be posted, got '%s'", transactions[1].GetStatus())
+		}
+	})
+
+	// --- Test Case: Invalid status ---
+	t.Run("invalid status", func(t *testing.T) {
+		invalidStatusContent := `Id,Date,Transaction,Status
+0,7/15,-60.5,settled`
+		invalidStatusPath := filepath.Join(tempDir, "invalid_status.csv")
+		os.WriteFile(invalidStatusPath, []byte(invalidStatusContent), 0644)
+
+		_, err := reader.ReadTransactions(context.Background(), invalidStatusPath)
+		if err == nil {
+			t.Error("Expected an error for invalid status, got nil")
+		}
+	})
+
+	// --- Test Case: Profiles ---
+	t.Run("profiles", func(t *testing.T) {
+		profiles, err := LoadCSVProfiles(filepath.Join("..", "..", "csv_profiles.yaml"))
+		if err != nil {
+			t.Fatalf("LoadCSVProfiles failed: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			profile  string
+			content  string
+			expected []model.Transaction
+		}{
+			{
+				name:    "delimiter, decimal and thousands separators",
+				profile: "eu-bank",
+				content: "Booking Date;Reference;Amount;Description\n" +
+					"01.07.2025;R1;1.234,56;\"Salary; July\"\n" +
+					"03.07.2025;R2;-12,30;Coffee\n",
+				expected: []model.Transaction{
+					{ID: "R1", Date: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), Amount: 1234.56, IsCredit: true, Description: "Salary; July"},
+					{ID: "R2", Date: time.Date(2025, time.July, 3, 0, 0, 0, 0, time.UTC), Amount: 12.3, IsCredit: false, Description: "Coffee"},
+				},
+			},
+			{
+				name:    "debit and credit columns without ID",
+				profile: "debit-credit",
+				content: "Date,Description,Debit,Credit\n" +
+					"2025-07-01,Salary,,1500\n" +
+					"2025-07-02,Rent,800,\n",
+				expected: []model.Transaction{
+					{ID: "0", Date: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), Amount: 1500, IsCredit: true, Description: "Salary"},
+					{ID: "1", Date: time.Date(2025, time.July, 2, 0, 0, 0, 0, time.UTC), Amount: 800, IsCredit: false, Description: "Rent"},
+				},
+			},
+			{
+				name:    "header-less file with single quotes",
+				profile: "headerless",
+				content: "A1,07/15/2025,-20.5,'Books, \"used\"'\n",
+				expected: []model.Transaction{
+					{ID: "A1", Date: time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), Amount: 20.5, IsCredit: false, Description: `Books, "used"`},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				profilePath := filepath.Join(tempDir, tt.profile+".csv")
+				os.WriteFile(profilePath, []byte(tt.content), 0644)
+
+				transactions, err := NewCSVFileReaderWithProfile(profiles[tt.profile]).ReadTransactions(context.Background(), profilePath)
+				if err != nil {
+					t.Fatalf("ReadTransactions failed: %v", err)
+				}
+				if len(transactions) != len(tt.expected) {
+					t.Fatalf("Expected %d transactions, got %d", len(tt.expected), len(transactions))
+				}
+				for i, expected := range tt.expected {
+					assert.Equal(t, expected, *transactions[i])
+				}
+			})
+		}
+	})
+
+	t.Run("encodings and whitespace", func(t *testing.T) {
+		utf16 := []byte{0xff, 0xfe}
+		for _, r := range "Id,Date,Transaction,Category\r\n0,7/15,+60.5,Café\r\n" {
+			utf16 = append(utf16, byte(r), byte(r>>8))
+		}
+
+		latin1Profile := DefaultCSVProfile()
+		latin1Profile.Encoding = "iso-8859-1"
+
+		tests := []struct {
+			name    string
+			profile CSVProfile
+			content []byte
+		}{
+			{name: "UTF-8 with BOM", profile: DefaultCSVProfile(), content: []byte("\xef\xbb\xbfId,Date,Transaction,Category\n0,7/15,+60.5,Café\n")},
+			{name: "UTF-16 with BOM", profile: DefaultCSVProfile(), content: utf16},
+			{name: "Windows-1252 detected", profile: DefaultCSVProfile(), content: []byte("Id,Date,Transaction,Category\r\n0,7/15,+60.5,Caf\xe9\r\n")},
+			{name: "Windows-1252 past the sniffed start", profile: DefaultCSVProfile(), content: []byte("Id,Date,Transaction,Category\r\n0,7/15,+60.5," + strings.Repeat(" ", 2*sniffSize) + "Caf\xe9\r\n")},
+			{name: "explicit Latin-1", profile: latin1Profile, content: []byte("Id,Date,Transaction,Category\n0,7/15,+60.5,Caf\xe9\n")},
+			{name: "header case and whitespace", profile: DefaultCSVProfile(), content: []byte(" ID ,date,Transaction\u00a0,\u00a0Category\n0,7/15, +60.5 ,Caf\u00e9\u00a0\n")},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				encodedPath := filepath.Join(tempDir, "encoded.csv")
+				os.WriteFile(encodedPath, tt.content, 0644)
+
+				transactions, err := NewCSVFileReaderWithProfile(tt.profile).ReadTransactions(context.Background(), encodedPath)
+				if err != nil {
+					t.Fatalf("ReadTransactions failed: %v", err)
+				}
+				if len(transactions) != 1 {
+					t.Fatalf("Expected 1 transaction, got %d", len(transactions))
+				}
+				assert.Equal(t, "0", transactions[0].ID)
+				assert.Equal(t, 60.5, transactions[0].Amount)
+				assert.Equal(t, "Café", transactions[0].Category)
+			})
+		}
+
+		t.Run("sniffed with BOM", func(t *testing.T) {
+			assert.True(t, NewCSVFileReader().sniff([]byte("\xef\xbb\xbfId,Date,Transaction\n")))
+			assert.True(t, NewCSVFileReader().sniff(utf16))
+		})
+	})
+
 	// --- Test Case: Non-existent file ---
 	t.Run("non-existent file", func(t *testing.T) {
-		_, err := reader.ReadTransactions(filepath.Join(tempDir, "non_existent.csv"))
+		_, err := reader.ReadTransactions(context.Background(), filepath.Join(tempDir, "non_existent.csv"))
 		if err == nil {
 			t.Error("Expected an error for non-existent file, got nil")
 		}
 	})
 }
+
+func TestCSVFileReader_ReadStatement_Trailer(t *testing.T) {
+	tempDir := t.TempDir()
+	reader := NewCSVFileReader()
+
+	writeFile := func(t *testing.T, name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test CSV file: %v", err)
+		}
+		return filePath
+	}
+
+	t.Run("trailer with control totals", func(t *testing.T) {
+		filePath := writeFile(t, "trailer.csv", "Id,Date,Transaction\n0,7/15,+60.5\n1,7/28,-10.3\nTRAILER,2,60.50,10.30\n")
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		assert.Len(t, statement.Transactions, 2)
+		assert.Equal(t, &model.ControlTotals{Count: 2, TotalCredit: 60.5, TotalDebit: 10.3}, statement.ControlTotals)
+	})
+
+	t.Run("without trailer", func(t *testing.T) {
+		filePath := writeFile(t, "no_trailer.csv", "Id,Date,Transaction\n0,7/15,+60.5\n")
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		assert.Len(t, statement.Transactions, 1)
+		assert.Nil(t, statement.ControlTotals)
+	})
+
+	t.Run("records after the trailer", func(t *testing.T) {
+		filePath := writeFile(t, "misplaced.csv", "Id,Date,Transaction\nTRAILER,1,60.50,0\n0,7/15,+60.5\n")
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid trailer", func(t *testing.T) {
+		filePath := writeFile(t, "invalid.csv", "Id,Date,Transaction\n0,7/15,+60.5\nTRAILER,one\n")
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.Error(t, err)
+	})
+}
+
+func TestCSVFileReader_ReadTransactions_Cancelled(t *testing.T) {
+	testFilePath := filepath.Join(t.TempDir(), "transactions.csv")
+	if err := os.WriteFile(testFilePath, []byte("Id,Date,Transaction\n0,7/15,+60.5\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test CSV file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewCSVFileReader().ReadTransactions(ctx, testFilePath)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
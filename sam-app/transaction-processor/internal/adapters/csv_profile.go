@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCSVProfileNotFound is returned when a requested CSV profile is not defined
+var ErrCSVProfileNotFound = errors.New("CSV profile not found")
+
+// CSVColumn locates a column by its header name or by its zero-based position. Header-less files
+// can only locate columns by position.
+type CSVColumn struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Index *int   `json:"index,omitempty" yaml:"index,omitempty"`
+}
+
+// IsSet reports whether the column is mapped
+func (c CSVColumn) IsSet() bool {
+	return c.Name != "" || c.Index != nil
+}
+
+// CSVColumns maps the transaction fields to the columns of a CSV file. Amounts are read either from
+// a signed Amount column or from separate Debit and Credit columns. Transactions are numbered by row
+// when there is no ID column.
+type CSVColumns struct {
+	ID          CSVColumn `json:"id" yaml:"id"`
+	Date        CSVColumn `json:"date" yaml:"date"`
+	Amount      CSVColumn `json:"amount" yaml:"amount"`
+	Debit       CSVColumn `json:"debit" yaml:"debit"`
+	Credit      CSVColumn `json:"credit" yaml:"credit"`
+	Category    CSVColumn `json:"category" yaml:"category"`
+	Description CSVColumn `json:"description" yaml:"description"`
+	Status      CSVColumn `json:"status" yaml:"status"`
+}
+
+// CSVProfile describes the column mapping and dialect of a CSV file. Encoding is an IANA character set
+// name, such as windows-1252 or iso-8859-1; when empty it is detected from the byte order mark and content.
+// Trailer is the first field of an optional last record holding the control totals of the file: the
+// number of transactions and the credit and debit totals, e.g. TRAILER,3,150.00,60.00.
+type CSVProfile struct {
+	Name               string     `json:"name" yaml:"name"`
+	Delimiter          string     `json:"delimiter,omitempty" yaml:"delimiter,omitempty"`
+	Quote              string     `json:"quote,omitempty" yaml:"quote,omitempty"`
+	DecimalSeparator   string     `json:"decimalSeparator,omitempty" yaml:"decimalSeparator,omitempty"`
+	ThousandsSeparator string     `json:"thousandsSeparator,omitempty" yaml:"thousandsSeparator,omitempty"`
+	DateFormat         string     `json:"dateFormat,omitempty" yaml:"dateFormat,omitempty"`
+	Encoding           string     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	NoHeader           bool       `json:"noHeader,omitempty" yaml:"noHeader,omitempty"`
+	Trailer            string     `json:"trailer,omitempty" yaml:"trailer,omitempty"`
+	Columns            CSVColumns `json:"columns" yaml:"columns"`
+}
+
+// DefaultCSVProfile returns the profile of the Id,Date,Transaction format, with dates in MM/DD format
+// in the current year, optional Category and Status columns and an optional TRAILER record
+func DefaultCSVProfile() CSVProfile {
+	return CSVProfile{
+		Name:       "default",
+		DateFormat: "1/2",
+		Trailer:    "TRAILER",
+		Columns: CSVColumns{
+			ID:       CSVColumn{Name: "Id"},
+			Date:     CSVColumn{Name: "Date"},
+			Amount:   CSVColumn{Name: "Transaction"},
+			Category: CSVColumn{Name: "Category"},
+			Status:   CSVColumn{Name: "Status"},
+		},
+	}
+}
+
+// Validate checks that the profile maps a date and an amount and that its dialect is supported
+func (p CSVProfile) Validate() error {
+	for field, value := range map[string]string{"delimiter": p.Delimiter, "quote": p.Quote, "decimalSeparator": p.DecimalSeparator} {
+		if len([]rune(value)) > 1 {
+			return fmt.Errorf("invalid CSV profile %s: %s must be a single character", p.Name, field)
+		}
+	}
+	if len(p.Quote) > 1 {
+		return fmt.Errorf("invalid CSV profile %s: quote must be an ASCII character", p.Name)
+	}
+	if _, err := lookupCSVEncoding(p.Encoding); err != nil {
+		return fmt.Errorf("invalid CSV profile %s: %w", p.Name, err)
+	}
+	if p.Delimiter != "" && p.Delimiter == p.Quote {
+		return fmt.Errorf("invalid CSV profile %s: delimiter and quote must differ", p.Name)
+	}
+
+	if !p.Columns.Date.IsSet() {
+		return fmt.Errorf("invalid CSV profile %s: a date column is required", p.Name)
+	}
+	if !p.Columns.Amount.IsSet() && !p.Columns.Debit.IsSet() && !p.Columns.Credit.IsSet() {
+		return fmt.Errorf("invalid CSV profile %s: an amount column or debit and credit columns are required", p.Name)
+	}
+
+	if p.NoHeader {
+		for _, column := range []CSVColumn{p.Columns.ID, p.Columns.Date, p.Columns.Amount, p.Columns.Debit,
+			p.Columns.Credit, p.Columns.Category, p.Columns.Description, p.Columns.Status} {
+			if column.Name != "" && column.Index == nil {
+				return fmt.Errorf("invalid CSV profile %s: column %s must be located by index in a file without header", p.Name, column.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadCSVProfiles loads the CSV profiles listed in a JSON or YAML file, keyed by name
+func LoadCSVProfiles(filePath string) (map[string]CSVProfile, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV profiles: %w", err)
+	}
+
+	var list []CSVProfile
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		err = json.Unmarshal(content, &list)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &list)
+	default:
+		return nil, fmt.Errorf("unsupported CSV profiles file %s, expected .json, .yaml or .yml", filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CSV profiles: %w", err)
+	}
+
+	profiles := make(map[string]CSVProfile, len(list))
+	for _, profile := range list {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("invalid CSV profile: name is required")
+		}
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+		profiles[profile.Name] = profile
+	}
+
+	return profiles, nil
+}
+
+// LoadCSVProfile loads a single CSV profile by name from a JSON or YAML file
+func LoadCSVProfile(filePath, name string) (CSVProfile, error) {
+	profiles, err := LoadCSVProfiles(filePath)
+	if err != nil {
+		return CSVProfile{}, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return CSVProfile{}, fmt.Errorf("%w: %s", ErrCSVProfileNotFound, name)
+	}
+	return profile, nil
+}
@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCSVProfiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile := func(t *testing.T, name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test profiles file: %v", err)
+		}
+		return filePath
+	}
+
+	t.Run("JSON profiles", func(t *testing.T) {
+		filePath := writeFile(t, "profiles.json", `[
+  {"name": "bank", "delimiter": ";", "columns": {"date": {"index": 0}, "amount": {"name": "Amount"}}}
+]`)
+
+		profile, err := LoadCSVProfile(filePath, "bank")
+		if err != nil {
+			t.Fatalf("LoadCSVProfile failed: %v", err)
+		}
+		assert.Equal(t, ";", profile.Delimiter)
+		assert.Equal(t, 0, *profile.Columns.Date.Index)
+		assert.Equal(t, "Amount", profile.Columns.Amount.Name)
+	})
+
+	t.Run("profile not found", func(t *testing.T) {
+		filePath := writeFile(t, "empty.yaml", "[]")
+
+		_, err := LoadCSVProfile(filePath, "missing")
+		assert.True(t, errors.Is(err, ErrCSVProfileNotFound), "expected ErrCSVProfileNotFound, got %v", err)
+	})
+
+	invalidTests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing name", content: `- columns: {date: {index: 0}, amount: {index: 1}}`},
+		{name: "missing amount column", content: `- {name: a, columns: {date: {index: 0}}}`},
+		{name: "multi-character delimiter", content: `- {name: a, delimiter: ";;", columns: {date: {index: 0}, amount: {index: 1}}}`},
+		{name: "column name without header", content: `- {name: a, noHeader: true, columns: {date: {name: Date}, amount: {index: 1}}}`},
+		{name: "unsupported encoding", content: `- {name: a, encoding: ebcdic-xyz, columns: {date: {index: 0}, amount: {index: 1}}}`},
+	}
+
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadCSVProfiles(writeFile(t, "invalid.yml", tt.content))
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("unsupported file", func(t *testing.T) {
+		_, err := LoadCSVProfiles(writeFile(t, "profiles.txt", "[]"))
+		assert.Error(t, err)
+	})
+}
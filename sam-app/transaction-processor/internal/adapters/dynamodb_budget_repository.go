@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBBudgetRepository implements the BudgetRepository port using DynamoDB
+type DynamoDBBudgetRepository struct {
+	dynamoClient ports.DynamoDBClient
+	budgetsTable string
+}
+
+// NewDynamoDBBudgetRepository creates a new DynamoDBBudgetRepository
+func NewDynamoDBBudgetRepository(dynamoClient *dynamodb.Client, budgetsTable string) *DynamoDBBudgetRepository {
+	return &DynamoDBBudgetRepository{
+		dynamoClient: dynamoClient,
+		budgetsTable: budgetsTable,
+	}
+}
+
+// SaveBudget saves a budget to DynamoDB, keyed by account and normalized category, so a category
+// set again in a different case replaces the budget. The category is kept as set in CategoryName.
+func (r *DynamoDBBudgetRepository) SaveBudget(ctx context.Context, budget *model.Budget) error {
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"AccountID":      &types.AttributeValueMemberS{Value: budget.AccountID},
+		"Category":       &types.AttributeValueMemberS{Value: budget.Key()},
+		"CategoryName":   &types.AttributeValueMemberS{Value: budget.Category},
+		"MonthlyLimit":   &types.AttributeValueMemberN{Value: strconv.FormatFloat(budget.MonthlyLimit, 'f', 2, 64)},
+		"AlertThreshold": &types.AttributeValueMemberN{Value: strconv.FormatFloat(budget.AlertThreshold, 'f', -1, 64)},
+		"Timestamp":      &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.budgetsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving budget to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// GetBudgets retrieves all budgets for an account from DynamoDB, following every page of the query
+func (r *DynamoDBBudgetRepository) GetBudgets(ctx context.Context, accountID string) ([]*model.Budget, error) {
+	var budgets []*model.Budget
+	var startKey map[string]types.AttributeValue
+
+	for {
+		// Query the budgets of the account
+		result, err := r.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.budgetsTable),
+			KeyConditionExpression: aws.String("AccountID = :accountID"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":accountID": &types.AttributeValueMemberS{Value: accountID},
+			},
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error querying budgets table: %w", err)
+		}
+
+		// Convert the items to budgets
+		for _, item := range result.Items {
+			budget, err := budgetFromItem(accountID, item)
+			if err != nil {
+				return nil, err
+			}
+			budgets = append(budgets, budget)
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = result.LastEvaluatedKey
+	}
+
+	return budgets, nil
+}
+
+// budgetFromItem converts an item of the budgets table to a budget. Budgets saved before the category
+// name was stored apart from the key are named by their key.
+func budgetFromItem(accountID string, item map[string]types.AttributeValue) (*model.Budget, error) {
+	category := item["Category"].(*types.AttributeValueMemberS).Value
+	if name, ok := item["CategoryName"].(*types.AttributeValueMemberS); ok {
+		category = name.Value
+	}
+	limitStr := item["MonthlyLimit"].(*types.AttributeValueMemberN).Value
+	thresholdStr := item["AlertThreshold"].(*types.AttributeValueMemberN).Value
+
+	limit, err := strconv.ParseFloat(limitStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing monthly limit: %w", err)
+	}
+
+	threshold, err := strconv.ParseFloat(thresholdStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing alert threshold: %w", err)
+	}
+
+	return model.NewBudget(accountID, category, limit, threshold), nil
+}
@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBBudgetRepository_SaveBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBBudgetRepository{
+		dynamoClient: mockDynamo,
+		budgetsTable: "BudgetsTable",
+	}
+
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "BudgetsTable", *input.TableName)
+			assert.Equal(t, "acc123", input.Item["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "groceries", input.Item["Category"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "Groceries", input.Item["CategoryName"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "250.00", input.Item["MonthlyLimit"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "0.9", input.Item["AlertThreshold"].(*types.AttributeValueMemberN).Value)
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	err := repo.SaveBudget(context.Background(), model.NewBudget("acc123", " Groceries ", 250, 0.9))
+
+	assert.NoError(t, err)
+}
+
+func TestDynamoDBBudgetRepository_GetBudgets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBBudgetRepository{
+		dynamoClient: mockDynamo,
+		budgetsTable: "BudgetsTable",
+	}
+
+	// Every page of the query is read. Budgets saved before the category name was stored are named by their key.
+	gomock.InOrder(
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Nil(t, input.ExclusiveStartKey)
+				return &dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{
+						{
+							"AccountID":      &types.AttributeValueMemberS{Value: "acc123"},
+							"Category":       &types.AttributeValueMemberS{Value: "groceries"},
+							"CategoryName":   &types.AttributeValueMemberS{Value: "Groceries"},
+							"MonthlyLimit":   &types.AttributeValueMemberN{Value: "250.00"},
+							"AlertThreshold": &types.AttributeValueMemberN{Value: "0.9"},
+						},
+					},
+					LastEvaluatedKey: map[string]types.AttributeValue{
+						"AccountID": &types.AttributeValueMemberS{Value: "acc123"},
+						"Category":  &types.AttributeValueMemberS{Value: "groceries"},
+					},
+				}, nil
+			}),
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Equal(t, "groceries", input.ExclusiveStartKey["Category"].(*types.AttributeValueMemberS).Value)
+				return &dynamodb.QueryOutput{
+					Items: []map[string]types.AttributeValue{
+						{
+							"AccountID":      &types.AttributeValueMemberS{Value: "acc123"},
+							"Category":       &types.AttributeValueMemberS{Value: "Transport"},
+							"MonthlyLimit":   &types.AttributeValueMemberN{Value: "100"},
+							"AlertThreshold": &types.AttributeValueMemberN{Value: "0.8"},
+						},
+					},
+				}, nil
+			}),
+	)
+
+	budgets, err := repo.GetBudgets(context.Background(), "acc123")
+
+	assert.NoError(t, err)
+	assert.Len(t, budgets, 2)
+	assert.Equal(t, "acc123", budgets[0].AccountID)
+	assert.Equal(t, "Groceries", budgets[0].Category)
+	assert.Equal(t, 250.0, budgets[0].MonthlyLimit)
+	assert.Equal(t, 0.9, budgets[0].AlertThreshold)
+	assert.Equal(t, "Transport", budgets[1].Category)
+	assert.Equal(t, "transport", budgets[1].Key())
+}
+
+func TestDynamoDBBudgetRepository_GetBudgets_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBBudgetRepository{
+		dynamoClient: mockDynamo,
+		budgetsTable: "BudgetsTable",
+	}
+
+	mockDynamo.EXPECT().
+		Query(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("boom"))
+
+	_, err := repo.GetBudgets(context.Background(), "acc123")
+
+	assert.EqualError(t, err, "error querying budgets table: boom")
+}
+
+func TestInMemoryBudgetRepository(t *testing.T) {
+	repo := NewInMemoryBudgetRepository()
+
+	assert.NoError(t, repo.SaveBudget(context.Background(), model.NewBudget("acc123", "Transport", 100, 0)))
+	assert.NoError(t, repo.SaveBudget(context.Background(), model.NewBudget("acc123", "Groceries", 200, 0)))
+	assert.NoError(t, repo.SaveBudget(context.Background(), model.NewBudget("acc123", "groceries", 300, 0)))
+	assert.NoError(t, repo.SaveBudget(context.Background(), model.NewBudget("other", "Leisure", 50, 0)))
+
+	budgets, err := repo.GetBudgets(context.Background(), "acc123")
+
+	assert.NoError(t, err)
+	assert.Len(t, budgets, 2)
+	assert.Equal(t, "Transport", budgets[0].Category)
+	assert.Equal(t, 300.0, budgets[1].MonthlyLimit)
+	assert.Equal(t, model.DefaultBudgetAlertThreshold, budgets[1].AlertThreshold)
+}
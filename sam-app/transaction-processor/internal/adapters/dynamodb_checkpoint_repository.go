@@ -0,0 +1,208 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// checkpointTTL is how long the checkpoint of a file that is never processed again is kept
+const checkpointTTL = 7 * 24 * time.Hour
+
+// DynamoDBCheckpointRepository implements the CheckpointRepository port using DynamoDB
+type DynamoDBCheckpointRepository struct {
+	dynamoClient     ports.DynamoDBClient
+	checkpointsTable string
+}
+
+// NewDynamoDBCheckpointRepository creates a new DynamoDBCheckpointRepository
+func NewDynamoDBCheckpointRepository(dynamoClient *dynamodb.Client, checkpointsTable string) *DynamoDBCheckpointRepository {
+	return &DynamoDBCheckpointRepository{
+		dynamoClient:     dynamoClient,
+		checkpointsTable: checkpointsTable,
+	}
+}
+
+// SaveCheckpoint saves a checkpoint to DynamoDB, keyed by account ID and file fingerprint. Amounts are
+// stored with full precision so the resumed account matches one built from the first row.
+func (r *DynamoDBCheckpointRepository) SaveCheckpoint(ctx context.Context, checkpoint *model.ProcessingCheckpoint) error {
+	monthlyStats := make(map[string]types.AttributeValue)
+	for month, stats := range checkpoint.Aggregates.MonthlyStats {
+		monthlyStats[month] = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"Month":            &types.AttributeValueMemberN{Value: strconv.Itoa(int(stats.Month))},
+			"Year":             &types.AttributeValueMemberN{Value: strconv.Itoa(stats.Year)},
+			"TransactionCount": &types.AttributeValueMemberN{Value: strconv.Itoa(stats.TransactionCount)},
+			"TotalCredit":      &types.AttributeValueMemberN{Value: formatExactFloat(stats.TotalCredit)},
+			"TotalDebit":       &types.AttributeValueMemberN{Value: formatExactFloat(stats.TotalDebit)},
+			"CreditCount":      &types.AttributeValueMemberN{Value: strconv.Itoa(stats.CreditCount)},
+			"DebitCount":       &types.AttributeValueMemberN{Value: strconv.Itoa(stats.DebitCount)},
+		}}
+	}
+
+	persisted := make([]types.AttributeValue, 0, len(checkpoint.Persisted))
+	for _, row := range checkpoint.Persisted {
+		persisted = append(persisted, &types.AttributeValueMemberN{Value: strconv.Itoa(row)})
+	}
+
+	// Create the item, expiring it in case the file is never processed again
+	item := map[string]types.AttributeValue{
+		"AccountID":        &types.AttributeValueMemberS{Value: checkpoint.AccountID},
+		"Fingerprint":      &types.AttributeValueMemberS{Value: checkpoint.Fingerprint},
+		"Row":              &types.AttributeValueMemberN{Value: strconv.Itoa(checkpoint.Row)},
+		"Persisted":        &types.AttributeValueMemberL{Value: persisted},
+		"Balance":          &types.AttributeValueMemberN{Value: formatExactFloat(checkpoint.Aggregates.Balance)},
+		"AvailableBalance": &types.AttributeValueMemberN{Value: formatExactFloat(checkpoint.Aggregates.AvailableBalance)},
+		"MonthlyStats":     &types.AttributeValueMemberM{Value: monthlyStats},
+		"UpdatedAt":        &types.AttributeValueMemberS{Value: checkpoint.UpdatedAt.Format(time.RFC3339)},
+		"ExpiresAt":        &types.AttributeValueMemberN{Value: strconv.FormatInt(checkpoint.UpdatedAt.Add(checkpointTTL).Unix(), 10)},
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.checkpointsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// GetCheckpoint retrieves the checkpoint of a file for an account from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBCheckpointRepository) GetCheckpoint(ctx context.Context, accountID, fingerprint string) (*model.ProcessingCheckpoint, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(r.checkpointsTable),
+		Key:            checkpointKey(accountID, fingerprint),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting checkpoint from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	checkpoint := &model.ProcessingCheckpoint{
+		AccountID:   accountID,
+		Fingerprint: fingerprint,
+		Aggregates:  model.AccountAggregates{MonthlyStats: make(map[string]*model.MonthlyStats)},
+	}
+
+	if checkpoint.Row, err = intAttribute(result.Item, "Row"); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint row: %w", err)
+	}
+	if persisted, ok := result.Item["Persisted"].(*types.AttributeValueMemberL); ok {
+		for _, value := range persisted.Value {
+			row, err := strconv.Atoi(value.(*types.AttributeValueMemberN).Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing checkpoint persisted row: %w", err)
+			}
+			checkpoint.Persisted = append(checkpoint.Persisted, row)
+		}
+	}
+	if checkpoint.Aggregates.Balance, err = floatAttribute(result.Item, "Balance"); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint balance: %w", err)
+	}
+	if checkpoint.Aggregates.AvailableBalance, err = floatAttribute(result.Item, "AvailableBalance"); err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint available balance: %w", err)
+	}
+	if monthlyStats, ok := result.Item["MonthlyStats"].(*types.AttributeValueMemberM); ok {
+		for month, value := range monthlyStats.Value {
+			stats, err := monthlyStatsFromItem(value.(*types.AttributeValueMemberM).Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing checkpoint stats of %s: %w", month, err)
+			}
+			checkpoint.Aggregates.MonthlyStats[month] = stats
+		}
+	}
+	if updatedAt, ok := result.Item["UpdatedAt"].(*types.AttributeValueMemberS); ok {
+		if checkpoint.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt.Value); err != nil {
+			return nil, fmt.Errorf("error parsing checkpoint update date: %w", err)
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// DeleteCheckpoint removes the checkpoint of a file for an account from DynamoDB
+func (r *DynamoDBCheckpointRepository) DeleteCheckpoint(ctx context.Context, accountID, fingerprint string) error {
+	_, err := r.dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.checkpointsTable),
+		Key:       checkpointKey(accountID, fingerprint),
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting checkpoint from DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// checkpointKey returns the key of the checkpoint of a file for an account
+func checkpointKey(accountID, fingerprint string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"AccountID":   &types.AttributeValueMemberS{Value: accountID},
+		"Fingerprint": &types.AttributeValueMemberS{Value: fingerprint},
+	}
+}
+
+// monthlyStatsFromItem converts a DynamoDB map into the stats of a month
+func monthlyStatsFromItem(item map[string]types.AttributeValue) (*model.MonthlyStats, error) {
+	stats := &model.MonthlyStats{}
+
+	month, err := intAttribute(item, "Month")
+	if err != nil {
+		return nil, err
+	}
+	stats.Month = time.Month(month)
+
+	for name, target := range map[string]*int{
+		"Year":             &stats.Year,
+		"TransactionCount": &stats.TransactionCount,
+		"CreditCount":      &stats.CreditCount,
+		"DebitCount":       &stats.DebitCount,
+	} {
+		if *target, err = intAttribute(item, name); err != nil {
+			return nil, err
+		}
+	}
+	if stats.TotalCredit, err = floatAttribute(item, "TotalCredit"); err != nil {
+		return nil, err
+	}
+	if stats.TotalDebit, err = floatAttribute(item, "TotalDebit"); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// intAttribute parses a number attribute as an int, which is zero when missing
+func intAttribute(item map[string]types.AttributeValue, name string) (int, error) {
+	value, ok := item[name].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, nil
+	}
+	return strconv.Atoi(value.Value)
+}
+
+// floatAttribute parses a number attribute as a float, which is zero when missing
+func floatAttribute(item map[string]types.AttributeValue, name string) (float64, error) {
+	value, ok := item[name].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value.Value, 64)
+}
+
+// formatExactFloat formats a float with the fewest digits that parse back to the same value
+func formatExactFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBCheckpointRepository_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBCheckpointRepository{
+		dynamoClient:     mockDynamo,
+		checkpointsTable: "CheckpointsTable",
+	}
+
+	account := model.NewAccount()
+	account.AddTransaction(&model.Transaction{ID: "1", Date: time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), Amount: 0.1, IsCredit: true})
+	account.AddTransaction(&model.Transaction{ID: "2", Date: time.Date(2025, time.July, 16, 0, 0, 0, 0, time.UTC), Amount: 0.2, IsCredit: true})
+
+	updatedAt := time.Date(2025, time.July, 20, 10, 0, 0, 0, time.UTC)
+	checkpoint := model.NewProcessingCheckpoint("acc1", "abc123", updatedAt)
+	checkpoint.Advance(2, account, []int{4}, updatedAt)
+
+	var stored map[string]types.AttributeValue
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "CheckpointsTable", *input.TableName)
+			assert.Equal(t, "acc1", input.Item["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "abc123", input.Item["Fingerprint"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "2", input.Item["Row"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "0.30000000000000004", input.Item["Balance"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "1753610400", input.Item["ExpiresAt"].(*types.AttributeValueMemberN).Value)
+			stored = input.Item
+			return &dynamodb.PutItemOutput{}, nil
+		})
+	mockDynamo.
+		EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, "acc1", input.Key["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "abc123", input.Key["Fingerprint"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.GetItemOutput{Item: stored}, nil
+		})
+
+	err := repo.SaveCheckpoint(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+
+	loaded, err := repo.GetCheckpoint(context.Background(), "acc1", "abc123")
+	if err != nil {
+		t.Fatalf("GetCheckpoint failed: %v", err)
+	}
+
+	assert.Equal(t, checkpoint, loaded)
+}
+
+func TestDynamoDBCheckpointRepository_GetCheckpoint_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBCheckpointRepository{
+		dynamoClient:     mockDynamo,
+		checkpointsTable: "CheckpointsTable",
+	}
+
+	mockDynamo.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+
+	checkpoint, err := repo.GetCheckpoint(context.Background(), "acc1", "abc123")
+
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestDynamoDBCheckpointRepository_DeleteCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBCheckpointRepository{
+		dynamoClient:     mockDynamo,
+		checkpointsTable: "CheckpointsTable",
+	}
+
+	mockDynamo.
+		EXPECT().
+		DeleteItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+			assert.Equal(t, "CheckpointsTable", *input.TableName)
+			assert.Equal(t, "abc123", input.Key["Fingerprint"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.DeleteItemOutput{}, nil
+		})
+
+	err := repo.DeleteCheckpoint(context.Background(), "acc1", "abc123")
+
+	assert.NoError(t, err)
+}
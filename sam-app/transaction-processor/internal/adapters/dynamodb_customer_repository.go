@@ -0,0 +1,86 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBCustomerRepository implements the CustomerRepository port using DynamoDB
+type DynamoDBCustomerRepository struct {
+	dynamoClient   ports.DynamoDBClient
+	customersTable string
+}
+
+// NewDynamoDBCustomerRepository creates a new DynamoDBCustomerRepository
+func NewDynamoDBCustomerRepository(dynamoClient *dynamodb.Client, customersTable string) *DynamoDBCustomerRepository {
+	return &DynamoDBCustomerRepository{
+		dynamoClient:   dynamoClient,
+		customersTable: customersTable,
+	}
+}
+
+// SaveCustomer saves a customer and the accounts it owns to DynamoDB
+func (r *DynamoDBCustomerRepository) SaveCustomer(ctx context.Context, customer *model.Customer) error {
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"CustomerID": &types.AttributeValueMemberS{Value: customer.ID},
+		"Name":       &types.AttributeValueMemberS{Value: customer.Name},
+		"Email":      &types.AttributeValueMemberS{Value: customer.Email},
+		"Timestamp":  &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+	}
+
+	// String sets cannot be empty, so customers without accounts omit the attribute
+	if len(customer.AccountIDs) > 0 {
+		item["AccountIDs"] = &types.AttributeValueMemberSS{Value: customer.AccountIDs}
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.customersTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving customer to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// GetCustomer retrieves a customer by ID from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBCustomerRepository) GetCustomer(ctx context.Context, customerID string) (*model.Customer, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.customersTable),
+		Key: map[string]types.AttributeValue{
+			"CustomerID": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting customer from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	customer := &model.Customer{
+		ID: customerID,
+	}
+	if name, ok := result.Item["Name"].(*types.AttributeValueMemberS); ok {
+		customer.Name = name.Value
+	}
+	if email, ok := result.Item["Email"].(*types.AttributeValueMemberS); ok {
+		customer.Email = email.Value
+	}
+	if accountIDs, ok := result.Item["AccountIDs"].(*types.AttributeValueMemberSS); ok {
+		customer.AccountIDs = accountIDs.Value
+	}
+
+	return customer, nil
+}
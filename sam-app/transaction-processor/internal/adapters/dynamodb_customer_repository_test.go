@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBCustomerRepository_SaveAndGetCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBCustomerRepository{
+		dynamoClient:   mockDynamo,
+		customersTable: "CustomersTable",
+	}
+
+	customer := &model.Customer{
+		ID:         "cust1",
+		Name:       "Jane",
+		Email:      "jane@example.com",
+		AccountIDs: []string{"checking", "savings"},
+	}
+
+	var savedItem map[string]types.AttributeValue
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "CustomersTable", *input.TableName)
+			savedItem = input.Item
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	assert.NoError(t, repo.SaveCustomer(context.Background(), customer))
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{Item: savedItem}, nil)
+
+	stored, err := repo.GetCustomer(context.Background(), "cust1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, customer, stored)
+}
+
+func TestDynamoDBCustomerRepository_GetCustomer_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBCustomerRepository{
+		dynamoClient:   mockDynamo,
+		customersTable: "CustomersTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{}, nil)
+
+	customer, err := repo.GetCustomer(context.Background(), "ghost")
+
+	assert.NoError(t, err)
+	assert.Nil(t, customer)
+}
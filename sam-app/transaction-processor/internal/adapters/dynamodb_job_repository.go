@@ -0,0 +1,127 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBJobRepository implements the JobRepository port using DynamoDB
+type DynamoDBJobRepository struct {
+	dynamoClient ports.DynamoDBClient
+	jobsTable    string
+}
+
+// NewDynamoDBJobRepository creates a new DynamoDBJobRepository
+func NewDynamoDBJobRepository(dynamoClient *dynamodb.Client, jobsTable string) *DynamoDBJobRepository {
+	return &DynamoDBJobRepository{
+		dynamoClient: dynamoClient,
+		jobsTable:    jobsTable,
+	}
+}
+
+// SaveJob saves a job to DynamoDB, keyed by job ID
+func (r *DynamoDBJobRepository) SaveJob(ctx context.Context, job *model.Job) error {
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"JobID":     &types.AttributeValueMemberS{Value: job.ID},
+		"AccountID": &types.AttributeValueMemberS{Value: job.AccountID},
+		"Email":     &types.AttributeValueMemberS{Value: job.Email},
+		"Source":    &types.AttributeValueMemberS{Value: job.Source},
+		"Status":    &types.AttributeValueMemberS{Value: string(job.Status)},
+		"Total":     &types.AttributeValueMemberN{Value: strconv.Itoa(job.Total)},
+		"Processed": &types.AttributeValueMemberN{Value: strconv.Itoa(job.Processed)},
+		"Attempts":  &types.AttributeValueMemberN{Value: strconv.Itoa(job.Attempts)},
+		"CreatedAt": &types.AttributeValueMemberS{Value: job.CreatedAt.Format(time.RFC3339)},
+		"UpdatedAt": &types.AttributeValueMemberS{Value: job.UpdatedAt.Format(time.RFC3339)},
+	}
+
+	// Optional attributes are only stored when set
+	for name, value := range map[string]string{
+		"CustomerID": job.CustomerID,
+		"CSVProfile": job.CSVProfile,
+		"Error":      job.Error,
+	} {
+		if value != "" {
+			item[name] = &types.AttributeValueMemberS{Value: value}
+		}
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.jobsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving job to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob retrieves a job by ID from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBJobRepository) GetJob(ctx context.Context, jobID string) (*model.Job, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.jobsTable),
+		Key: map[string]types.AttributeValue{
+			"JobID": &types.AttributeValueMemberS{Value: jobID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting job from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	stringValue := func(name string) string {
+		if value, ok := result.Item[name].(*types.AttributeValueMemberS); ok {
+			return value.Value
+		}
+		return ""
+	}
+	intValue := func(name string) (int, error) {
+		value, ok := result.Item[name].(*types.AttributeValueMemberN)
+		if !ok {
+			return 0, nil
+		}
+		return strconv.Atoi(value.Value)
+	}
+
+	job := &model.Job{
+		ID:         jobID,
+		AccountID:  stringValue("AccountID"),
+		CustomerID: stringValue("CustomerID"),
+		Email:      stringValue("Email"),
+		CSVProfile: stringValue("CSVProfile"),
+		Source:     stringValue("Source"),
+		Status:     model.JobStatus(stringValue("Status")),
+		Error:      stringValue("Error"),
+	}
+
+	if job.Total, err = intValue("Total"); err != nil {
+		return nil, fmt.Errorf("error parsing job total: %w", err)
+	}
+	if job.Processed, err = intValue("Processed"); err != nil {
+		return nil, fmt.Errorf("error parsing job progress: %w", err)
+	}
+	if job.Attempts, err = intValue("Attempts"); err != nil {
+		return nil, fmt.Errorf("error parsing job attempts: %w", err)
+	}
+	if job.CreatedAt, err = time.Parse(time.RFC3339, stringValue("CreatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing job creation date: %w", err)
+	}
+	if job.UpdatedAt, err = time.Parse(time.RFC3339, stringValue("UpdatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing job update date: %w", err)
+	}
+
+	return job, nil
+}
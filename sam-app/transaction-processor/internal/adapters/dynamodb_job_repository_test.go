@@ -0,0 +1,130 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBJobRepository_SaveJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBJobRepository{
+		dynamoClient: mockDynamo,
+		jobsTable:    "JobsTable",
+	}
+
+	job := model.NewJob("job1", "acc123", "transactions.csv", time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC))
+	job.Email = "user@example.com"
+	job.Progress(40, 120, time.Date(2025, time.January, 15, 10, 0, 5, 0, time.UTC))
+
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "JobsTable", *input.TableName)
+			assert.Equal(t, "job1", input.Item["JobID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "queued", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "40", input.Item["Processed"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "120", input.Item["Total"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "2025-01-15T10:00:05Z", input.Item["UpdatedAt"].(*types.AttributeValueMemberS).Value)
+			assert.NotContains(t, input.Item, "Error")
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	err := repo.SaveJob(context.Background(), job)
+
+	assert.NoError(t, err)
+}
+
+func TestDynamoDBJobRepository_GetJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBJobRepository{
+		dynamoClient: mockDynamo,
+		jobsTable:    "JobsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"JobID":     &types.AttributeValueMemberS{Value: "job1"},
+				"AccountID": &types.AttributeValueMemberS{Value: "acc123"},
+				"Email":     &types.AttributeValueMemberS{Value: "user@example.com"},
+				"Source":    &types.AttributeValueMemberS{Value: "transactions.csv"},
+				"Status":    &types.AttributeValueMemberS{Value: "failed"},
+				"Total":     &types.AttributeValueMemberN{Value: "120"},
+				"Processed": &types.AttributeValueMemberN{Value: "40"},
+				"Error":     &types.AttributeValueMemberS{Value: "invalid amount"},
+				"CreatedAt": &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+				"UpdatedAt": &types.AttributeValueMemberS{Value: "2025-01-15T10:00:05Z"},
+			},
+		}, nil)
+
+	job, err := repo.GetJob(context.Background(), "job1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acc123", job.AccountID)
+	assert.Equal(t, model.JobStatusFailed, job.Status)
+	assert.Equal(t, 40, job.Processed)
+	assert.Equal(t, 120, job.Total)
+	assert.Equal(t, "invalid amount", job.Error)
+	assert.Equal(t, time.Date(2025, time.January, 15, 10, 0, 5, 0, time.UTC), job.UpdatedAt)
+}
+
+func TestDynamoDBJobRepository_GetJob_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBJobRepository{
+		dynamoClient: mockDynamo,
+		jobsTable:    "JobsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{}, nil)
+
+	job, err := repo.GetJob(context.Background(), "missing")
+
+	assert.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestDynamoDBJobRepository_GetJob_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBJobRepository{
+		dynamoClient: mockDynamo,
+		jobsTable:    "JobsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("DynamoDB error"))
+
+	job, err := repo.GetJob(context.Background(), "job1")
+
+	assert.Error(t, err)
+	assert.Nil(t, job)
+}
@@ -0,0 +1,200 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// pendingNotificationsIndex is the sparse index of the pending notifications by next attempt. Only
+	// pending notifications carry its PendingStatus key, so it holds no other.
+	pendingNotificationsIndex = "PendingNotifications"
+
+	// notificationTTL is how long delivered and failed notifications are kept for inspection
+	notificationTTL = 30 * 24 * time.Hour
+)
+
+// DynamoDBOutboxRepository implements the OutboxRepository port using DynamoDB. Dates are stored as
+// RFC 3339 UTC strings, so due notifications can be found by comparing them as strings.
+type DynamoDBOutboxRepository struct {
+	dynamoClient ports.DynamoDBClient
+	outboxTable  string
+}
+
+// NewDynamoDBOutboxRepository creates a new DynamoDBOutboxRepository
+func NewDynamoDBOutboxRepository(dynamoClient *dynamodb.Client, outboxTable string) *DynamoDBOutboxRepository {
+	return &DynamoDBOutboxRepository{
+		dynamoClient: dynamoClient,
+		outboxTable:  outboxTable,
+	}
+}
+
+// SaveNotification saves a notification to DynamoDB, keyed by notification ID. The write is conditional
+// on the stored version, so a notification changed by someone else since it was read is not overwritten.
+func (r *DynamoDBOutboxRepository) SaveNotification(ctx context.Context, notification *model.Notification) error {
+	version := notification.Version + 1
+
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"NotificationID": &types.AttributeValueMemberS{Value: notification.ID},
+		"Kind":           &types.AttributeValueMemberS{Value: string(notification.Kind)},
+		"Recipient":      &types.AttributeValueMemberS{Value: notification.Recipient},
+		"Payload":        &types.AttributeValueMemberS{Value: string(notification.Payload)},
+		"Status":         &types.AttributeValueMemberS{Value: string(notification.Status)},
+		"Attempts":       &types.AttributeValueMemberN{Value: strconv.Itoa(notification.Attempts)},
+		"NextAttemptAt":  &types.AttributeValueMemberS{Value: notification.NextAttemptAt.UTC().Format(time.RFC3339)},
+		"Version":        &types.AttributeValueMemberN{Value: strconv.Itoa(version)},
+		"CreatedAt":      &types.AttributeValueMemberS{Value: notification.CreatedAt.UTC().Format(time.RFC3339)},
+		"UpdatedAt":      &types.AttributeValueMemberS{Value: notification.UpdatedAt.UTC().Format(time.RFC3339)},
+	}
+	if notification.Error != "" {
+		item["Error"] = &types.AttributeValueMemberS{Value: notification.Error}
+	}
+	if notification.Status == model.NotificationStatusPending {
+		item["PendingStatus"] = &types.AttributeValueMemberS{Value: string(notification.Status)}
+	} else {
+		item["ExpiresAt"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(notification.UpdatedAt.Add(notificationTTL).Unix(), 10)}
+	}
+
+	// New notifications must not exist yet, and existing ones must still be at the version read
+	input := &dynamodb.PutItemInput{
+		TableName:           aws.String(r.outboxTable),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(NotificationID)"),
+	}
+	if notification.Version > 0 {
+		input.ConditionExpression = aws.String("Version = :version")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":version": &types.AttributeValueMemberN{Value: strconv.Itoa(notification.Version)},
+		}
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, input)
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return fmt.Errorf("error saving notification %s: %w", notification.ID, model.ErrNotificationChanged)
+	}
+	if err != nil {
+		return fmt.Errorf("error saving notification to DynamoDB: %w", err)
+	}
+
+	notification.Version = version
+	return nil
+}
+
+// GetNotification retrieves a notification by ID from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBOutboxRepository) GetNotification(ctx context.Context, notificationID string) (*model.Notification, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.outboxTable),
+		Key: map[string]types.AttributeValue{
+			"NotificationID": &types.AttributeValueMemberS{Value: notificationID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting notification from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	return notificationFromItem(result.Item)
+}
+
+// ListDueNotifications queries the pending notifications index for the notifications whose next attempt
+// is not after at, in the order of their next attempt, until limit of them are found
+func (r *DynamoDBOutboxRepository) ListDueNotifications(ctx context.Context, at time.Time, limit int) ([]*model.Notification, error) {
+	var notifications []*model.Notification
+	var startKey map[string]types.AttributeValue
+
+	for {
+		result, err := r.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.outboxTable),
+			IndexName:              aws.String(pendingNotificationsIndex),
+			KeyConditionExpression: aws.String("PendingStatus = :pending AND NextAttemptAt <= :at"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":pending": &types.AttributeValueMemberS{Value: string(model.NotificationStatusPending)},
+				":at":      &types.AttributeValueMemberS{Value: at.UTC().Format(time.RFC3339)},
+			},
+			Limit:             aws.Int32(int32(limit - len(notifications))),
+			ExclusiveStartKey: startKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error querying outbox table: %w", err)
+		}
+
+		for _, item := range result.Items {
+			notification, err := notificationFromItem(item)
+			if err != nil {
+				return nil, err
+			}
+
+			notifications = append(notifications, notification)
+			if len(notifications) == limit {
+				return notifications, nil
+			}
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = result.LastEvaluatedKey
+	}
+
+	return notifications, nil
+}
+
+// notificationFromItem converts an item of the outbox table to a notification
+func notificationFromItem(item map[string]types.AttributeValue) (*model.Notification, error) {
+	stringValue := func(name string) string {
+		if value, ok := item[name].(*types.AttributeValueMemberS); ok {
+			return value.Value
+		}
+		return ""
+	}
+	intValue := func(name string) (int, error) {
+		value, ok := item[name].(*types.AttributeValueMemberN)
+		if !ok {
+			return 0, nil
+		}
+		return strconv.Atoi(value.Value)
+	}
+
+	notification := &model.Notification{
+		ID:        stringValue("NotificationID"),
+		Kind:      model.NotificationKind(stringValue("Kind")),
+		Recipient: stringValue("Recipient"),
+		Payload:   []byte(stringValue("Payload")),
+		Status:    model.NotificationStatus(stringValue("Status")),
+		Error:     stringValue("Error"),
+	}
+
+	var err error
+	if notification.Attempts, err = intValue("Attempts"); err != nil {
+		return nil, fmt.Errorf("error parsing notification attempts: %w", err)
+	}
+	if notification.Version, err = intValue("Version"); err != nil {
+		return nil, fmt.Errorf("error parsing notification version: %w", err)
+	}
+	if notification.NextAttemptAt, err = time.Parse(time.RFC3339, stringValue("NextAttemptAt")); err != nil {
+		return nil, fmt.Errorf("error parsing notification next attempt date: %w", err)
+	}
+	if notification.CreatedAt, err = time.Parse(time.RFC3339, stringValue("CreatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing notification creation date: %w", err)
+	}
+	if notification.UpdatedAt, err = time.Parse(time.RFC3339, stringValue("UpdatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing notification update date: %w", err)
+	}
+
+	return notification, nil
+}
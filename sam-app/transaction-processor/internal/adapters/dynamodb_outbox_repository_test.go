@@ -0,0 +1,207 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBOutboxRepository_SaveNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBOutboxRepository{
+		dynamoClient: mockDynamo,
+		outboxTable:  "OutboxTable",
+	}
+
+	notification := model.NewNotification("n1", model.NotificationKindSummary, "user@example.com", []byte(`{"TotalBalance":10}`), time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC))
+
+	// New notifications are only created if they do not exist yet
+	mockDynamo.EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "OutboxTable", *input.TableName)
+			assert.Equal(t, "attribute_not_exists(NotificationID)", *input.ConditionExpression)
+			assert.Equal(t, "n1", input.Item["NotificationID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "pending", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, `{"TotalBalance":10}`, input.Item["Payload"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "2025-01-15T10:00:00Z", input.Item["NextAttemptAt"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "1", input.Item["Version"].(*types.AttributeValueMemberN).Value)
+			assert.NotContains(t, input.Item, "Error")
+			assert.Equal(t, "pending", input.Item["PendingStatus"].(*types.AttributeValueMemberS).Value)
+			assert.NotContains(t, input.Item, "ExpiresAt")
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	assert.NoError(t, repo.SaveNotification(context.Background(), notification))
+	assert.Equal(t, 1, notification.Version)
+
+	// Existing notifications are only replaced at the version they were read at
+	notification.Claim(time.Date(2025, time.January, 15, 10, 1, 0, 0, time.UTC), 5*time.Minute)
+	mockDynamo.EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "Version = :version", *input.ConditionExpression)
+			assert.Equal(t, "1", input.ExpressionAttributeValues[":version"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "2", input.Item["Version"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "1", input.Item["Attempts"].(*types.AttributeValueMemberN).Value)
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+		})
+
+	err := repo.SaveNotification(context.Background(), notification)
+
+	assert.True(t, errors.Is(err, model.ErrNotificationChanged), "expected ErrNotificationChanged, got %v", err)
+	assert.Equal(t, 1, notification.Version)
+
+	// Delivered notifications leave the pending notifications index and expire
+	notification.Deliver(time.Date(2025, time.January, 15, 10, 2, 0, 0, time.UTC))
+	mockDynamo.EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "delivered", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+			assert.NotContains(t, input.Item, "PendingStatus")
+			assert.Equal(t, "1739527320", input.Item["ExpiresAt"].(*types.AttributeValueMemberN).Value)
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	assert.NoError(t, repo.SaveNotification(context.Background(), notification))
+}
+
+func TestDynamoDBOutboxRepository_GetNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBOutboxRepository{
+		dynamoClient: mockDynamo,
+		outboxTable:  "OutboxTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"NotificationID": &types.AttributeValueMemberS{Value: "n1"},
+				"Kind":           &types.AttributeValueMemberS{Value: "consolidated-summary"},
+				"Recipient":      &types.AttributeValueMemberS{Value: "user@example.com"},
+				"Payload":        &types.AttributeValueMemberS{Value: `{"NetWorth":10}`},
+				"Status":         &types.AttributeValueMemberS{Value: "pending"},
+				"Attempts":       &types.AttributeValueMemberN{Value: "2"},
+				"NextAttemptAt":  &types.AttributeValueMemberS{Value: "2025-01-15T10:03:00Z"},
+				"Error":          &types.AttributeValueMemberS{Value: "connection refused"},
+				"Version":        &types.AttributeValueMemberN{Value: "4"},
+				"CreatedAt":      &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+				"UpdatedAt":      &types.AttributeValueMemberS{Value: "2025-01-15T10:01:00Z"},
+			},
+		}, nil)
+
+	notification, err := repo.GetNotification(context.Background(), "n1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Notification{
+		ID:            "n1",
+		Kind:          model.NotificationKindConsolidatedSummary,
+		Recipient:     "user@example.com",
+		Payload:       []byte(`{"NetWorth":10}`),
+		Status:        model.NotificationStatusPending,
+		Attempts:      2,
+		NextAttemptAt: time.Date(2025, time.January, 15, 10, 3, 0, 0, time.UTC),
+		Error:         "connection refused",
+		Version:       4,
+		CreatedAt:     time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2025, time.January, 15, 10, 1, 0, 0, time.UTC),
+	}, notification)
+}
+
+func TestDynamoDBOutboxRepository_GetNotification_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBOutboxRepository{
+		dynamoClient: mockDynamo,
+		outboxTable:  "OutboxTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{}, nil)
+
+	notification, err := repo.GetNotification(context.Background(), "missing")
+
+	assert.NoError(t, err)
+	assert.Nil(t, notification)
+}
+
+func TestDynamoDBOutboxRepository_ListDueNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBOutboxRepository{
+		dynamoClient: mockDynamo,
+		outboxTable:  "OutboxTable",
+	}
+
+	item := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"NotificationID": &types.AttributeValueMemberS{Value: id},
+			"Kind":           &types.AttributeValueMemberS{Value: "summary"},
+			"Status":         &types.AttributeValueMemberS{Value: "pending"},
+			"NextAttemptAt":  &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+			"CreatedAt":      &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+			"UpdatedAt":      &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+		}
+	}
+
+	// The query goes on to the next page until the limit is reached
+	gomock.InOrder(
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Equal(t, "OutboxTable", *input.TableName)
+				assert.Equal(t, "PendingNotifications", *input.IndexName)
+				assert.Equal(t, "PendingStatus = :pending AND NextAttemptAt <= :at", *input.KeyConditionExpression)
+				assert.Equal(t, "pending", input.ExpressionAttributeValues[":pending"].(*types.AttributeValueMemberS).Value)
+				assert.Equal(t, "2025-01-15T10:05:00Z", input.ExpressionAttributeValues[":at"].(*types.AttributeValueMemberS).Value)
+				assert.Equal(t, int32(2), *input.Limit)
+				assert.Nil(t, input.ExclusiveStartKey)
+				return &dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{item("n1")},
+					LastEvaluatedKey: map[string]types.AttributeValue{"NotificationID": &types.AttributeValueMemberS{Value: "n1"}},
+				}, nil
+			}),
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Equal(t, "n1", input.ExclusiveStartKey["NotificationID"].(*types.AttributeValueMemberS).Value)
+				assert.Equal(t, int32(1), *input.Limit)
+				return &dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{item("n2"), item("n3")},
+					LastEvaluatedKey: map[string]types.AttributeValue{"NotificationID": &types.AttributeValueMemberS{Value: "n3"}},
+				}, nil
+			}),
+	)
+
+	notifications, err := repo.ListDueNotifications(context.Background(), time.Date(2025, time.January, 15, 7, 5, 0, 0, time.FixedZone("UTC-3", -3*60*60)), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, "n1", notifications[0].ID)
+	assert.Equal(t, "n2", notifications[1].ID)
+}
@@ -3,6 +3,7 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"strconv"
 	"time"
 	"transaction-processor/internal/domain/model"
@@ -34,18 +35,48 @@ func NewDynamoDBRepository(
 }
 
 // SaveTransaction saves a transaction to DynamoDB
-func (r *DynamoDBRepository) SaveTransaction(tx *model.Transaction) error {
+func (r *DynamoDBRepository) SaveTransaction(ctx context.Context, tx *model.Transaction) error {
 	// Create the item
 	item := map[string]types.AttributeValue{
+		"AccountID": &types.AttributeValueMemberS{Value: tx.AccountID},
 		"ID":        &types.AttributeValueMemberS{Value: tx.ID},
 		"Date":      &types.AttributeValueMemberS{Value: tx.Date.Format(time.RFC3339)},
 		"Amount":    &types.AttributeValueMemberN{Value: strconv.FormatFloat(tx.Amount, 'f', 2, 64)},
 		"IsCredit":  &types.AttributeValueMemberBOOL{Value: tx.IsCredit},
 		"Timestamp": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
 	}
+	if tx.Category != "" {
+		item["Category"] = &types.AttributeValueMemberS{Value: tx.Category}
+	}
+	if tx.Description != "" {
+		item["Description"] = &types.AttributeValueMemberS{Value: tx.Description}
+	}
+	if !tx.ValueDate.IsZero() {
+		item["ValueDate"] = &types.AttributeValueMemberS{Value: tx.ValueDate.Format(time.RFC3339)}
+	}
+	if tx.Type != "" {
+		item["Type"] = &types.AttributeValueMemberS{Value: tx.Type}
+	}
+	if tx.Reference != "" {
+		item["Reference"] = &types.AttributeValueMemberS{Value: tx.Reference}
+	}
+	if tx.IsGenerated() {
+		item["Kind"] = &types.AttributeValueMemberS{Value: string(tx.Kind)}
+	}
+	item["Status"] = &types.AttributeValueMemberS{Value: string(tx.GetStatus())}
+	if len(tx.StatusHistory) > 0 {
+		history := make([]types.AttributeValue, 0, len(tx.StatusHistory))
+		for _, change := range tx.StatusHistory {
+			history = append(history, &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"Status":    &types.AttributeValueMemberS{Value: string(change.Status)},
+				"Timestamp": &types.AttributeValueMemberS{Value: change.Timestamp.Format(time.RFC3339)},
+			}})
+		}
+		item["StatusHistory"] = &types.AttributeValueMemberL{Value: history}
+	}
 
 	// Put the item in the table
-	_, err := r.dynamoClient.PutItem(context.TODO(), &dynamodb.PutItemInput{
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
 		TableName: aws.String(r.transactionsTable),
 		Item:      item,
 	})
@@ -56,8 +87,8 @@ func (r *DynamoDBRepository) SaveTransaction(tx *model.Transaction) error {
 	return nil
 }
 
-// SaveAccount saves account information to DynamoDB
-func (r *DynamoDBRepository) SaveAccount(accountID string, summary ports.EmailSummary) error {
+// SaveAccount saves account information and its owner to DynamoDB
+func (r *DynamoDBRepository) SaveAccount(ctx context.Context, owner model.AccountOwner, summary ports.EmailSummary) error {
 	// Create the monthly transaction counts attribute
 	monthlyCountsMap := make(map[string]types.AttributeValue)
 	for month, count := range summary.MonthlyTransactionCounts {
@@ -66,16 +97,34 @@ func (r *DynamoDBRepository) SaveAccount(accountID string, summary ports.EmailSu
 
 	// Create the item
 	item := map[string]types.AttributeValue{
-		"AccountID":           &types.AttributeValueMemberS{Value: accountID},
+		"AccountID":           &types.AttributeValueMemberS{Value: owner.AccountID},
 		"TotalBalance":        &types.AttributeValueMemberN{Value: strconv.FormatFloat(summary.TotalBalance, 'f', 2, 64)},
+		"AvailableBalance":    &types.AttributeValueMemberN{Value: strconv.FormatFloat(summary.AvailableBalance, 'f', 2, 64)},
 		"MonthlyTransactions": &types.AttributeValueMemberM{Value: monthlyCountsMap},
 		"AverageCreditAmount": &types.AttributeValueMemberN{Value: strconv.FormatFloat(summary.AverageCreditAmount, 'f', 2, 64)},
 		"AverageDebitAmount":  &types.AttributeValueMemberN{Value: strconv.FormatFloat(summary.AverageDebitAmount, 'f', 2, 64)},
 		"Timestamp":           &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
 	}
+	if owner.CustomerID != "" {
+		item["CustomerID"] = &types.AttributeValueMemberS{Value: owner.CustomerID}
+	}
+	if owner.Email != "" {
+		item["Email"] = &types.AttributeValueMemberS{Value: owner.Email}
+	}
+	if summary.StatementBalance != nil {
+		item["StatementBalance"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(summary.StatementBalance.Amount, 'f', 2, 64)}
+	}
+	if summary.Reconciliation != nil {
+		mismatches := make([]types.AttributeValue, 0, len(summary.Reconciliation.Mismatches))
+		for _, mismatch := range summary.Reconciliation.Mismatches {
+			mismatches = append(mismatches, &types.AttributeValueMemberS{Value: mismatch})
+		}
+		item["Reconciled"] = &types.AttributeValueMemberBOOL{Value: summary.Reconciliation.IsReconciled()}
+		item["ReconciliationMismatches"] = &types.AttributeValueMemberL{Value: mismatches}
+	}
 
 	// Put the item in the table
-	_, err := r.dynamoClient.PutItem(context.TODO(), &dynamodb.PutItemInput{
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
 		TableName: aws.String(r.accountsTable),
 		Item:      item,
 	})
@@ -86,50 +135,263 @@ func (r *DynamoDBRepository) SaveAccount(accountID string, summary ports.EmailSu
 	return nil
 }
 
-// GetTransactions retrieves all transactions for an account from DynamoDB
-func (r *DynamoDBRepository) GetTransactions(accountID string) ([]*model.Transaction, error) {
-	// This is a simplified implementation that doesn't filter by account ID
-	// In a real services, you would use a GSI or a query with a filter expression
+// ListAccounts retrieves a page of account owners from the accounts table. Pages follow the scan
+// order of the table, so any account of a page can be used to resume the scan after it.
+func (r *DynamoDBRepository) ListAccounts(ctx context.Context, startAfter string, limit int) ([]model.AccountOwner, string, error) {
+	input := &dynamodb.ScanInput{
+		TableName:            aws.String(r.accountsTable),
+		ProjectionExpression: aws.String("AccountID, CustomerID, Email"),
+		Limit:                aws.Int32(int32(limit)),
+	}
+	if startAfter != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"AccountID": &types.AttributeValueMemberS{Value: startAfter},
+		}
+	}
 
-	// Scan the table
-	result, err := r.dynamoClient.Scan(context.TODO(), &dynamodb.ScanInput{
-		TableName: aws.String(r.transactionsTable),
+	result, err := r.dynamoClient.Scan(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("error scanning accounts table: %w", err)
+	}
+
+	owners := make([]model.AccountOwner, 0, len(result.Items))
+	for _, item := range result.Items {
+		owners = append(owners, accountOwnerFromItem(item))
+	}
+
+	var next string
+	if accountID, ok := result.LastEvaluatedKey["AccountID"].(*types.AttributeValueMemberS); ok {
+		next = accountID.Value
+	}
+
+	return owners, next, nil
+}
+
+// GetAccountOwner retrieves the owner of an account from the accounts table, returning nil if it does not exist
+func (r *DynamoDBRepository) GetAccountOwner(ctx context.Context, accountID string) (*model.AccountOwner, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.accountsTable),
+		Key: map[string]types.AttributeValue{
+			"AccountID": &types.AttributeValueMemberS{Value: accountID},
+		},
+		ProjectionExpression: aws.String("AccountID, CustomerID, Email"),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error scanning transactions table: %w", err)
+		return nil, fmt.Errorf("error getting account from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
 	}
 
-	// Convert the items to transactions
+	owner := accountOwnerFromItem(result.Item)
+	return &owner, nil
+}
+
+// accountOwnerFromItem converts an item of the accounts table into the owner of the account
+func accountOwnerFromItem(item map[string]types.AttributeValue) model.AccountOwner {
+	var owner model.AccountOwner
+	if accountID, ok := item["AccountID"].(*types.AttributeValueMemberS); ok {
+		owner.AccountID = accountID.Value
+	}
+	if customerID, ok := item["CustomerID"].(*types.AttributeValueMemberS); ok {
+		owner.CustomerID = customerID.Value
+	}
+	if email, ok := item["Email"].(*types.AttributeValueMemberS); ok {
+		owner.Email = email.Value
+	}
+	return owner
+}
+
+// GetTransactions retrieves all transactions for an account from DynamoDB
+func (r *DynamoDBRepository) GetTransactions(ctx context.Context, accountID string) ([]*model.Transaction, error) {
 	var transactions []*model.Transaction
-	for _, item := range result.Items {
-		// Extract the values
-		id := item["ID"].(*types.AttributeValueMemberS).Value
-		dateStr := item["Date"].(*types.AttributeValueMemberS).Value
-		amountStr := item["Amount"].(*types.AttributeValueMemberN).Value
-		isCredit := item["IsCredit"].(*types.AttributeValueMemberBOOL).Value
-
-		// Parse the date
-		date, err := time.Parse(time.RFC3339, dateStr)
+
+	// Query the account partition, following pagination until every item was read
+	var startKey map[string]types.AttributeValue
+	for {
+		result, err := r.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(r.transactionsTable),
+			KeyConditionExpression: aws.String("AccountID = :accountID"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":accountID": &types.AttributeValueMemberS{Value: accountID},
+			},
+			ExclusiveStartKey: startKey,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("error parsing date: %w", err)
+			return nil, fmt.Errorf("error querying transactions table: %w", err)
 		}
 
-		// Parse the amount
-		amount, err := strconv.ParseFloat(amountStr, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing amount: %w", err)
+		// Convert the items to transactions
+		for _, item := range result.Items {
+			tx, err := transactionFromItem(item)
+			if err != nil {
+				return nil, err
+			}
+
+			transactions = append(transactions, tx)
 		}
 
-		// Create the transaction
-		tx := &model.Transaction{
-			ID:       id,
-			Date:     date,
-			Amount:   amount,
-			IsCredit: isCredit,
+		if len(result.LastEvaluatedKey) == 0 {
+			break
 		}
+		startKey = result.LastEvaluatedKey
+	}
+
+	return transactions, nil
+}
+
+const (
+	// batchGetLimit is the maximum number of keys DynamoDB reads in a single BatchGetItem request
+	batchGetLimit = 100
+
+	// batchGetRetries is how many times keys left unprocessed by a BatchGetItem request are requested again
+	batchGetRetries = 5
 
-		transactions = append(transactions, tx)
+	// batchGetBackoff is the longest wait before the first retry of unprocessed keys, doubled with each retry
+	batchGetBackoff = 50 * time.Millisecond
+)
+
+// GetTransactionsByID retrieves the transactions of an account with the given IDs from DynamoDB, keyed by
+// ID, reading them in batches of up to 100 keys. Keys left unprocessed by DynamoDB, typically when the
+// table is throttled, are requested again after an exponential backoff with jitter, up to
+// batchGetRetries times.
+func (r *DynamoDBRepository) GetTransactionsByID(ctx context.Context, accountID string, ids []string) (map[string]*model.Transaction, error) {
+	// Build the keys of the distinct IDs
+	var keys []map[string]types.AttributeValue
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		keys = append(keys, map[string]types.AttributeValue{
+			"AccountID": &types.AttributeValueMemberS{Value: accountID},
+			"ID":        &types.AttributeValueMemberS{Value: id},
+		})
+	}
+
+	transactions := make(map[string]*model.Transaction, len(keys))
+	for start := 0; start < len(keys); start += batchGetLimit {
+		pending := keys[start:min(start+batchGetLimit, len(keys))]
+		for retry := 0; len(pending) > 0; retry++ {
+			if retry > batchGetRetries {
+				return nil, fmt.Errorf("error getting transactions from DynamoDB: %d keys still unprocessed after %d retries", len(pending), batchGetRetries)
+			}
+			if retry > 0 {
+				if err := sleepContext(ctx, time.Duration(rand.Int64N(int64(batchGetBackoff<<(retry-1))))); err != nil {
+					return nil, fmt.Errorf("error getting transactions from DynamoDB: %w", err)
+				}
+			}
+
+			result, err := r.dynamoClient.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{
+					r.transactionsTable: {Keys: pending},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error getting transactions from DynamoDB: %w", err)
+			}
+
+			// Convert the items to transactions
+			for _, item := range result.Responses[r.transactionsTable] {
+				tx, err := transactionFromItem(item)
+				if err != nil {
+					return nil, err
+				}
+				transactions[tx.ID] = tx
+			}
+
+			pending = result.UnprocessedKeys[r.transactionsTable].Keys
+		}
 	}
 
 	return transactions, nil
 }
+
+// sleepContext waits for d, returning the error of ctx if it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// transactionFromItem converts a DynamoDB item into a transaction
+func transactionFromItem(item map[string]types.AttributeValue) (*model.Transaction, error) {
+	// Extract the values
+	id := item["ID"].(*types.AttributeValueMemberS).Value
+	dateStr := item["Date"].(*types.AttributeValueMemberS).Value
+	amountStr := item["Amount"].(*types.AttributeValueMemberN).Value
+	isCredit := item["IsCredit"].(*types.AttributeValueMemberBOOL).Value
+
+	// Parse the date
+	date, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing date: %w", err)
+	}
+
+	// Parse the amount
+	amount, err := strconv.ParseFloat(amountStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing amount: %w", err)
+	}
+
+	// Create the transaction
+	tx := &model.Transaction{
+		ID:       id,
+		Date:     date,
+		Amount:   amount,
+		IsCredit: isCredit,
+	}
+	if accountID, ok := item["AccountID"].(*types.AttributeValueMemberS); ok {
+		tx.AccountID = accountID.Value
+	}
+	if category, ok := item["Category"].(*types.AttributeValueMemberS); ok {
+		tx.Category = category.Value
+	}
+	if description, ok := item["Description"].(*types.AttributeValueMemberS); ok {
+		tx.Description = description.Value
+	}
+	if valueDate, ok := item["ValueDate"].(*types.AttributeValueMemberS); ok {
+		tx.ValueDate, err = time.Parse(time.RFC3339, valueDate.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value date: %w", err)
+		}
+	}
+	if txType, ok := item["Type"].(*types.AttributeValueMemberS); ok {
+		tx.Type = txType.Value
+	}
+	if reference, ok := item["Reference"].(*types.AttributeValueMemberS); ok {
+		tx.Reference = reference.Value
+	}
+	if kind, ok := item["Kind"].(*types.AttributeValueMemberS); ok {
+		tx.Kind = model.TransactionKind(kind.Value)
+	}
+	if status, ok := item["Status"].(*types.AttributeValueMemberS); ok {
+		tx.Status = model.TransactionStatus(status.Value)
+	}
+	if history, ok := item["StatusHistory"].(*types.AttributeValueMemberL); ok {
+		for _, entry := range history.Value {
+			change, ok := entry.(*types.AttributeValueMemberM)
+			if !ok {
+				continue
+			}
+			timestamp, err := time.Parse(time.RFC3339, change.Value["Timestamp"].(*types.AttributeValueMemberS).Value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing status timestamp: %w", err)
+			}
+			tx.StatusHistory = append(tx.StatusHistory, model.StatusChange{
+				Status:    model.TransactionStatus(change.Value["Status"].(*types.AttributeValueMemberS).Value),
+				Timestamp: timestamp,
+			})
+		}
+	}
+
+	return tx, nil
+}
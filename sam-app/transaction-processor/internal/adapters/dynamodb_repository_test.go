@@ -1,6 +1,9 @@
 package adapters
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
@@ -36,7 +39,7 @@ func TestDynamoDBRepository_SaveTransaction(t *testing.T) {
 		PutItem(gomock.Any(), gomock.Any()).
 		Return(&dynamodb.PutItemOutput{}, nil)
 
-	err := ports.TransactionRepository.SaveTransaction(repo, tx)
+	err := ports.TransactionRepository.SaveTransaction(repo, context.Background(), tx)
 
 	assert.NoError(t, err)
 }
@@ -53,7 +56,7 @@ func TestDynamoDBRepository_SaveAccount(t *testing.T) {
 		accountsTable:     "AccountsTable",
 	}
 
-	accountID := "account123"
+	owner := model.AccountOwner{AccountID: "account123", CustomerID: "cust1", Email: "user@example.com"}
 	summary := ports.EmailSummary{
 		TotalBalance:        1000.50,
 		AverageCreditAmount: 300.75,
@@ -67,13 +70,114 @@ func TestDynamoDBRepository_SaveAccount(t *testing.T) {
 	mockDynamo.
 		EXPECT().
 		PutItem(gomock.Any(), gomock.Any()).
-		Return(&dynamodb.PutItemOutput{}, nil)
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "AccountsTable", *input.TableName)
+			assert.Equal(t, "account123", input.Item["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "cust1", input.Item["CustomerID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "user@example.com", input.Item["Email"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.PutItemOutput{}, nil
+		})
 
-	err := repo.SaveAccount(accountID, summary)
+	err := repo.SaveAccount(context.Background(), owner, summary)
 
 	assert.NoError(t, err)
 }
 
+func TestDynamoDBRepository_ListAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBRepository{
+		dynamoClient:      mockDynamo,
+		transactionsTable: "TransactionsTable",
+		accountsTable:     "AccountsTable",
+	}
+
+	mockDynamo.
+		EXPECT().
+		Scan(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			assert.Equal(t, "AccountsTable", *input.TableName)
+			assert.Equal(t, int32(2), *input.Limit)
+			assert.Equal(t, "acc1", input.ExclusiveStartKey["AccountID"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.ScanOutput{
+				Items: []map[string]types.AttributeValue{
+					{
+						"AccountID":  &types.AttributeValueMemberS{Value: "acc2"},
+						"CustomerID": &types.AttributeValueMemberS{Value: "cust1"},
+					},
+					{
+						"AccountID": &types.AttributeValueMemberS{Value: "acc3"},
+						"Email":     &types.AttributeValueMemberS{Value: "user@example.com"},
+					},
+				},
+				LastEvaluatedKey: map[string]types.AttributeValue{
+					"AccountID": &types.AttributeValueMemberS{Value: "acc3"},
+				},
+			}, nil
+		})
+
+	owners, next, err := repo.ListAccounts(context.Background(), "acc1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AccountOwner{
+		{AccountID: "acc2", CustomerID: "cust1"},
+		{AccountID: "acc3", Email: "user@example.com"},
+	}, owners)
+	assert.Equal(t, "acc3", next)
+
+	// The last page has no LastEvaluatedKey
+	mockDynamo.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(&dynamodb.ScanOutput{}, nil)
+
+	owners, next, err = repo.ListAccounts(context.Background(), "", 2)
+
+	assert.NoError(t, err)
+	assert.Empty(t, owners)
+	assert.Empty(t, next)
+}
+
+func TestDynamoDBRepository_GetAccountOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBRepository{
+		dynamoClient:      mockDynamo,
+		transactionsTable: "TransactionsTable",
+		accountsTable:     "AccountsTable",
+	}
+
+	mockDynamo.
+		EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			assert.Equal(t, "AccountsTable", *input.TableName)
+			assert.Equal(t, "acc1", input.Key["AccountID"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.GetItemOutput{
+				Item: map[string]types.AttributeValue{
+					"AccountID":  &types.AttributeValueMemberS{Value: "acc1"},
+					"CustomerID": &types.AttributeValueMemberS{Value: "cust1"},
+					"Email":      &types.AttributeValueMemberS{Value: "user@example.com"},
+				},
+			}, nil
+		})
+
+	owner, err := repo.GetAccountOwner(context.Background(), "acc1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.AccountOwner{AccountID: "acc1", CustomerID: "cust1", Email: "user@example.com"}, owner)
+
+	mockDynamo.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+
+	owner, err = repo.GetAccountOwner(context.Background(), "missing")
+
+	assert.NoError(t, err)
+	assert.Nil(t, owner)
+}
+
 func TestDynamoDBRepository_GetTransactions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -91,8 +195,8 @@ func TestDynamoDBRepository_GetTransactions(t *testing.T) {
 	// Mock DynamoDB response
 	now := time.Now().UTC().Truncate(time.Second)
 	mockDynamo.EXPECT().
-		Scan(gomock.Any(), gomock.Any()).
-		Return(&dynamodb.ScanOutput{
+		Query(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.QueryOutput{
 			Items: []map[string]types.AttributeValue{
 				{
 					"ID":       &types.AttributeValueMemberS{Value: "tx1"},
@@ -103,7 +207,7 @@ func TestDynamoDBRepository_GetTransactions(t *testing.T) {
 			},
 		}, nil)
 
-	txs, err := repo.GetTransactions(accountID)
+	txs, err := repo.GetTransactions(context.Background(), accountID)
 
 	assert.NoError(t, err)
 	assert.Len(t, txs, 1)
@@ -112,3 +216,208 @@ func TestDynamoDBRepository_GetTransactions(t *testing.T) {
 	assert.Equal(t, true, txs[0].IsCredit)
 	assert.Equal(t, now, txs[0].Date)
 }
+
+func TestDynamoDBRepository_SaveTransaction_StatusHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBRepository{
+		dynamoClient:      mockDynamo,
+		transactionsTable: "TransactionsTable",
+		accountsTable:     "AccountsTable",
+	}
+
+	authorizedAt := time.Date(2025, 6, 29, 12, 0, 0, 0, time.UTC)
+	postedAt := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	tx := &model.Transaction{
+		ID:     "tx123",
+		Date:   time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC),
+		Amount: 12.5,
+		Status: model.TransactionStatusPosted,
+		StatusHistory: []model.StatusChange{
+			{Status: model.TransactionStatusPending, Timestamp: authorizedAt},
+			{Status: model.TransactionStatusPosted, Timestamp: postedAt},
+		},
+	}
+
+	var savedItem map[string]types.AttributeValue
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			savedItem = input.Item
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	assert.NoError(t, repo.SaveTransaction(context.Background(), tx))
+	assert.Equal(t, "posted", savedItem["Status"].(*types.AttributeValueMemberS).Value)
+	assert.Len(t, savedItem["StatusHistory"].(*types.AttributeValueMemberL).Value, 2)
+
+	// The stored item is read back with its full history
+	mockDynamo.EXPECT().
+		BatchGetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.BatchGetItemOutput{
+			Responses: map[string][]map[string]types.AttributeValue{"TransactionsTable": {savedItem}},
+		}, nil)
+
+	stored, err := repo.GetTransactionsByID(context.Background(), "acc123", []string{"tx123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.TransactionStatusPosted, stored["tx123"].Status)
+	assert.Equal(t, tx.StatusHistory, stored["tx123"].StatusHistory)
+}
+
+func TestDynamoDBRepository_GetTransactionsByID_Batches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBRepository{
+		dynamoClient:      mockDynamo,
+		transactionsTable: "TransactionsTable",
+		accountsTable:     "AccountsTable",
+	}
+
+	// 150 distinct IDs, one of them repeated
+	ids := []string{"tx0"}
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprintf("tx%d", i))
+	}
+
+	item := func(key map[string]types.AttributeValue) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"AccountID": key["AccountID"],
+			"ID":        key["ID"],
+			"Date":      &types.AttributeValueMemberS{Value: "2025-06-29T00:00:00Z"},
+			"Amount":    &types.AttributeValueMemberN{Value: "10"},
+			"IsCredit":  &types.AttributeValueMemberBOOL{Value: true},
+		}
+	}
+
+	// Keys are read 100 at a time and the unprocessed ones are requested again. Only tx1 is missing.
+	var requested []int
+	mockDynamo.EXPECT().
+		BatchGetItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			keys := input.RequestItems["TransactionsTable"].Keys
+			requested = append(requested, len(keys))
+			output := &dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{}}
+			for i, key := range keys {
+				if len(keys) == 100 && i >= 90 {
+					unprocessed := output.UnprocessedKeys["TransactionsTable"]
+					unprocessed.Keys = append(unprocessed.Keys, key)
+					output.UnprocessedKeys = map[string]types.KeysAndAttributes{"TransactionsTable": unprocessed}
+					continue
+				}
+				if key["ID"].(*types.AttributeValueMemberS).Value == "tx1" {
+					continue
+				}
+				output.Responses["TransactionsTable"] = append(output.Responses["TransactionsTable"], item(key))
+			}
+			return output, nil
+		}).
+		Times(3)
+
+	transactions, err := repo.GetTransactionsByID(context.Background(), "acc123", ids)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{100, 10, 50}, requested)
+	assert.Len(t, transactions, 149)
+	assert.NotContains(t, transactions, "tx1")
+	assert.Equal(t, 10.0, transactions["tx149"].Amount)
+}
+
+func TestDynamoDBRepository_GetTransactionsByID_Unprocessed(t *testing.T) {
+	// throttled leaves every requested key unprocessed
+	throttled := func(_ interface{}, input *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+		return &dynamodb.BatchGetItemOutput{UnprocessedKeys: input.RequestItems}, nil
+	}
+
+	t.Run("gives up once the retries run out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+		repo := &DynamoDBRepository{dynamoClient: mockDynamo, transactionsTable: "TransactionsTable"}
+
+		mockDynamo.EXPECT().
+			BatchGetItem(gomock.Any(), gomock.Any()).
+			DoAndReturn(throttled).
+			Times(batchGetRetries + 1)
+
+		transactions, err := repo.GetTransactionsByID(context.Background(), "acc123", []string{"tx1", "tx2"})
+
+		assert.EqualError(t, err, "error getting transactions from DynamoDB: 2 keys still unprocessed after 5 retries")
+		assert.Nil(t, transactions)
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+		repo := &DynamoDBRepository{dynamoClient: mockDynamo, transactionsTable: "TransactionsTable"}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		mockDynamo.EXPECT().
+			BatchGetItem(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.BatchGetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+				cancel()
+				return throttled(nil, input)
+			})
+
+		_, err := repo.GetTransactionsByID(ctx, "acc123", []string{"tx1"})
+
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	})
+}
+
+func TestDynamoDBRepository_GetTransactions_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBRepository{
+		dynamoClient:      mockDynamo,
+		transactionsTable: "TransactionsTable",
+		accountsTable:     "AccountsTable",
+	}
+
+	item := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"AccountID": &types.AttributeValueMemberS{Value: "account123"},
+			"ID":        &types.AttributeValueMemberS{Value: id},
+			"Date":      &types.AttributeValueMemberS{Value: "2025-07-01T00:00:00Z"},
+			"Amount":    &types.AttributeValueMemberN{Value: "10.00"},
+			"IsCredit":  &types.AttributeValueMemberBOOL{Value: true},
+		}
+	}
+
+	gomock.InOrder(
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Equal(t, "account123", input.ExpressionAttributeValues[":accountID"].(*types.AttributeValueMemberS).Value)
+				assert.Nil(t, input.ExclusiveStartKey)
+				return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item("tx1")}, LastEvaluatedKey: item("tx1")}, nil
+			}),
+		mockDynamo.EXPECT().
+			Query(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.NotNil(t, input.ExclusiveStartKey)
+				return &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item("tx2")}}, nil
+			}),
+	)
+
+	txs, err := repo.GetTransactions(context.Background(), "account123")
+
+	assert.NoError(t, err)
+	assert.Len(t, txs, 2)
+	assert.Equal(t, "account123", txs[1].AccountID)
+	assert.Equal(t, "tx2", txs[1].ID)
+}
@@ -0,0 +1,126 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBStatementRunRepository implements the StatementRunRepository port using DynamoDB
+type DynamoDBStatementRunRepository struct {
+	dynamoClient ports.DynamoDBClient
+	runsTable    string
+}
+
+// NewDynamoDBStatementRunRepository creates a new DynamoDBStatementRunRepository
+func NewDynamoDBStatementRunRepository(dynamoClient *dynamodb.Client, runsTable string) *DynamoDBStatementRunRepository {
+	return &DynamoDBStatementRunRepository{
+		dynamoClient: dynamoClient,
+		runsTable:    runsTable,
+	}
+}
+
+// SaveStatementRun saves the checkpoint of a statement run to DynamoDB, keyed by period
+func (r *DynamoDBStatementRunRepository) SaveStatementRun(ctx context.Context, run *model.StatementRun) error {
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"Period":    &types.AttributeValueMemberS{Value: run.Period},
+		"Status":    &types.AttributeValueMemberS{Value: string(run.Status)},
+		"Sent":      &types.AttributeValueMemberN{Value: strconv.Itoa(run.Sent)},
+		"Skipped":   &types.AttributeValueMemberN{Value: strconv.Itoa(run.Skipped)},
+		"Failed":    &types.AttributeValueMemberN{Value: strconv.Itoa(run.Failed)},
+		"StartedAt": &types.AttributeValueMemberS{Value: run.StartedAt.Format(time.RFC3339)},
+		"UpdatedAt": &types.AttributeValueMemberS{Value: run.UpdatedAt.Format(time.RFC3339)},
+	}
+
+	// Optional attributes are only stored when set
+	for name, value := range map[string]string{
+		"Cursor": run.Cursor,
+		"Error":  run.Error,
+	} {
+		if value != "" {
+			item[name] = &types.AttributeValueMemberS{Value: value}
+		}
+	}
+	if len(run.FailedAccounts) > 0 {
+		item["FailedAccounts"] = &types.AttributeValueMemberSS{Value: run.FailedAccounts}
+	}
+
+	// Put the item in the table
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.runsTable),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving statement run to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatementRun retrieves the statement run of a period from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBStatementRunRepository) GetStatementRun(ctx context.Context, period string) (*model.StatementRun, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.runsTable),
+		Key: map[string]types.AttributeValue{
+			"Period": &types.AttributeValueMemberS{Value: period},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting statement run from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	stringValue := func(name string) string {
+		if value, ok := result.Item[name].(*types.AttributeValueMemberS); ok {
+			return value.Value
+		}
+		return ""
+	}
+	intValue := func(name string) (int, error) {
+		value, ok := result.Item[name].(*types.AttributeValueMemberN)
+		if !ok {
+			return 0, nil
+		}
+		return strconv.Atoi(value.Value)
+	}
+
+	run := &model.StatementRun{
+		Period: period,
+		Status: model.StatementRunStatus(stringValue("Status")),
+		Cursor: stringValue("Cursor"),
+		Error:  stringValue("Error"),
+	}
+	if failedAccounts, ok := result.Item["FailedAccounts"].(*types.AttributeValueMemberSS); ok {
+		run.FailedAccounts = failedAccounts.Value
+	}
+
+	if run.Sent, err = intValue("Sent"); err != nil {
+		return nil, fmt.Errorf("error parsing statement run sent count: %w", err)
+	}
+	if run.Skipped, err = intValue("Skipped"); err != nil {
+		return nil, fmt.Errorf("error parsing statement run skipped count: %w", err)
+	}
+	if run.Failed, err = intValue("Failed"); err != nil {
+		return nil, fmt.Errorf("error parsing statement run failed count: %w", err)
+	}
+	if run.StartedAt, err = time.Parse(time.RFC3339, stringValue("StartedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing statement run start date: %w", err)
+	}
+	if run.UpdatedAt, err = time.Parse(time.RFC3339, stringValue("UpdatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing statement run update date: %w", err)
+	}
+
+	return run, nil
+}
@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBStatementRunRepository_SaveStatementRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBStatementRunRepository{
+		dynamoClient: mockDynamo,
+		runsTable:    "StatementRunsTable",
+	}
+
+	run := model.NewStatementRun("2025-06", time.Date(2025, time.July, 1, 6, 0, 0, 0, time.UTC))
+	run.MarkFailed("acc1", errors.New("throttled"), time.Date(2025, time.July, 1, 6, 0, 2, 0, time.UTC))
+	run.MarkSent("acc2", time.Date(2025, time.July, 1, 6, 0, 5, 0, time.UTC))
+
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "StatementRunsTable", *input.TableName)
+			assert.Equal(t, "2025-06", input.Item["Period"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "running", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "acc2", input.Item["Cursor"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "1", input.Item["Sent"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "1", input.Item["Failed"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, []string{"acc1"}, input.Item["FailedAccounts"].(*types.AttributeValueMemberSS).Value)
+			assert.Equal(t, "throttled", input.Item["Error"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "2025-07-01T06:00:05Z", input.Item["UpdatedAt"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	err := repo.SaveStatementRun(context.Background(), run)
+
+	assert.NoError(t, err)
+}
+
+func TestDynamoDBStatementRunRepository_GetStatementRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBStatementRunRepository{
+		dynamoClient: mockDynamo,
+		runsTable:    "StatementRunsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"Period":         &types.AttributeValueMemberS{Value: "2025-06"},
+				"Status":         &types.AttributeValueMemberS{Value: "running"},
+				"Cursor":         &types.AttributeValueMemberS{Value: "acc3"},
+				"Sent":           &types.AttributeValueMemberN{Value: "2"},
+				"Skipped":        &types.AttributeValueMemberN{Value: "1"},
+				"Failed":         &types.AttributeValueMemberN{Value: "1"},
+				"FailedAccounts": &types.AttributeValueMemberSS{Value: []string{"acc2"}},
+				"StartedAt":      &types.AttributeValueMemberS{Value: "2025-07-01T06:00:00Z"},
+				"UpdatedAt":      &types.AttributeValueMemberS{Value: "2025-07-01T06:00:09Z"},
+			},
+		}, nil)
+
+	run, err := repo.GetStatementRun(context.Background(), "2025-06")
+
+	assert.NoError(t, err)
+	assert.False(t, run.IsCompleted())
+	assert.Equal(t, "acc3", run.Cursor)
+	assert.Equal(t, 2, run.Sent)
+	assert.Equal(t, 1, run.Skipped)
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, []string{"acc2"}, run.FailedAccounts)
+	assert.Equal(t, time.Date(2025, time.July, 1, 6, 0, 9, 0, time.UTC), run.UpdatedAt)
+
+	mockDynamo.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+
+	run, err = repo.GetStatementRun(context.Background(), "2025-05")
+
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+}
@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBTransactionMigrator implements the TransactionMigrator port using DynamoDB. It copies the items
+// of the legacy Transactions table, keyed by ID, to the AccountTransactions table, keyed by account and ID.
+type DynamoDBTransactionMigrator struct {
+	dynamoClient      ports.DynamoDBClient
+	legacyTable       string
+	transactionsTable string
+}
+
+// NewDynamoDBTransactionMigrator creates a new DynamoDBTransactionMigrator
+func NewDynamoDBTransactionMigrator(dynamoClient *dynamodb.Client, legacyTable, transactionsTable string) *DynamoDBTransactionMigrator {
+	return &DynamoDBTransactionMigrator{
+		dynamoClient:      dynamoClient,
+		legacyTable:       legacyTable,
+		transactionsTable: transactionsTable,
+	}
+}
+
+// MigrateTransactions scans a page of the legacy table and copies its items to the account. The copies
+// are conditional on the transaction not being stored yet, so running the migration again, or after the
+// account processed newer files, does not overwrite anything.
+func (m *DynamoDBTransactionMigrator) MigrateTransactions(ctx context.Context, accountID, startAfter string, limit int) (int, int, string, error) {
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(m.legacyTable),
+		Limit:     aws.Int32(int32(limit)),
+	}
+	if startAfter != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			"ID": &types.AttributeValueMemberS{Value: startAfter},
+		}
+	}
+
+	result, err := m.dynamoClient.Scan(ctx, input)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("error scanning legacy transactions table: %w", err)
+	}
+
+	copied, skipped := 0, 0
+	for _, item := range result.Items {
+		migrated := make(map[string]types.AttributeValue, len(item)+1)
+		for name, value := range item {
+			migrated[name] = value
+		}
+		migrated["AccountID"] = &types.AttributeValueMemberS{Value: accountID}
+
+		_, err := m.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:           aws.String(m.transactionsTable),
+			Item:                migrated,
+			ConditionExpression: aws.String("attribute_not_exists(ID)"),
+		})
+		var conditionFailed *types.ConditionalCheckFailedException
+		switch {
+		case errors.As(err, &conditionFailed):
+			skipped++
+		case err != nil:
+			return copied, skipped, "", fmt.Errorf("error copying legacy transaction: %w", err)
+		default:
+			copied++
+		}
+	}
+
+	var next string
+	if id, ok := result.LastEvaluatedKey["ID"].(*types.AttributeValueMemberS); ok {
+		next = id.Value
+	}
+
+	return copied, skipped, next, nil
+}
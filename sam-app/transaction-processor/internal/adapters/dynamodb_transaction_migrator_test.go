@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBTransactionMigrator_MigrateTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	migrator := &DynamoDBTransactionMigrator{
+		dynamoClient:      mockDynamo,
+		legacyTable:       "Transactions",
+		transactionsTable: "AccountTransactions",
+	}
+
+	legacyItem := func(id string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"ID":       &types.AttributeValueMemberS{Value: id},
+			"Amount":   &types.AttributeValueMemberN{Value: "10"},
+			"IsCredit": &types.AttributeValueMemberBOOL{Value: true},
+		}
+	}
+
+	mockDynamo.
+		EXPECT().
+		Scan(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			assert.Equal(t, "Transactions", *input.TableName)
+			assert.Equal(t, int32(2), *input.Limit)
+			assert.Equal(t, "0", input.ExclusiveStartKey["ID"].(*types.AttributeValueMemberS).Value)
+			return &dynamodb.ScanOutput{
+				Items: []map[string]types.AttributeValue{legacyItem("1"), legacyItem("2")},
+				LastEvaluatedKey: map[string]types.AttributeValue{
+					"ID": &types.AttributeValueMemberS{Value: "2"},
+				},
+			}, nil
+		})
+
+	var copiedIDs []string
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "AccountTransactions", *input.TableName)
+			assert.Equal(t, "acc1", input.Item["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "10", input.Item["Amount"].(*types.AttributeValueMemberN).Value)
+			assert.Equal(t, "attribute_not_exists(ID)", *input.ConditionExpression)
+
+			id := input.Item["ID"].(*types.AttributeValueMemberS).Value
+			if id == "2" {
+				return nil, &types.ConditionalCheckFailedException{}
+			}
+			copiedIDs = append(copiedIDs, id)
+			return &dynamodb.PutItemOutput{}, nil
+		}).
+		Times(2)
+
+	copied, skipped, next, err := migrator.MigrateTransactions(context.Background(), "acc1", "0", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, copied)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, "2", next)
+	assert.Equal(t, []string{"1"}, copiedIDs)
+}
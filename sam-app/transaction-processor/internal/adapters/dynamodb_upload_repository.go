@@ -0,0 +1,150 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoDBUploadRepository implements the UploadRepository port using DynamoDB
+type DynamoDBUploadRepository struct {
+	dynamoClient ports.DynamoDBClient
+	uploadsTable string
+}
+
+// NewDynamoDBUploadRepository creates a new DynamoDBUploadRepository
+func NewDynamoDBUploadRepository(dynamoClient *dynamodb.Client, uploadsTable string) *DynamoDBUploadRepository {
+	return &DynamoDBUploadRepository{
+		dynamoClient: dynamoClient,
+		uploadsTable: uploadsTable,
+	}
+}
+
+// SaveUpload saves an upload to DynamoDB, keyed by upload ID
+func (r *DynamoDBUploadRepository) SaveUpload(ctx context.Context, upload *model.Upload) error {
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.uploadsTable),
+		Item:      uploadItem(upload),
+	})
+	if err != nil {
+		return fmt.Errorf("error saving upload to DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimUpload saves a claimed upload to DynamoDB. The write is conditional on the stored upload being
+// pending or claimed until before at, so concurrent notifications of the same object cannot both claim it.
+func (r *DynamoDBUploadRepository) ClaimUpload(ctx context.Context, upload *model.Upload, at time.Time) error {
+	_, err := r.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.uploadsTable),
+		Item:                     uploadItem(upload),
+		ConditionExpression:      aws.String("#status = :pending OR (#status = :processing AND ClaimedUntil <= :at)"),
+		ExpressionAttributeNames: map[string]string{"#status": "Status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pending":    &types.AttributeValueMemberS{Value: string(model.UploadStatusPending)},
+			":processing": &types.AttributeValueMemberS{Value: string(model.UploadStatusProcessing)},
+			":at":         &types.AttributeValueMemberS{Value: at.UTC().Format(time.RFC3339)},
+		},
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return fmt.Errorf("error claiming upload %s: %w", upload.ID, model.ErrUploadClaimed)
+	}
+	if err != nil {
+		return fmt.Errorf("error claiming upload in DynamoDB: %w", err)
+	}
+
+	return nil
+}
+
+// uploadItem converts an upload to an item of the uploads table
+func uploadItem(upload *model.Upload) map[string]types.AttributeValue {
+	// Create the item
+	item := map[string]types.AttributeValue{
+		"UploadID":  &types.AttributeValueMemberS{Value: upload.ID},
+		"AccountID": &types.AttributeValueMemberS{Value: upload.AccountID},
+		"Email":     &types.AttributeValueMemberS{Value: upload.Email},
+		"FileName":  &types.AttributeValueMemberS{Value: upload.FileName},
+		"ObjectKey": &types.AttributeValueMemberS{Value: upload.Key},
+		"Status":    &types.AttributeValueMemberS{Value: string(upload.Status)},
+		"CreatedAt": &types.AttributeValueMemberS{Value: upload.CreatedAt.Format(time.RFC3339)},
+		"ExpiresAt": &types.AttributeValueMemberS{Value: upload.ExpiresAt.Format(time.RFC3339)},
+		"Timestamp": &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+	}
+
+	// Optional attributes are only stored when set
+	for name, value := range map[string]string{
+		"CustomerID":  upload.CustomerID,
+		"CSVProfile":  upload.CSVProfile,
+		"ContentType": upload.ContentType,
+		"Error":       upload.Error,
+	} {
+		if value != "" {
+			item[name] = &types.AttributeValueMemberS{Value: value}
+		}
+	}
+	if !upload.ClaimedUntil.IsZero() {
+		item["ClaimedUntil"] = &types.AttributeValueMemberS{Value: upload.ClaimedUntil.UTC().Format(time.RFC3339)}
+	}
+
+	return item
+}
+
+// GetUpload retrieves an upload by ID from DynamoDB, returning nil if it does not exist
+func (r *DynamoDBUploadRepository) GetUpload(ctx context.Context, uploadID string) (*model.Upload, error) {
+	result, err := r.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.uploadsTable),
+		Key: map[string]types.AttributeValue{
+			"UploadID": &types.AttributeValueMemberS{Value: uploadID},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting upload from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	stringValue := func(name string) string {
+		if value, ok := result.Item[name].(*types.AttributeValueMemberS); ok {
+			return value.Value
+		}
+		return ""
+	}
+
+	upload := &model.Upload{
+		ID:          uploadID,
+		AccountID:   stringValue("AccountID"),
+		CustomerID:  stringValue("CustomerID"),
+		Email:       stringValue("Email"),
+		CSVProfile:  stringValue("CSVProfile"),
+		FileName:    stringValue("FileName"),
+		ContentType: stringValue("ContentType"),
+		Key:         stringValue("ObjectKey"),
+		Status:      model.UploadStatus(stringValue("Status")),
+		Error:       stringValue("Error"),
+	}
+
+	if upload.CreatedAt, err = time.Parse(time.RFC3339, stringValue("CreatedAt")); err != nil {
+		return nil, fmt.Errorf("error parsing upload creation date: %w", err)
+	}
+	if upload.ExpiresAt, err = time.Parse(time.RFC3339, stringValue("ExpiresAt")); err != nil {
+		return nil, fmt.Errorf("error parsing upload expiration date: %w", err)
+	}
+	if claimedUntil := stringValue("ClaimedUntil"); claimedUntil != "" {
+		if upload.ClaimedUntil, err = time.Parse(time.RFC3339, claimedUntil); err != nil {
+			return nil, fmt.Errorf("error parsing upload claim date: %w", err)
+		}
+	}
+
+	return upload, nil
+}
@@ -0,0 +1,181 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDynamoDBUploadRepository_SaveUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBUploadRepository{
+		dynamoClient: mockDynamo,
+		uploadsTable: "UploadsTable",
+	}
+
+	createdAt := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	upload := model.NewUpload("u1", "acc123", "statement.csv", createdAt, 15*time.Minute)
+	upload.Email = "user@example.com"
+	upload.ContentType = "text/csv"
+
+	mockDynamo.
+		EXPECT().
+		PutItem(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, "UploadsTable", *input.TableName)
+			assert.Equal(t, "u1", input.Item["UploadID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "acc123", input.Item["AccountID"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "uploads/acc123/u1/statement.csv", input.Item["ObjectKey"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "pending", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "2025-01-15T10:15:00Z", input.Item["ExpiresAt"].(*types.AttributeValueMemberS).Value)
+			assert.Equal(t, "text/csv", input.Item["ContentType"].(*types.AttributeValueMemberS).Value)
+			assert.NotContains(t, input.Item, "CustomerID")
+			assert.NotContains(t, input.Item, "Error")
+			return &dynamodb.PutItemOutput{}, nil
+		})
+
+	err := repo.SaveUpload(context.Background(), upload)
+
+	assert.NoError(t, err)
+}
+
+func TestDynamoDBUploadRepository_ClaimUpload(t *testing.T) {
+	createdAt := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	claimedAt := createdAt.Add(time.Minute)
+
+	for _, tt := range []struct {
+		name        string
+		putErr      error
+		expectedErr error
+	}{
+		{name: "claimed"},
+		{name: "already claimed", putErr: &types.ConditionalCheckFailedException{}, expectedErr: model.ErrUploadClaimed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+			repo := &DynamoDBUploadRepository{
+				dynamoClient: mockDynamo,
+				uploadsTable: "UploadsTable",
+			}
+
+			upload := model.NewUpload("u1", "acc123", "statement.csv", createdAt, 15*time.Minute)
+			upload.Claim(claimedAt, 15*time.Minute)
+
+			mockDynamo.
+				EXPECT().
+				PutItem(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+					assert.Equal(t, "processing", input.Item["Status"].(*types.AttributeValueMemberS).Value)
+					assert.Equal(t, "2025-01-15T10:16:00Z", input.Item["ClaimedUntil"].(*types.AttributeValueMemberS).Value)
+					assert.Equal(t, "#status = :pending OR (#status = :processing AND ClaimedUntil <= :at)", *input.ConditionExpression)
+					assert.Equal(t, "2025-01-15T10:01:00Z", input.ExpressionAttributeValues[":at"].(*types.AttributeValueMemberS).Value)
+					return &dynamodb.PutItemOutput{}, tt.putErr
+				})
+
+			err := repo.ClaimUpload(context.Background(), upload, claimedAt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDynamoDBUploadRepository_GetUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBUploadRepository{
+		dynamoClient: mockDynamo,
+		uploadsTable: "UploadsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"UploadID":   &types.AttributeValueMemberS{Value: "u1"},
+				"AccountID":  &types.AttributeValueMemberS{Value: "acc123"},
+				"CustomerID": &types.AttributeValueMemberS{Value: "cust1"},
+				"Email":      &types.AttributeValueMemberS{Value: "user@example.com"},
+				"FileName":   &types.AttributeValueMemberS{Value: "statement.csv"},
+				"ObjectKey":  &types.AttributeValueMemberS{Value: "uploads/acc123/u1/statement.csv"},
+				"Status":     &types.AttributeValueMemberS{Value: "failed"},
+				"Error":      &types.AttributeValueMemberS{Value: "invalid amount"},
+				"CreatedAt":  &types.AttributeValueMemberS{Value: "2025-01-15T10:00:00Z"},
+				"ExpiresAt":  &types.AttributeValueMemberS{Value: "2025-01-15T10:15:00Z"},
+			},
+		}, nil)
+
+	upload, err := repo.GetUpload(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acc123", upload.AccountID)
+	assert.Equal(t, "cust1", upload.CustomerID)
+	assert.Equal(t, "uploads/acc123/u1/statement.csv", upload.Key)
+	assert.Equal(t, model.UploadStatusFailed, upload.Status)
+	assert.Equal(t, "invalid amount", upload.Error)
+	assert.Equal(t, time.Date(2025, time.January, 15, 10, 15, 0, 0, time.UTC), upload.ExpiresAt)
+}
+
+func TestDynamoDBUploadRepository_GetUpload_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBUploadRepository{
+		dynamoClient: mockDynamo,
+		uploadsTable: "UploadsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(&dynamodb.GetItemOutput{}, nil)
+
+	upload, err := repo.GetUpload(context.Background(), "missing")
+
+	assert.NoError(t, err)
+	assert.Nil(t, upload)
+}
+
+func TestDynamoDBUploadRepository_GetUpload_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDynamo := mocks.NewMockDynamoDBClient(ctrl)
+
+	repo := &DynamoDBUploadRepository{
+		dynamoClient: mockDynamo,
+		uploadsTable: "UploadsTable",
+	}
+
+	mockDynamo.EXPECT().
+		GetItem(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("DynamoDB error"))
+
+	upload, err := repo.GetUpload(context.Background(), "u1")
+
+	assert.Error(t, err)
+	assert.Nil(t, upload)
+}
@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"transaction-processor/internal/domain/model"
+)
+
+// InMemoryBudgetRepository implements the BudgetRepository port in memory, for local runs and tests
+type InMemoryBudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[string]map[string]*model.Budget // account ID -> normalized category -> budget
+}
+
+// NewInMemoryBudgetRepository creates a new empty InMemoryBudgetRepository
+func NewInMemoryBudgetRepository() *InMemoryBudgetRepository {
+	return &InMemoryBudgetRepository{
+		budgets: make(map[string]map[string]*model.Budget),
+	}
+}
+
+// SaveBudget creates or replaces the budget of a category
+func (r *InMemoryBudgetRepository) SaveBudget(ctx context.Context, budget *model.Budget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.budgets[budget.AccountID] == nil {
+		r.budgets[budget.AccountID] = make(map[string]*model.Budget)
+	}
+
+	stored := *budget
+	r.budgets[budget.AccountID][budget.Key()] = &stored
+
+	return nil
+}
+
+// GetBudgets retrieves all budgets for an account sorted by category
+func (r *InMemoryBudgetRepository) GetBudgets(ctx context.Context, accountID string) ([]*model.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var budgets []*model.Budget
+	for _, budget := range r.budgets[accountID] {
+		stored := *budget
+		budgets = append(budgets, &stored)
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Category < budgets[j].Category
+	})
+
+	return budgets, nil
+}
@@ -0,0 +1,221 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"transaction-processor/internal/domain/model"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// transactionSchemaJSON is the JSON Schema every transaction of a JSON or NDJSON file must match
+//
+//go:embed schemas/transaction.schema.json
+var transactionSchemaJSON []byte
+
+// transactionSchema is the compiled transaction JSON Schema
+var transactionSchema = compileTransactionSchema()
+
+func compileTransactionSchema() *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	if err := compiler.AddResource("transaction.schema.json", bytes.NewReader(transactionSchemaJSON)); err != nil {
+		panic(err)
+	}
+	return compiler.MustCompile("transaction.schema.json")
+}
+
+// jsonTransaction is a transaction as described by schemas/transaction.schema.json
+type jsonTransaction struct {
+	ID          string  `json:"id"`
+	Date        string  `json:"date"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+}
+
+// JSONFileReader implements the FileReader port for JSON files holding an array of transactions
+// and for newline-delimited JSON (NDJSON) files holding a transaction per line. The format is
+// selected by the file extension and, for other extensions, by sniffing the first character.
+// Transactions are decoded as a stream and validated against the transaction JSON Schema.
+type JSONFileReader struct{}
+
+// NewJSONFileReader creates a new JSONFileReader
+func NewJSONFileReader() *JSONFileReader {
+	return &JSONFileReader{}
+}
+
+// ReadTransactions reads transactions from a JSON or NDJSON file
+func (r *JSONFileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	ndjson, err := isNDJSON(filePath, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if ndjson {
+		return readNDJSONTransactions(ctx, reader)
+	}
+	return readJSONArrayTransactions(ctx, reader)
+}
+
+// isNDJSON reports whether the file holds newline-delimited JSON. Files without a .json, .ndjson
+// or .jsonl extension are NDJSON unless their content starts with an array.
+func isNDJSON(filePath string, reader *bufio.Reader) (bool, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".ndjson", ".jsonl":
+		return true, nil
+	case ".json":
+		return false, nil
+	}
+
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return false, fmt.Errorf("error reading JSON file: %w", err)
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			reader.ReadByte()
+		case '[':
+			return false, nil
+		default:
+			return true, nil
+		}
+	}
+}
+
+// readJSONArrayTransactions decodes the elements of a JSON array one at a time
+func readJSONArrayTransactions(ctx context.Context, reader io.Reader) ([]*model.Transaction, error) {
+	decoder := json.NewDecoder(reader)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON array: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("invalid JSON format, expected an array of transactions")
+	}
+
+	var transactions []*model.Transaction
+	for index := 1; decoder.More(); index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped reading JSON record %d: %w", index, err)
+		}
+
+		var record json.RawMessage
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("error reading JSON record %d: %w", index, err)
+		}
+
+		tx, err := newJSONTransaction(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON record %d: %w", index, err)
+		}
+		transactions = append(transactions, tx)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("error reading JSON array: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// readNDJSONTransactions decodes a transaction per line, skipping blank lines
+func readNDJSONTransactions(ctx context.Context, reader io.Reader) ([]*model.Transaction, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var transactions []*model.Transaction
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped reading NDJSON file on line %d: %w", line, err)
+		}
+
+		record := bytes.TrimSpace(scanner.Bytes())
+		if len(record) == 0 {
+			continue
+		}
+
+		tx, err := newJSONTransaction(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NDJSON record on line %d: %w", line, err)
+		}
+		transactions = append(transactions, tx)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading NDJSON file: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// newJSONTransaction validates a record against the transaction schema and maps it into a transaction
+func newJSONTransaction(record []byte) (*model.Transaction, error) {
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if err := transactionSchema.Validate(value); err != nil {
+		return nil, err
+	}
+
+	var raw jsonTransaction
+	if err := json.Unmarshal(record, &raw); err != nil {
+		return nil, err
+	}
+
+	date, err := parseJSONDate(raw.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := model.ParseTransactionStatus(raw.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Transaction{
+		ID:          raw.ID,
+		Date:        date,
+		Amount:      math.Abs(raw.Amount),
+		IsCredit:    raw.Amount >= 0,
+		Category:    raw.Category,
+		Description: raw.Description,
+		Status:      status,
+	}, nil
+}
+
+// parseJSONDate parses a YYYY-MM-DD date or an RFC 3339 date-time into the posting day
+func parseJSONDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err == nil {
+		return date, nil
+	}
+
+	date, err = time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONFileReader_ReadTransactions(t *testing.T) {
+	tempDir := t.TempDir()
+	reader := NewJSONFileReader()
+
+	writeFile := func(t *testing.T, name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test JSON file: %v", err)
+		}
+		return filePath
+	}
+
+	jsonContent := `[
+  {"id": "0", "date": "2025-07-15", "amount": 60.5, "category": "Salary"},
+  {"id": "1", "date": "2025-07-28T18:30:00-03:00", "amount": -10.3, "description": "Coffee", "status": "pending"}
+]`
+
+	ndjsonContent := `{"id": "0", "date": "2025-07-15", "amount": 60.5, "category": "Salary"}
+
+{"id": "1", "date": "2025-07-28T18:30:00-03:00", "amount": -10.3, "description": "Coffee", "status": "pending"}
+`
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{name: "JSON array", fileName: "transactions.json", content: jsonContent},
+		{name: "NDJSON", fileName: "transactions.ndjson", content: ndjsonContent},
+		{name: "JSON array sniffed", fileName: "transactions.txt", content: jsonContent},
+		{name: "NDJSON sniffed", fileName: "transactions.log", content: ndjsonContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions, err := reader.ReadTransactions(context.Background(), writeFile(t, tt.fileName, tt.content))
+			if err != nil {
+				t.Fatalf("ReadTransactions failed: %v", err)
+			}
+			if len(transactions) != 2 {
+				t.Fatalf("Expected 2 transactions, got %d", len(transactions))
+			}
+
+			assert.Equal(t, "0", transactions[0].ID)
+			assert.Equal(t, time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), transactions[0].Date)
+			assert.Equal(t, 60.5, transactions[0].Amount)
+			assert.True(t, transactions[0].IsCredit)
+			assert.Equal(t, "Salary", transactions[0].GetCategory())
+			assert.True(t, transactions[0].IsPosted())
+
+			assert.Equal(t, time.Date(2025, time.July, 28, 0, 0, 0, 0, time.UTC), transactions[1].Date)
+			assert.Equal(t, 10.3, transactions[1].Amount)
+			assert.False(t, transactions[1].IsCredit)
+			assert.Equal(t, "Coffee", transactions[1].Description)
+			assert.True(t, transactions[1].IsPending())
+		})
+	}
+
+	invalidTests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{name: "missing amount", fileName: "missing.json", content: `[{"id": "0", "date": "2025-07-15"}]`},
+		{name: "invalid date", fileName: "date.ndjson", content: `{"id": "0", "date": "07/15", "amount": 1}`},
+		{name: "unknown status", fileName: "status.ndjson", content: `{"id": "0", "date": "2025-07-15", "amount": 1, "status": "settled"}`},
+		{name: "unknown field", fileName: "field.json", content: `[{"id": "0", "date": "2025-07-15", "amount": 1, "amout": 2}]`},
+		{name: "not an array", fileName: "object.json", content: `{"id": "0", "date": "2025-07-15", "amount": 1}`},
+		{name: "malformed line", fileName: "malformed.ndjson", content: `{"id": "0", "date": "2025-07-15", "amount": 1`},
+	}
+
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := reader.ReadTransactions(context.Background(), writeFile(t, tt.fileName, tt.content))
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("non-existent file", func(t *testing.T) {
+		_, err := reader.ReadTransactions(context.Background(), filepath.Join(tempDir, "non_existent.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrJobQueueFull is returned when the in-memory queue cannot take more jobs
+var ErrJobQueueFull = errors.New("job queue is full")
+
+// MemoryJobQueue implements the JobQueue and JobDeadLetterQueue ports with a buffered channel consumed
+// by a goroutine of the same process. It is meant for local development and tests, where no SQS queue
+// is available.
+type MemoryJobQueue struct {
+	jobs        chan string
+	once        sync.Once
+	stopped     chan struct{}
+	mu          sync.Mutex
+	deadLetters []JobMessage
+}
+
+// NewMemoryJobQueue creates a new MemoryJobQueue holding up to size queued jobs
+func NewMemoryJobQueue(size int) *MemoryJobQueue {
+	return &MemoryJobQueue{
+		jobs:    make(chan string, size),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Enqueue queues a job without blocking
+func (q *MemoryJobQueue) Enqueue(ctx context.Context, jobID string) error {
+	select {
+	case q.jobs <- jobID:
+		return nil
+	default:
+		return ErrJobQueueFull
+	}
+}
+
+// DeadLetter keeps a job aside with the reason of its last failure
+func (q *MemoryJobQueue) DeadLetter(ctx context.Context, jobID, reason string, attempts int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	log.Printf("Dead-lettered job %s after %d attempts: %s", jobID, attempts, reason)
+	q.deadLetters = append(q.deadLetters, JobMessage{JobID: jobID, Reason: reason, Attempts: attempts})
+	return nil
+}
+
+// DeadLetters returns the dead-lettered jobs
+func (q *MemoryJobQueue) DeadLetters() []JobMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]JobMessage(nil), q.deadLetters...)
+}
+
+// Start runs the queued jobs one at a time with the given worker until Close is called. Like an SQS
+// message, a job whose attempt fails is delivered again with the next attempt number, up to
+// maxReceives times. Jobs run with a background context, as a local process has no deadline. Only
+// the first call starts a consumer.
+func (q *MemoryJobQueue) Start(run func(ctx context.Context, jobID string, attempt int) error, maxReceives int) {
+	q.once.Do(func() {
+		go func() {
+			defer close(q.stopped)
+			for jobID := range q.jobs {
+				for attempt := 1; attempt <= maxReceives; attempt++ {
+					err := run(context.Background(), jobID, attempt)
+					if err == nil {
+						break
+					}
+					log.Printf("Error running attempt %d of job %s: %v", attempt, jobID, err)
+				}
+			}
+		}()
+	})
+}
+
+// Close stops accepting jobs and waits for the consumer, if started, to run the queued ones
+func (q *MemoryJobQueue) Close() {
+	close(q.jobs)
+	started := true
+	q.once.Do(func() { started = false })
+	if started {
+		<-q.stopped
+	}
+}
@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryJobQueue(t *testing.T) {
+	queue := NewMemoryJobQueue(2)
+
+	assert.NoError(t, queue.Enqueue(context.Background(), "job1"))
+	assert.NoError(t, queue.Enqueue(context.Background(), "job2"))
+
+	// Enqueue does not block when the queue is full
+	err := queue.Enqueue(context.Background(), "job3")
+	assert.True(t, errors.Is(err, ErrJobQueueFull), "expected ErrJobQueueFull, got %v", err)
+
+	// Failed attempts are delivered again until they succeed or maxReceives is reached
+	var run []string
+	queue.Start(func(ctx context.Context, jobID string, attempt int) error {
+		run = append(run, fmt.Sprintf("%s#%d", jobID, attempt))
+		if jobID == "job1" && attempt < 2 {
+			return errors.New("retried")
+		}
+		if jobID == "job2" {
+			return errors.New("failed jobs do not stop the consumer")
+		}
+		return nil
+	}, 3)
+	queue.Close()
+
+	assert.Equal(t, []string{"job1#1", "job1#2", "job2#1", "job2#2", "job2#3"}, run)
+}
+
+func TestMemoryJobQueue_DeadLetter(t *testing.T) {
+	queue := NewMemoryJobQueue(1)
+
+	assert.NoError(t, queue.DeadLetter(context.Background(), "job1", "gave up after 3 attempts: invalid amount", 3))
+
+	assert.Equal(t, []JobMessage{{JobID: "job1", Reason: "gave up after 3 attempts: invalid amount", Attempts: 3}}, queue.DeadLetters())
+}
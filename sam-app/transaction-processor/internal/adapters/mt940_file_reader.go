@@ -0,0 +1,311 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// MT940FileReader implements the FileReader and StatementReader ports for SWIFT MT940 customer
+// statements. A file may hold several statements, with or without the SWIFT message blocks.
+type MT940FileReader struct{}
+
+// NewMT940FileReader creates a new MT940FileReader
+func NewMT940FileReader() *MT940FileReader {
+	return &MT940FileReader{}
+}
+
+var (
+	// mt940TagPattern matches the first line of a field, e.g. ":61:2501030103CR2500,00NTRFNONREF"
+	mt940TagPattern = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+	// mt940StatementLinePattern matches the :61: statement line: value date, optional entry date,
+	// debit/credit mark, optional funds code, amount, transaction type and references
+	mt940StatementLinePattern = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^\n]*?)(?://([^\n]*))?(?:\n(.*))?$`)
+
+	// mt940BalancePattern matches the :60F:, :60M:, :62F: and :62M: balances
+	mt940BalancePattern = regexp.MustCompile(`^(C|D)(\d{6})([A-Z]{3})(\d+,\d*)$`)
+
+	// mt940SubfieldPattern matches the ?NN subfields of a structured :86: narrative
+	mt940SubfieldPattern = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// mt940Field is a tag and its value, including continuation lines
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ReadTransactions reads transactions from an MT940 file
+func (r *MT940FileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := r.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads an MT940 file and validates the opening and closing balances of each
+// statement against its transactions, unless balances are lenient in ctx. Several statements are
+// merged into one.
+func (r *MT940FileReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	// Split the file into the fields of each statement
+	var statementFields [][]mt940Field
+	var fields []mt940Field
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped reading MT940 file: %w", err)
+		}
+
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		// Skip the SWIFT header and trailer blocks around the message text
+		if i := strings.Index(line, "{4:"); i >= 0 {
+			line = line[i+3:]
+		}
+		if line == "" || strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		// A line with a dash ends the message text of a statement
+		if line == "-" || strings.HasPrefix(line, "-}") {
+			if len(fields) > 0 {
+				statementFields = append(statementFields, fields)
+				fields = nil
+			}
+			continue
+		}
+
+		match := mt940TagPattern.FindStringSubmatch(line)
+		if match == nil {
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("invalid MT940 format, unexpected line: %s", line)
+			}
+			fields[len(fields)-1].value += "\n" + line
+			continue
+		}
+
+		// A new :20: field starts the next statement when the previous one was not terminated
+		if match[1] == "20" && len(fields) > 0 {
+			statementFields = append(statementFields, fields)
+			fields = nil
+		}
+		fields = append(fields, mt940Field{tag: match[1], value: match[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading MT940 file: %w", err)
+	}
+	if len(fields) > 0 {
+		statementFields = append(statementFields, fields)
+	}
+
+	if len(statementFields) == 0 {
+		return nil, fmt.Errorf("invalid MT940 format, no statement found")
+	}
+
+	statements := make([]*model.Statement, 0, len(statementFields))
+	for _, fields := range statementFields {
+		statement, reference, err := newMT940Statement(fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := statement.ValidateBalances(); err != nil {
+			if !ports.LenientBalances(ctx) {
+				return nil, fmt.Errorf("statement %s: %w", reference, err)
+			}
+			statement.BalanceMismatches = append(statement.BalanceMismatches, fmt.Sprintf("statement %s: %v", reference, err))
+		}
+		statements = append(statements, statement)
+	}
+
+	return model.MergeStatements(statements)
+}
+
+// newMT940Statement maps the fields of a statement. It also returns the statement reference, made
+// of the :20: transaction reference and the :28C: statement number.
+func newMT940Statement(fields []mt940Field) (*model.Statement, string, error) {
+	statement := &model.Statement{}
+	reference := ""
+
+	var last *model.Transaction
+	for _, field := range fields {
+		switch field.tag {
+		case "20":
+			reference = strings.TrimSpace(field.value)
+		case "28C":
+			reference += "/" + strings.TrimSpace(field.value)
+		case "25":
+			statement.AccountID = strings.TrimSpace(field.value)
+		case "60F", "60M":
+			balance, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, "", fmt.Errorf("statement %s: error reading opening balance: %w", reference, err)
+			}
+			statement.OpeningBalance = balance
+			statement.Currency = currency
+		case "62F", "62M":
+			balance, _, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, "", fmt.Errorf("statement %s: error reading closing balance: %w", reference, err)
+			}
+			statement.ClosingBalance = balance
+			last = nil
+		case "61":
+			tx, err := parseMT940StatementLine(field.value, fmt.Sprintf("%s-%d", reference, len(statement.Transactions)+1))
+			if err != nil {
+				return nil, "", fmt.Errorf("statement %s: %w", reference, err)
+			}
+			statement.Transactions = append(statement.Transactions, tx)
+			last = tx
+		case "86":
+			// The narrative belongs to the preceding statement line and replaces its supplementary details
+			if last != nil {
+				if narrative := parseMT940Narrative(field.value); narrative != "" {
+					last.Description = narrative
+				}
+				last = nil
+			}
+		}
+	}
+
+	return statement, reference, nil
+}
+
+// parseMT940StatementLine maps a :61: statement line into a transaction. The ID is the bank
+// reference, falling back to the customer reference and then to the position of the line.
+func parseMT940StatementLine(value, fallbackID string) (*model.Transaction, error) {
+	match := mt940StatementLinePattern.FindStringSubmatch(value)
+	if match == nil {
+		return nil, fmt.Errorf("invalid MT940 statement line: %s", value)
+	}
+
+	valueDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid MT940 value date: %s", match[1])
+	}
+
+	// The entry date has no year, which is taken from the value date adjusting for year boundaries
+	date := valueDate
+	if match[2] != "" {
+		month, _ := strconv.Atoi(match[2][:2])
+		day, _ := strconv.Atoi(match[2][2:])
+		date = time.Date(valueDate.Year(), time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if valueDate.Month() == time.December && date.Month() == time.January {
+			date = date.AddDate(1, 0, 0)
+		} else if valueDate.Month() == time.January && date.Month() == time.December {
+			date = date.AddDate(-1, 0, 0)
+		}
+	}
+
+	amount, err := parseMT940Amount(match[5])
+	if err != nil {
+		return nil, err
+	}
+
+	// Reversals of credits are debits and reversals of debits are credits
+	isCredit := match[3] == "C" || match[3] == "RD"
+
+	customerRef := strings.TrimSpace(match[7])
+	bankRef := strings.TrimSpace(match[8])
+	if customerRef == "NONREF" {
+		customerRef = ""
+	}
+
+	return &model.Transaction{
+		ID:          firstNonEmpty(bankRef, customerRef, fallbackID),
+		Date:        date,
+		ValueDate:   valueDate,
+		Amount:      amount,
+		IsCredit:    isCredit,
+		Description: strings.TrimSpace(match[9]),
+		Type:        match[6],
+		Reference:   firstNonEmpty(bankRef, customerRef),
+	}, nil
+}
+
+// parseMT940Balance parses a balance field, which is negative when its mark is D
+func parseMT940Balance(value string) (*model.DeclaredBalance, string, error) {
+	match := mt940BalancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return nil, "", fmt.Errorf("invalid MT940 balance: %s", value)
+	}
+
+	date, err := time.Parse("060102", match[2])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid MT940 balance date: %s", match[2])
+	}
+
+	amount, err := parseMT940Amount(match[4])
+	if err != nil {
+		return nil, "", err
+	}
+	if match[1] == "D" {
+		amount = -amount
+	}
+
+	return &model.DeclaredBalance{Amount: amount, Date: date}, match[3], nil
+}
+
+// parseMT940Amount parses an amount using a comma as decimal separator
+func parseMT940Amount(value string) (float64, error) {
+	amount, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid MT940 amount: %s", value)
+	}
+	return amount, nil
+}
+
+// parseMT940Narrative returns the description of a :86: field. Structured narratives, which start
+// with a transaction code followed by ?NN subfields, are reduced to the counterparty name and the
+// remittance information; unstructured narratives are joined into a single line.
+func parseMT940Narrative(value string) string {
+	if len(value) < 4 || value[3] != '?' {
+		return strings.Join(strings.Fields(value), " ")
+	}
+
+	// Lines of a structured narrative are wrapped at a fixed width and are not separated by spaces
+	value = strings.ReplaceAll(value, "\n", "")
+
+	locations := mt940SubfieldPattern.FindAllStringSubmatchIndex(value, -1)
+	var name, remittance []string
+	for i, location := range locations {
+		end := len(value)
+		if i+1 < len(locations) {
+			end = locations[i+1][0]
+		}
+		code, _ := strconv.Atoi(value[location[2]:location[3]])
+		content := value[location[1]:end]
+
+		switch {
+		case code >= 20 && code <= 29, code >= 60 && code <= 63:
+			if content = strings.TrimSpace(content); content != "" {
+				remittance = append(remittance, content)
+			}
+		case code == 32 || code == 33:
+			// The name is split across both subfields without separators
+			name = append(name, content)
+		}
+	}
+
+	counterparty := strings.TrimSpace(strings.Join(name, ""))
+	description := strings.Join(remittance, " ")
+	if counterparty != "" && description != "" {
+		return counterparty + " - " + description
+	}
+	return counterparty + description
+}
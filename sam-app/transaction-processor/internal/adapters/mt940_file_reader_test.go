@@ -0,0 +1,166 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMT940FileReader_ReadStatement(t *testing.T) {
+	mt940Content := `{1:F01BANKDEFFAXXX0000000000}{2:O9400000250131BANKDEFFAXXX00000000002501310000N}{4:
+:20:STARTUMSE
+:25:10020030/1234567
+:28C:00001/001
+:60F:C250101EUR1000,00
+:61:2501030103CR2500,00NTRFNONREF//BANKREF1
+:86:166?00GUTSCHRIFT?20EREF+123?21Salary Jan
+uary 2025?32ACME
+?33 GMBH
+:61:2501100110D120,50NMSCCARD77
+:86:Card payment
+ at the supermarket
+:61:250115RD20,00NCHKNONREF
+:62F:C250131EUR3399,50
+-}
+:20:STARTUMSE
+:25:10020030/1234567
+:28C:00002/001
+:60F:C250131EUR3399,50
+:61:250201D400,00NDDTNONREF
+Rent February
+:62F:C250201EUR2999,50
+-`
+
+	tempDir := t.TempDir()
+	reader := NewMT940FileReader()
+
+	writeStatement := func(t *testing.T, name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test MT940 file: %v", err)
+		}
+		return filePath
+	}
+
+	t.Run("multiple statements", func(t *testing.T) {
+		statement, err := reader.ReadStatement(context.Background(), writeStatement(t, "statement.sta", mt940Content))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		assert.Equal(t, "10020030/1234567", statement.AccountID)
+		assert.Equal(t, "EUR", statement.Currency)
+		assert.Equal(t, 1000.0, statement.OpeningBalance.Amount)
+		assert.Equal(t, 2999.5, statement.ClosingBalance.Amount)
+		assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), statement.ClosingBalance.Date)
+		if len(statement.Transactions) != 4 {
+			t.Fatalf("Expected 4 transactions, got %d", len(statement.Transactions))
+		}
+
+		salary := statement.Transactions[0]
+		assert.Equal(t, "BANKREF1", salary.ID)
+		assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), salary.Date)
+		assert.Equal(t, 2500.0, salary.Amount)
+		assert.True(t, salary.IsCredit)
+		assert.Equal(t, "NTRF", salary.Type)
+		assert.Equal(t, "ACME GMBH - EREF+123 Salary January 2025", salary.Description)
+
+		card := statement.Transactions[1]
+		assert.Equal(t, "CARD77", card.ID)
+		assert.False(t, card.IsCredit)
+		assert.Equal(t, "Card payment at the supermarket", card.Description)
+
+		// A reversed debit is a credit
+		reversal := statement.Transactions[2]
+		assert.Equal(t, "STARTUMSE/00001/001-3", reversal.ID)
+		assert.True(t, reversal.IsCredit)
+
+		// Supplementary details are used when there is no narrative
+		rent := statement.Transactions[3]
+		assert.Equal(t, "STARTUMSE/00002/001-1", rent.ID)
+		assert.Equal(t, "Rent February", rent.Description)
+	})
+
+	t.Run("entry date across year boundary", func(t *testing.T) {
+		tx, err := parseMT940StatementLine("2412310102D10,00NMSCNONREF", "fallback")
+		if err != nil {
+			t.Fatalf("parseMT940StatementLine failed: %v", err)
+		}
+		assert.Equal(t, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), tx.ValueDate)
+		assert.Equal(t, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), tx.Date)
+		assert.Equal(t, "fallback", tx.ID)
+	})
+
+	t.Run("closing balance mismatch", func(t *testing.T) {
+		content := `:20:REF
+:25:ACC
+:60F:C250101EUR100,00
+:61:250102D10,00NMSCNONREF
+:62F:C250102EUR100,00`
+
+		filePath := writeStatement(t, "mismatch.sta", content)
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.True(t, errors.Is(err, model.ErrBalanceMismatch), "expected ErrBalanceMismatch, got %v", err)
+
+		// With lenient balances the statement is read and the mismatch recorded
+		statement, err := reader.ReadStatement(ports.WithLenientBalances(context.Background()), filePath)
+		assert.NoError(t, err)
+		assert.Len(t, statement.Transactions, 1)
+		if assert.Len(t, statement.BalanceMismatches, 1) {
+			assert.Contains(t, statement.BalanceMismatches[0], "statement REF")
+		}
+	})
+
+	t.Run("statements of different accounts", func(t *testing.T) {
+		content := `:20:REF
+:25:ACC1
+:60F:C250101EUR100,00
+:62F:C250101EUR100,00
+-
+:20:REF
+:25:ACC2
+:60F:C250102EUR100,00
+:62F:C250102EUR100,00
+-`
+
+		_, err := reader.ReadStatement(context.Background(), writeStatement(t, "accounts.sta", content))
+		assert.True(t, errors.Is(err, model.ErrStatementMismatch), "expected ErrStatementMismatch, got %v", err)
+	})
+
+	t.Run("statements in different currencies", func(t *testing.T) {
+		content := `:20:REF
+:25:ACC
+:60F:C250101EUR100,00
+:62F:C250101EUR100,00
+-
+:20:REF
+:25:ACC
+:60F:C250102USD100,00
+:62F:C250102USD100,00
+-`
+
+		_, err := reader.ReadStatement(context.Background(), writeStatement(t, "currencies.sta", content))
+		assert.True(t, errors.Is(err, model.ErrStatementMismatch), "expected ErrStatementMismatch, got %v", err)
+	})
+
+	t.Run("invalid statement line", func(t *testing.T) {
+		content := `:20:REF
+:61:not a statement line`
+
+		_, err := reader.ReadStatement(context.Background(), writeStatement(t, "invalid.sta", content))
+		assert.Error(t, err)
+	})
+
+	t.Run("non-existent file", func(t *testing.T) {
+		_, err := reader.ReadTransactions(context.Background(), filepath.Join(tempDir, "non_existent.sta"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// NoopTransactionRepository implements the TransactionRepository port without storing anything.
+// Writes are dropped and reads find nothing, so dry runs process a file as if the account were new.
+type NoopTransactionRepository struct{}
+
+// NewNoopTransactionRepository creates a new NoopTransactionRepository
+func NewNoopTransactionRepository() *NoopTransactionRepository {
+	return &NoopTransactionRepository{}
+}
+
+// SaveTransaction drops the transaction
+func (r *NoopTransactionRepository) SaveTransaction(ctx context.Context, tx *model.Transaction) error {
+	return nil
+}
+
+// GetTransactionsByID finds no transaction
+func (r *NoopTransactionRepository) GetTransactionsByID(ctx context.Context, accountID string, ids []string) (map[string]*model.Transaction, error) {
+	return nil, nil
+}
+
+// SaveAccount drops the account summary
+func (r *NoopTransactionRepository) SaveAccount(ctx context.Context, owner model.AccountOwner, summary ports.EmailSummary) error {
+	return nil
+}
+
+// GetAccountOwner finds no account
+func (r *NoopTransactionRepository) GetAccountOwner(ctx context.Context, accountID string) (*model.AccountOwner, error) {
+	return nil, nil
+}
+
+// ListAccounts finds no account
+func (r *NoopTransactionRepository) ListAccounts(ctx context.Context, startAfter string, limit int) ([]model.AccountOwner, string, error) {
+	return nil, "", nil
+}
+
+// GetTransactions finds no transaction
+func (r *NoopTransactionRepository) GetTransactions(ctx context.Context, accountID string) ([]*model.Transaction, error) {
+	return nil, nil
+}
@@ -0,0 +1,286 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"transaction-processor/internal/domain/model"
+)
+
+// OFXFileReader implements the FileReader and StatementReader ports for OFX and QFX files.
+// Both OFX 1.x (SGML, where leaf elements have no closing tag) and OFX 2.x (XML) are supported.
+type OFXFileReader struct{}
+
+// NewOFXFileReader creates a new OFXFileReader
+func NewOFXFileReader() *OFXFileReader {
+	return &OFXFileReader{}
+}
+
+// ReadTransactions reads transactions from an OFX file
+func (r *OFXFileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := r.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads the transactions, account and ledger balance of an OFX file
+func (r *OFXFileReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+
+	return parseOFX(string(content))
+}
+
+// ofxAggregates are the OFX aggregates of bank and credit card statements. Other elements are leaves,
+// even when they are empty, since SGML leaves have no closing tag to tell them from aggregates.
+var ofxAggregates = map[string]bool{
+	"OFX": true, "SIGNONMSGSRSV1": true, "SONRS": true, "STATUS": true, "FI": true,
+	"BANKMSGSRSV1": true, "STMTTRNRS": true, "STMTRS": true, "BANKACCTFROM": true, "BANKACCTTO": true,
+	"CREDITCARDMSGSRSV1": true, "CCSTMTTRNRS": true, "CCSTMTRS": true, "CCACCTFROM": true, "CCACCTTO": true,
+	"BANKTRANLIST": true, "STMTTRN": true, "PAYEE": true, "CURRENCY": true, "ORIGCURRENCY": true,
+	"LEDGERBAL": true, "AVAILBAL": true, "BALLIST": true, "BAL": true,
+}
+
+// ofxParser builds a statement from the elements of an OFX document
+type ofxParser struct {
+	statement   *model.Statement
+	stack       []string
+	transaction map[string]string
+	ledger      map[string]string
+}
+
+// parseOFX parses the body of an OFX document, skipping the OFX 1.x header and XML declarations.
+// Known aggregates end at their closing tag; any other element is a leaf, whose closing tag is optional.
+func parseOFX(content string) (*model.Statement, error) {
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("invalid OFX format, missing <OFX> element")
+	}
+
+	p := &ofxParser{statement: &model.Statement{}}
+	body := content[start:]
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid OFX format, unterminated tag")
+		}
+		tag := strings.TrimSpace(body[open+1 : open+end])
+		body = body[open+end+1:]
+
+		// Skip processing instructions and comments
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		if strings.HasPrefix(tag, "/") {
+			if err := p.closeAggregate(strings.ToUpper(tag[1:])); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		name := strings.ToUpper(strings.TrimSuffix(tag, "/"))
+		if ofxAggregates[name] && !strings.HasSuffix(tag, "/") {
+			p.stack = append(p.stack, name)
+			p.openAggregate(name)
+			continue
+		}
+
+		next := strings.IndexByte(body, '<')
+		if next < 0 {
+			next = len(body)
+		}
+		text := strings.TrimSpace(body[:next])
+		body = body[next:]
+		p.leaf(name, html.UnescapeString(text))
+	}
+
+	if len(p.stack) > 0 {
+		return nil, fmt.Errorf("invalid OFX format, unclosed element <%s>", p.stack[len(p.stack)-1])
+	}
+
+	if p.ledger != nil {
+		balance, err := newOFXBalance(p.ledger)
+		if err != nil {
+			return nil, err
+		}
+		p.statement.ClosingBalance = balance
+	}
+
+	return p.statement, nil
+}
+
+// openAggregate starts collecting the fields of the aggregates mapped into the statement
+func (p *ofxParser) openAggregate(name string) {
+	switch name {
+	case "STMTTRN":
+		p.transaction = make(map[string]string)
+	case "LEDGERBAL":
+		p.ledger = make(map[string]string)
+	}
+}
+
+// closeAggregate pops the stack up to the aggregate being closed. Closing tags of leaf elements
+// are not in the stack and are ignored.
+func (p *ofxParser) closeAggregate(name string) error {
+	i := len(p.stack) - 1
+	for i >= 0 && p.stack[i] != name {
+		i--
+	}
+	if i < 0 {
+		return nil
+	}
+
+	for len(p.stack) > i {
+		closed := p.stack[len(p.stack)-1]
+		p.stack = p.stack[:len(p.stack)-1]
+		if closed == "STMTTRN" && p.transaction != nil {
+			tx, err := newOFXTransaction(p.transaction)
+			if err != nil {
+				return err
+			}
+			p.statement.Transactions = append(p.statement.Transactions, tx)
+			p.transaction = nil
+		}
+	}
+
+	return nil
+}
+
+// leaf stores the value of a leaf element in the aggregate it belongs to
+func (p *ofxParser) leaf(name, value string) {
+	parent := ""
+	if len(p.stack) > 0 {
+		parent = p.stack[len(p.stack)-1]
+	}
+
+	switch {
+	case parent == "STMTTRN" && p.transaction != nil:
+		p.transaction[name] = value
+	case parent == "LEDGERBAL" && p.ledger != nil:
+		p.ledger[name] = value
+	case name == "ACCTID" && (parent == "BANKACCTFROM" || parent == "CCACCTFROM") && p.statement.AccountID == "":
+		p.statement.AccountID = value
+	case name == "CURDEF" && p.statement.Currency == "":
+		p.statement.Currency = value
+	}
+}
+
+// newOFXTransaction maps the fields of a STMTTRN aggregate into a transaction
+func newOFXTransaction(fields map[string]string) (*model.Transaction, error) {
+	id := fields["FITID"]
+	if id == "" {
+		return nil, fmt.Errorf("invalid OFX transaction, missing FITID")
+	}
+
+	posted, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		return nil, fmt.Errorf("error creating transaction %s: %w", id, err)
+	}
+
+	amount, err := parseOFXAmount(fields["TRNAMT"])
+	if err != nil {
+		return nil, fmt.Errorf("error creating transaction %s: %w", id, err)
+	}
+
+	description := fields["NAME"]
+	if memo := fields["MEMO"]; memo != "" && memo != description {
+		if description != "" {
+			description += " - "
+		}
+		description += memo
+	}
+
+	return &model.Transaction{
+		ID:          id,
+		Date:        time.Date(posted.Year(), posted.Month(), posted.Day(), 0, 0, 0, 0, time.UTC),
+		Amount:      math.Abs(amount),
+		IsCredit:    amount >= 0,
+		Description: description,
+		Type:        fields["TRNTYPE"],
+	}, nil
+}
+
+// newOFXBalance maps the fields of a LEDGERBAL aggregate into a declared balance
+func newOFXBalance(fields map[string]string) (*model.DeclaredBalance, error) {
+	amount, err := parseOFXAmount(fields["BALAMT"])
+	if err != nil {
+		return nil, fmt.Errorf("error reading ledger balance: %w", err)
+	}
+
+	asOf, err := parseOFXDate(fields["DTASOF"])
+	if err != nil {
+		return nil, fmt.Errorf("error reading ledger balance: %w", err)
+	}
+
+	return &model.DeclaredBalance{Amount: amount, Date: asOf}, nil
+}
+
+// parseOFXAmount parses an OFX amount, which may use a comma as decimal separator. In amounts with a
+// decimal point, commas group the thousands and are dropped.
+func parseOFXAmount(value string) (float64, error) {
+	if value == "" {
+		return 0, fmt.Errorf("missing amount")
+	}
+	if strings.Contains(value, ".") {
+		value = strings.ReplaceAll(value, ",", "")
+	} else {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(strings.TrimPrefix(value, "+"), 64)
+}
+
+// parseOFXDate parses an OFX datetime in the YYYYMMDD[HHMMSS[.XXX]][offset[:TZ]] format.
+// Dates without a time zone are in UTC.
+func parseOFXDate(value string) (time.Time, error) {
+	location := time.UTC
+	if i := strings.IndexByte(value, '['); i >= 0 {
+		zone := strings.TrimSuffix(value[i+1:], "]")
+		value = value[:i]
+
+		offset, name, _ := strings.Cut(zone, ":")
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+		}
+		if name == "" {
+			name = "UTC" + offset
+		}
+		location = time.FixedZone(name, int(hours*3600))
+	}
+
+	value, fraction, _ := strings.Cut(strings.TrimSpace(value), ".")
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(value)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+	}
+
+	date, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+	}
+
+	if fraction != "" {
+		milliseconds, err := strconv.Atoi(fraction)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date format: %s", value)
+		}
+		date = date.Add(time.Duration(milliseconds) * time.Millisecond)
+	}
+
+	return date, nil
+}
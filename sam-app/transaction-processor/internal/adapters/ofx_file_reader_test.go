@@ -0,0 +1,252 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOFXFileReader_ReadStatement(t *testing.T) {
+	sgmlContent := `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20250131120000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>1
+<STATUS><CODE>0<SEVERITY>INFO</STATUS>
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>121000248<ACCTID>123456789<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20250101
+<DTEND>20250131
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250103120000.000[-5:EST]
+<TRNAMT>1500.00
+<FITID>2025010301
+<NAME>ACME PAYROLL
+<MEMO>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>POS
+<DTPOSTED>20250110
+<TRNAMT>-42,50
+<FITID>2025011001
+<NAME>Fish &amp; Chips
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>2457.50<DTASOF>20250131235959[0:GMT]</LEDGERBAL>
+<AVAILBAL><BALAMT>2400.00<DTASOF>20250131</AVAILBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>`
+
+	xmlContent := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>4111111111111111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20250205</DTPOSTED>
+            <TRNAMT>-19.99</TRNAMT>
+            <FITID>A1</FITID>
+            <NAME>Streaming</NAME>
+            <MEMO></MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL><BALAMT>-19.99</BALAMT><DTASOF>20250228</DTASOF></LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>`
+
+	tempDir := t.TempDir()
+	reader := NewOFXFileReader()
+
+	t.Run("OFX 1.x SGML", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "statement.ofx")
+		if err := os.WriteFile(filePath, []byte(sgmlContent), 0644); err != nil {
+			t.Fatalf("Failed to write test OFX file: %v", err)
+		}
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		assert.Equal(t, "123456789", statement.AccountID)
+		assert.Equal(t, "USD", statement.Currency)
+		if len(statement.Transactions) != 2 {
+			t.Fatalf("Expected 2 transactions, got %d", len(statement.Transactions))
+		}
+
+		salary := statement.Transactions[0]
+		assert.Equal(t, "2025010301", salary.ID)
+		assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), salary.Date)
+		assert.Equal(t, 1500.0, salary.Amount)
+		assert.True(t, salary.IsCredit)
+		assert.Equal(t, "ACME PAYROLL - Salary", salary.Description)
+		assert.Equal(t, "CREDIT", salary.Type)
+
+		purchase := statement.Transactions[1]
+		assert.Equal(t, 42.5, purchase.Amount)
+		assert.False(t, purchase.IsCredit)
+		assert.Equal(t, "Fish & Chips", purchase.Description)
+		assert.Equal(t, "POS", purchase.Type)
+
+		if statement.ClosingBalance == nil {
+			t.Fatal("Expected a ledger balance, got nil")
+		}
+		assert.Equal(t, 2457.5, statement.ClosingBalance.Amount)
+		assert.Equal(t, time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC), statement.ClosingBalance.Date.UTC())
+	})
+
+	t.Run("OFX 1.x SGML with empty leaves", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "empty-memo.ofx")
+		content := `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><BANKID><ACCTID>123456789</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<MEMO>
+<DTPOSTED>20250110
+<TRNAMT>-42.50
+<FITID>2025011001
+<NAME>GROCERY STORE
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test OFX file: %v", err)
+		}
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+
+		// The siblings of the empty MEMO and BANKID still belong to their aggregates
+		assert.Equal(t, "123456789", statement.AccountID)
+		if len(statement.Transactions) != 1 {
+			t.Fatalf("Expected 1 transaction, got %d", len(statement.Transactions))
+		}
+		assert.Equal(t, "2025011001", statement.Transactions[0].ID)
+		assert.Equal(t, 42.5, statement.Transactions[0].Amount)
+		assert.Equal(t, "GROCERY STORE", statement.Transactions[0].Description)
+	})
+
+	t.Run("OFX 2.x XML", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "statement.qfx")
+		if err := os.WriteFile(filePath, []byte(xmlContent), 0644); err != nil {
+			t.Fatalf("Failed to write test OFX file: %v", err)
+		}
+
+		transactions, err := reader.ReadTransactions(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadTransactions failed: %v", err)
+		}
+		if len(transactions) != 1 {
+			t.Fatalf("Expected 1 transaction, got %d", len(transactions))
+		}
+		assert.Equal(t, "A1", transactions[0].ID)
+		assert.Equal(t, 19.99, transactions[0].Amount)
+		assert.False(t, transactions[0].IsCredit)
+		assert.Equal(t, "Streaming", transactions[0].Description)
+
+		statement, err := reader.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Equal(t, "4111111111111111", statement.AccountID)
+		assert.Equal(t, "EUR", statement.Currency)
+		assert.Equal(t, -19.99, statement.ClosingBalance.Amount)
+	})
+
+	t.Run("date with time zone", func(t *testing.T) {
+		date, err := parseOFXDate("20250103120000.000[-5:EST]")
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Equal(t, time.Date(2025, time.January, 3, 17, 0, 0, 0, time.UTC), date.UTC())
+	})
+
+	t.Run("missing OFX element", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "invalid.ofx")
+		if err := os.WriteFile(filePath, []byte("Id,Date,Transaction\n0,7/15,+60.5"), 0644); err != nil {
+			t.Fatalf("Failed to write test OFX file: %v", err)
+		}
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("transaction without FITID", func(t *testing.T) {
+		filePath := filepath.Join(tempDir, "no_fitid.ofx")
+		content := "<OFX><BANKTRANLIST><STMTTRN><DTPOSTED>20250110<TRNAMT>-1.00</STMTTRN></BANKTRANLIST></OFX>"
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test OFX file: %v", err)
+		}
+
+		_, err := reader.ReadStatement(context.Background(), filePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("non-existent file", func(t *testing.T) {
+		_, err := reader.ReadStatement(context.Background(), filepath.Join(tempDir, "non_existent.ofx"))
+		assert.Error(t, err)
+	})
+}
+
+func TestParseOFXAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected float64
+	}{
+		{name: "decimal point", value: "-19.99", expected: -19.99},
+		{name: "decimal point with grouping commas", value: "1,234.56", expected: 1234.56},
+		{name: "negative with grouping commas", value: "-1,234,567.89", expected: -1234567.89},
+		{name: "decimal comma", value: "-42,50", expected: -42.5},
+		{name: "explicit sign", value: "+1500,00", expected: 1500},
+		{name: "whole amount", value: "250", expected: 250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := parseOFXAmount(tt.value)
+			if err != nil {
+				t.Fatalf("parseOFXAmount failed: %v", err)
+			}
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+
+	_, err := parseOFXAmount("")
+	assert.EqualError(t, err, "missing amount")
+}
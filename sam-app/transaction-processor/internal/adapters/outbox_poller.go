@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// OutboxPoller sweeps the outbox at a fixed interval from a goroutine of the same process. It is meant
+// for local development, where no scheduled event triggers the dispatcher.
+type OutboxPoller struct {
+	interval time.Duration
+	once     sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewOutboxPoller creates a new OutboxPoller sweeping every interval
+func NewOutboxPoller(interval time.Duration) *OutboxPoller {
+	return &OutboxPoller{
+		interval: interval,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// Start runs the given sweep every interval until Close is called. Sweeps run with a background
+// context, as a local process has no deadline, and failed sweeps are only logged, as the next one
+// retries them. Only the first call starts polling.
+func (p *OutboxPoller) Start(sweep func(ctx context.Context) error) {
+	p.once.Do(func() {
+		go func() {
+			defer close(p.stopped)
+			ticker := time.NewTicker(p.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-p.done:
+					return
+				case <-ticker.C:
+					if err := sweep(context.Background()); err != nil {
+						log.Printf("Error sweeping the outbox: %v", err)
+					}
+				}
+			}
+		}()
+	})
+}
+
+// Close stops polling and waits for the sweep in progress, if any, to finish
+func (p *OutboxPoller) Close() {
+	close(p.done)
+	started := true
+	p.once.Do(func() { started = false })
+	if started {
+		<-p.stopped
+	}
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxPoller(t *testing.T) {
+	poller := NewOutboxPoller(time.Millisecond)
+
+	// Failed sweeps do not stop polling
+	var sweeps atomic.Int32
+	swept := make(chan struct{})
+	poller.Start(func(ctx context.Context) error {
+		if sweeps.Add(1) == 3 {
+			close(swept)
+		}
+		return errors.New("mail server unavailable")
+	})
+
+	select {
+	case <-swept:
+	case <-time.After(time.Second):
+		t.Fatal("the outbox was not swept three times")
+	}
+	poller.Close()
+
+	// No sweep runs once the poller is closed
+	closed := sweeps.Load()
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, closed, sweeps.Load())
+}
+
+func TestOutboxPoller_CloseWithoutStart(t *testing.T) {
+	poller := NewOutboxPoller(time.Millisecond)
+
+	poller.Close()
+}
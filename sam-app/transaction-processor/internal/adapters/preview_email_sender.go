@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"transaction-processor/internal/ports"
+)
+
+// PreviewEmailSender implements the PreviewEmailSender port, rendering the emails with the SMTP email
+// templates and keeping them instead of sending them. It is used by dry runs.
+type PreviewEmailSender struct {
+	mu       sync.Mutex
+	previews []ports.EmailPreview
+}
+
+// NewPreviewEmailSender creates a new PreviewEmailSender
+func NewPreviewEmailSender() *PreviewEmailSender {
+	return &PreviewEmailSender{}
+}
+
+// SendSummaryEmail renders a summary email and keeps it with its summary
+func (s *PreviewEmailSender) SendSummaryEmail(ctx context.Context, recipient string, summary ports.EmailSummary) error {
+	html, err := renderEmail("summary", recipient, summary)
+	if err != nil {
+		return fmt.Errorf("error generating email body: %w", err)
+	}
+
+	s.keep(ports.EmailPreview{Recipient: recipient, Subject: summarySubject, HTML: html, Summary: &summary})
+	return nil
+}
+
+// SendConsolidatedSummaryEmail renders a consolidated summary email and keeps it
+func (s *PreviewEmailSender) SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ports.ConsolidatedSummary) error {
+	html, err := renderEmail("consolidated", recipient, summary)
+	if err != nil {
+		return fmt.Errorf("error generating email body: %w", err)
+	}
+
+	s.keep(ports.EmailPreview{Recipient: recipient, Subject: consolidatedSummarySubject, HTML: html})
+	return nil
+}
+
+// Previews returns the emails rendered so far
+func (s *PreviewEmailSender) Previews() []ports.EmailPreview {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ports.EmailPreview(nil), s.previews...)
+}
+
+// keep records a rendered email
+func (s *PreviewEmailSender) keep(preview ports.EmailPreview) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.previews = append(s.previews, preview)
+}
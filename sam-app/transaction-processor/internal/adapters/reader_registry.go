@@ -0,0 +1,356 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// sniffSize is the number of bytes read from the start of a file to detect its format
+const sniffSize = 4096
+
+// ErrUnsupportedFormat is returned when no registered reader matches a file
+var ErrUnsupportedFormat = errors.New("unsupported transaction file format")
+
+// ReaderFormat describes a transaction file format and the reader used for it
+type ReaderFormat struct {
+	// Name identifies the format in error messages
+	Name string
+	// Extensions are the lowercase file extensions of the format, including the dot
+	Extensions []string
+	// MIMETypes are the media types of the format
+	MIMETypes []string
+	// Sniff reports whether the start of a file is in this format, it may be nil
+	Sniff func(head []byte) bool
+	// Reader reads files of this format
+	Reader ports.FileReader
+}
+
+// ReaderRegistry implements the FileReader and StatementReader ports by delegating to the reader of
+// the file format. Formats are matched by MIME type first and then by extension. When several formats
+// share a MIME type or an extension, or none matches, the start of the file is sniffed to decide.
+type ReaderRegistry struct {
+	mu      sync.RWMutex
+	formats []ReaderFormat
+}
+
+// NewReaderRegistry creates an empty ReaderRegistry
+func NewReaderRegistry() *ReaderRegistry {
+	return &ReaderRegistry{}
+}
+
+// NewDefaultReaderRegistry creates a ReaderRegistry with the CSV, OFX, camt.053, MT940 and JSON readers
+func NewDefaultReaderRegistry() *ReaderRegistry {
+	registry := NewReaderRegistry()
+	registry.Register(NewCSVReaderFormat(NewCSVFileReader()))
+	registry.Register(ReaderFormat{
+		Name:       "OFX",
+		Extensions: []string{".ofx", ".qfx"},
+		MIMETypes:  []string{"application/x-ofx", "application/vnd.intu.qfx"},
+		Sniff:      sniffOFX,
+		Reader:     NewOFXFileReader(),
+	})
+	registry.Register(ReaderFormat{
+		Name:       "camt.053",
+		Extensions: []string{".xml"},
+		MIMETypes:  []string{"application/xml", "text/xml"},
+		Sniff:      sniffCAMT,
+		Reader:     NewCAMTFileReader(),
+	})
+	registry.Register(ReaderFormat{
+		Name:       "MT940",
+		Extensions: []string{".sta", ".mt940", ".940"},
+		Sniff:      sniffMT940,
+		Reader:     NewMT940FileReader(),
+	})
+	registry.Register(ReaderFormat{
+		Name:       "JSON",
+		Extensions: []string{".json", ".ndjson", ".jsonl"},
+		MIMETypes:  []string{"application/json", "application/x-ndjson"},
+		Sniff:      sniffJSON,
+		Reader:     NewJSONFileReader(),
+	})
+	return registry
+}
+
+// NewCSVReaderFormat describes the CSV format read by a CSV reader, e.g. one using a custom profile
+func NewCSVReaderFormat(reader *CSVFileReader) ReaderFormat {
+	return ReaderFormat{
+		Name:       "CSV",
+		Extensions: []string{".csv"},
+		MIMETypes:  []string{"text/csv", "application/csv"},
+		Sniff:      reader.sniff,
+		Reader:     reader,
+	}
+}
+
+// Register adds a format to the registry. Formats registered later take precedence, so custom
+// readers can replace the built-in ones.
+func (r *ReaderRegistry) Register(format ReaderFormat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.formats = append([]ReaderFormat{format}, r.formats...)
+}
+
+// Clone returns a copy of the registry, so formats can be registered for a single use
+func (r *ReaderRegistry) Clone() *ReaderRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &ReaderRegistry{formats: append([]ReaderFormat(nil), r.formats...)}
+}
+
+// Resolve returns the reader of a file. The MIME type is optional.
+func (r *ReaderRegistry) Resolve(filePath, mimeType string) (ports.FileReader, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Narrow the formats down by MIME type or else by extension
+	var candidates []ReaderFormat
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		candidates = filterFormats(r.formats, func(format ReaderFormat) bool {
+			return containsFold(format.MIMETypes, mediaType)
+		})
+	}
+	if len(candidates) == 0 {
+		extension := strings.ToLower(filepath.Ext(filePath))
+		candidates = filterFormats(r.formats, func(format ReaderFormat) bool {
+			return containsFold(format.Extensions, extension)
+		})
+	}
+	if len(candidates) == 1 {
+		return candidates[0].Reader, nil
+	}
+	matched := len(candidates) > 0
+	if !matched {
+		candidates = r.formats
+	}
+
+	head, err := readHead(filePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, format := range candidates {
+		if format.Sniff != nil && format.Sniff(head) {
+			return format.Reader, nil
+		}
+	}
+
+	// Formats matching the MIME type or extension are picked by precedence when sniffing is inconclusive
+	if matched {
+		return candidates[0].Reader, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s, supported formats: %s", ErrUnsupportedFormat, filepath.Base(filePath), r.supportedFormats())
+}
+
+// ReadTransactions reads transactions with the reader of the file format
+func (r *ReaderRegistry) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	return r.WithMIMEType("").ReadTransactions(ctx, filePath)
+}
+
+// ReadStatement reads a statement with the reader of the file format
+func (r *ReaderRegistry) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	return r.WithMIMEType("").ReadStatement(ctx, filePath)
+}
+
+// WithMIMEType returns a reader resolving files with the given MIME type, e.g. the content type of
+// an upload
+func (r *ReaderRegistry) WithMIMEType(mimeType string) ports.StatementReader {
+	return &mimeTypeReader{registry: r, mimeType: mimeType}
+}
+
+// supportedFormats lists the registered formats and their extensions in registration order
+func (r *ReaderRegistry) supportedFormats() string {
+	descriptions := make([]string, 0, len(r.formats))
+	for i := len(r.formats) - 1; i >= 0; i-- {
+		format := r.formats[i]
+		description := format.Name
+		if len(format.Extensions) > 0 {
+			description += " (" + strings.Join(format.Extensions, ", ") + ")"
+		}
+		descriptions = append(descriptions, description)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// mimeTypeReader resolves the reader of each file with a MIME type hint
+type mimeTypeReader struct {
+	registry *ReaderRegistry
+	mimeType string
+}
+
+// ReadTransactions reads transactions with the reader of the file format
+func (m *mimeTypeReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := m.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads a statement with the reader of the file format. Readers that do not declare
+// balances only provide the transactions. Gzip and zstd files are decompressed, and each file of a
+// zip or tar archive is read with the reader of its own format into a part of the statement, with the
+// IDs of its transactions namespaced by the file they were read from.
+func (m *mimeTypeReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	statement, err := m.registry.readStatement(ctx, filePath, m.mimeType, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceArchiveIDs(statement)
+	return statement, nil
+}
+
+// readStatement reads a statement, unwrapping up to maxContainerDepth compressed or archived layers
+func (r *ReaderRegistry) readStatement(ctx context.Context, filePath, mimeType string, depth int) (*model.Statement, error) {
+	head, err := readHead(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	container := detectContainer(head)
+	if container != containerNone && depth >= maxContainerDepth {
+		return nil, fmt.Errorf("%w for %s, more than %d nested archives", ErrUnsupportedFormat, filepath.Base(filePath), maxContainerDepth)
+	}
+
+	switch container {
+	case containerGzip, containerZstd:
+		decompressed, cleanup, err := decompress(filePath, container)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		return r.readStatement(ctx, decompressed, "", depth+1)
+	case containerZip, containerTar:
+		return r.readArchive(ctx, filePath, container, depth)
+	}
+
+	reader, err := r.Resolve(filePath, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	if statementReader, ok := reader.(ports.StatementReader); ok {
+		return statementReader.ReadStatement(ctx, filePath)
+	}
+
+	transactions, err := reader.ReadTransactions(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Statement{Transactions: transactions}, nil
+}
+
+// readArchive reads each file of a zip or tar archive into a part of the statement. Parts of nested
+// archives are flattened, with their source prefixed by the name of the nested archive.
+func (r *ReaderRegistry) readArchive(ctx context.Context, filePath string, container containerFormat, depth int) (*model.Statement, error) {
+	entries, cleanup, err := extractArchive(filePath, container)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	var parts []*model.Statement
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped reading archive before entry %s: %w", entry.name, err)
+		}
+
+		statement, err := r.readStatement(ctx, entry.path, "", depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("error reading archive entry %s: %w", entry.name, err)
+		}
+
+		nested := statement.Parts
+		if len(nested) == 0 {
+			nested = []*model.Statement{statement}
+		}
+		for _, part := range nested {
+			part.Source = path.Join(entry.name, part.Source)
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w for %s, the archive holds no statement files", ErrUnsupportedFormat, filepath.Base(filePath))
+	}
+	return newArchiveStatement(parts), nil
+}
+
+// readHead reads the start of a file for sniffing
+func readHead(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	return head[:n], nil
+}
+
+// filterFormats returns the formats matching the predicate
+func filterFormats(formats []ReaderFormat, match func(ReaderFormat) bool) []ReaderFormat {
+	var matched []ReaderFormat
+	for _, format := range formats {
+		if match(format) {
+			matched = append(matched, format)
+		}
+	}
+	return matched
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+var mt940StartPattern = regexp.MustCompile(`(?m)^(\{1:|:20:)`)
+
+// sniffOFX matches the OFX 1.x header or the OFX root element
+func sniffOFX(head []byte) bool {
+	upper := bytes.ToUpper(head)
+	return bytes.Contains(upper, []byte("OFXHEADER")) || bytes.Contains(upper, []byte("<OFX>"))
+}
+
+// sniffCAMT matches the camt.053 namespace or root element
+func sniffCAMT(head []byte) bool {
+	return bytes.Contains(head, []byte("camt.053")) || bytes.Contains(head, []byte("<BkToCstmrStmt"))
+}
+
+// sniffMT940 matches the SWIFT basic header block or a leading :20: transaction reference
+func sniffMT940(head []byte) bool {
+	return mt940StartPattern.Match(bytes.TrimSpace(head)) && bytes.Contains(head, []byte(":25:"))
+}
+
+// sniffJSON matches content starting with an array or an object with a named member
+func sniffJSON(head []byte) bool {
+	trimmed := bytes.TrimSpace(head)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		return true
+	}
+	return bytes.HasPrefix(trimmed, []byte("{")) && bytes.HasPrefix(bytes.TrimSpace(trimmed[1:]), []byte(`"`))
+}
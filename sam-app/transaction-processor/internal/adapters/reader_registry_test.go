@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReaderRegistry_Resolve(t *testing.T) {
+	tempDir := t.TempDir()
+	registry := NewDefaultReaderRegistry()
+
+	writeFile := func(t *testing.T, name, content string) string {
+		filePath := filepath.Join(tempDir, name)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+		return filePath
+	}
+
+	camtContent := strings.Replace(camtStatementTemplate, "CLOSING", "3379.50", 1)
+
+	tests := []struct {
+		name     string
+		fileName string
+		mimeType string
+		content  string
+		expected interface{}
+	}{
+		{name: "CSV by extension", fileName: "a.csv", content: "", expected: &CSVFileReader{}},
+		{name: "OFX by extension", fileName: "a.qfx", content: "", expected: &OFXFileReader{}},
+		{name: "MT940 by extension", fileName: "a.sta", content: "", expected: &MT940FileReader{}},
+		{name: "NDJSON by extension", fileName: "a.jsonl", content: "", expected: &JSONFileReader{}},
+		{name: "camt.053 by MIME type", fileName: "upload", mimeType: "application/xml; charset=utf-8", content: "", expected: &CAMTFileReader{}},
+		{name: "JSON by MIME type over extension", fileName: "a.csv", mimeType: "application/json", content: "", expected: &JSONFileReader{}},
+		{name: "CSV sniffed", fileName: "upload.txt", content: "Id,Date,Transaction\n0,7/15,+60.5", expected: &CSVFileReader{}},
+		{name: "OFX sniffed", fileName: "upload.dat", content: "OFXHEADER:100\nDATA:OFXSGML\n<OFX>", expected: &OFXFileReader{}},
+		{name: "camt.053 sniffed", fileName: "upload", content: camtContent, expected: &CAMTFileReader{}},
+		{name: "MT940 sniffed", fileName: "upload.txt", content: "{1:F01BANK}{2:O940}{4:\n:20:REF\n:25:ACC\n-}", expected: &MT940FileReader{}},
+		{name: "JSON sniffed", fileName: "upload.txt", content: `{"id": "0"}`, expected: &JSONFileReader{}},
+		{name: "unknown MIME type falls back to sniffing", fileName: "upload", mimeType: "application/octet-stream", content: "[]", expected: &JSONFileReader{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := registry.Resolve(writeFile(t, tt.fileName, tt.content), tt.mimeType)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			assert.IsType(t, tt.expected, reader)
+		})
+	}
+
+	t.Run("unsupported format lists supported formats", func(t *testing.T) {
+		_, err := registry.Resolve(writeFile(t, "notes.txt", "hello"), "")
+		assert.True(t, errors.Is(err, ErrUnsupportedFormat), "expected ErrUnsupportedFormat, got %v", err)
+		assert.Contains(t, err.Error(), "notes.txt")
+		assert.Contains(t, err.Error(), "CSV (.csv), OFX (.ofx, .qfx), camt.053 (.xml), MT940 (.sta, .mt940, .940), JSON (.json, .ndjson, .jsonl)")
+	})
+
+	t.Run("custom reader takes precedence", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		custom := mocks.NewMockFileReader(ctrl)
+		filePath := writeFile(t, "custom.csv", "Date;Amount")
+		custom.EXPECT().ReadTransactions(gomock.Any(), filePath).Return([]*model.Transaction{{ID: "1", Amount: 10}}, nil)
+
+		registry := NewDefaultReaderRegistry()
+		registry.Register(ReaderFormat{Name: "Bank CSV", Extensions: []string{".csv"}, Reader: custom})
+
+		// Readers without declared balances are wrapped into a statement
+		statement, err := registry.ReadStatement(context.Background(), filePath)
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 1)
+		assert.Nil(t, statement.ClosingBalance)
+	})
+
+	t.Run("statement reader", func(t *testing.T) {
+		statement, err := registry.WithMIMEType("").ReadStatement(context.Background(), writeFile(t, "statement.xml", camtContent))
+		if err != nil {
+			t.Fatalf("ReadStatement failed: %v", err)
+		}
+		assert.Len(t, statement.Transactions, 3)
+		assert.Equal(t, 3379.5, statement.ClosingBalance.Amount)
+	})
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// ErrObjectTooLarge is returned when downloading an object larger than the maximum size of the storage
+var ErrObjectTooLarge = errors.New("object exceeds the maximum size")
+
+// S3ObjectStorage implements the ObjectStorage port using an S3 bucket
+type S3ObjectStorage struct {
+	s3Client  ports.S3Client
+	presigner ports.S3Presigner
+	bucket    string
+	maxSize   int64
+}
+
+// NewS3ObjectStorage creates a new S3ObjectStorage downloading objects of up to maxSize bytes. Presigned
+// URLs cannot bound the size of what is PUT to them, so larger objects are rejected when downloaded.
+func NewS3ObjectStorage(s3Client *s3.Client, bucket string, maxSize int64) *S3ObjectStorage {
+	return &S3ObjectStorage{
+		s3Client:  s3Client,
+		presigner: s3.NewPresignClient(s3Client),
+		bucket:    bucket,
+		maxSize:   maxSize,
+	}
+}
+
+// PresignUpload returns a presigned PUT URL for an object. When a content type is given, uploads are
+// expected to send it as their Content-Type header.
+func (s *S3ObjectStorage) PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	request, err := s.presigner.PresignPutObject(ctx, input, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("error presigning upload URL: %w", err)
+	}
+
+	return request.URL, nil
+}
+
+// DownloadObject writes the content of an object of the bucket into a file. Objects over the maximum
+// size return ErrObjectTooLarge, before their content is read when S3 reports their length.
+func (s *S3ObjectStorage) DownloadObject(ctx context.Context, key, filePath string) error {
+	result, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting object from S3: %w", err)
+	}
+	defer result.Body.Close()
+
+	tooLarge := fmt.Errorf("%w of %d bytes: %s", ErrObjectTooLarge, s.maxSize, key)
+	if result.ContentLength > s.maxSize {
+		return tooLarge
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, io.LimitReader(result.Body, s.maxSize+1))
+	if err != nil {
+		return fmt.Errorf("error downloading object from S3: %w", err)
+	}
+	if written > s.maxSize {
+		return tooLarge
+	}
+
+	return nil
+}
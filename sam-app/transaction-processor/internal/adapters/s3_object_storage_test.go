@@ -0,0 +1,117 @@
+package adapters
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+// newS3StandIn starts an S3-compatible stand-in storing the objects PUT to it by path
+func newS3StandIn(t *testing.T) (*httptest.Server, map[string][]byte) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			content, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = content
+		case http.MethodGet:
+			content, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+				return
+			}
+			w.Write(content)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, objects
+}
+
+func newTestS3ObjectStorage(endpoint string) *S3ObjectStorage {
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		BaseEndpoint: aws.String(endpoint),
+		UsePathStyle: true,
+	})
+	return NewS3ObjectStorage(client, "statements", 64)
+}
+
+func TestS3ObjectStorage_PresignUpload(t *testing.T) {
+	server, objects := newS3StandIn(t)
+	storage := newTestS3ObjectStorage(server.URL)
+
+	presigned, err := storage.PresignUpload(context.Background(), "uploads/acc123/u1/statement.csv", "text/csv", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignUpload failed: %v", err)
+	}
+
+	parsed, err := url.Parse(presigned)
+	if err != nil {
+		t.Fatalf("Invalid presigned URL: %v", err)
+	}
+	assert.Equal(t, "/statements/uploads/acc123/u1/statement.csv", parsed.Path)
+	assert.Equal(t, "900", parsed.Query().Get("X-Amz-Expires"))
+
+	// The presigned URL uploads the object to the stand-in
+	request, _ := http.NewRequest(http.MethodPut, presigned, strings.NewReader("Id,Date,Transaction\n"))
+	request.Header.Set("Content-Type", "text/csv")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "Id,Date,Transaction\n", string(objects["/statements/uploads/acc123/u1/statement.csv"]))
+}
+
+func TestS3ObjectStorage_DownloadObject(t *testing.T) {
+	server, objects := newS3StandIn(t)
+	storage := newTestS3ObjectStorage(server.URL)
+	objects["/statements/uploads/acc123/u1/statement.csv"] = []byte("Id,Date,Transaction\n0,7/15,+60.5\n")
+
+	filePath := filepath.Join(t.TempDir(), "statement.csv")
+	err := storage.DownloadObject(context.Background(), "uploads/acc123/u1/statement.csv", filePath)
+	if err != nil {
+		t.Fatalf("DownloadObject failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "Id,Date,Transaction\n0,7/15,+60.5\n", string(content))
+
+	t.Run("object over the maximum size", func(t *testing.T) {
+		objects["/statements/uploads/acc123/u3/large.csv"] = []byte(strings.Repeat("0,7/15,+60.5\n", 10))
+
+		err := storage.DownloadObject(context.Background(), "uploads/acc123/u3/large.csv", filepath.Join(t.TempDir(), "large.csv"))
+		assert.ErrorIs(t, err, ErrObjectTooLarge)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		err := storage.DownloadObject(context.Background(), "uploads/acc123/u2/missing.csv", filepath.Join(t.TempDir(), "missing.csv"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "NoSuchKey")
+	})
+}
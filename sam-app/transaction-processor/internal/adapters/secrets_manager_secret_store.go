@@ -0,0 +1,50 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// SecretsManagerSecretStore implements the SecretStore port using AWS Secrets Manager. Secrets are cached
+// once read, so warm invocations of a function do not read them again.
+type SecretsManagerSecretStore struct {
+	client ports.SecretsManagerClient
+	mu     sync.Mutex
+	cache  map[string]string
+}
+
+// NewSecretsManagerSecretStore creates a new SecretsManagerSecretStore
+func NewSecretsManagerSecretStore(client *secretsmanager.Client) *SecretsManagerSecretStore {
+	return &SecretsManagerSecretStore{
+		client: client,
+		cache:  map[string]string{},
+	}
+}
+
+// GetSecret returns the string value of the current version of a secret
+func (s *SecretsManagerSecretStore) GetSecret(ctx context.Context, secretID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value, ok := s.cache[secretID]; ok {
+		return value, nil
+	}
+
+	result, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading secret %s: %w", secretID, err)
+	}
+	if result.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", secretID)
+	}
+
+	s.cache[secretID] = *result.SecretString
+	return *result.SecretString, nil
+}
@@ -0,0 +1,56 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSecretsManagerSecretStore_GetSecret(t *testing.T) {
+	t.Run("reads a secret once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := mocks.NewMockSecretsManagerClient(ctrl)
+		store := &SecretsManagerSecretStore{client: mockClient, cache: map[string]string{}}
+
+		mockClient.
+			EXPECT().
+			GetSecretValue(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+				assert.Equal(t, "email-password", *input.SecretId)
+				return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("app password")}, nil
+			})
+
+		for i := 0; i < 2; i++ {
+			value, err := store.GetSecret(context.Background(), "email-password")
+			assert.NoError(t, err)
+			assert.Equal(t, "app password", value)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockClient := mocks.NewMockSecretsManagerClient(ctrl)
+		store := &SecretsManagerSecretStore{client: mockClient, cache: map[string]string{}}
+
+		gomock.InOrder(
+			mockClient.EXPECT().GetSecretValue(gomock.Any(), gomock.Any()).Return(nil, errors.New("access denied")),
+			mockClient.EXPECT().GetSecretValue(gomock.Any(), gomock.Any()).Return(&secretsmanager.GetSecretValueOutput{}, nil),
+		)
+
+		_, err := store.GetSecret(context.Background(), "email-password")
+		assert.ErrorContains(t, err, "access denied")
+
+		_, err = store.GetSecret(context.Background(), "email-password")
+		assert.ErrorContains(t, err, "no string value")
+	})
+}
@@ -2,12 +2,19 @@ package adapters
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"gopkg.in/mail.v2"
 	"html/template"
 	"transaction-processor/internal/ports"
 )
 
+// Subjects of the summary emails
+const (
+	summarySubject             = "Transaction Summary"
+	consolidatedSummarySubject = "Consolidated Transaction Summary"
+)
+
 // SMTPConfiguration holds the SMTP configuration
 type SMTPConfiguration struct {
 	Sender     string
@@ -78,18 +85,39 @@ func NewSMTPEmailSenderWithDependencies(
 }
 
 // SendSummaryEmail sends a summary email using SMTP with gomail
-func (s *SMTPClient) SendSummaryEmail(recipient string, summary ports.EmailSummary) error {
+func (s *SMTPClient) SendSummaryEmail(ctx context.Context, recipient string, summary ports.EmailSummary) error {
 	// Generate the email content using the HTML template
 	emailBody, err := s.generateEmailBody(recipient, summary)
 	if err != nil {
 		return fmt.Errorf("error generating email body: %w", err)
 	}
 
+	return s.send(ctx, recipient, summarySubject, emailBody)
+}
+
+// SendConsolidatedSummaryEmail sends a summary email covering every account of a customer
+func (s *SMTPClient) SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ports.ConsolidatedSummary) error {
+	// Generate the email content using the HTML template
+	emailBody, err := s.generateConsolidatedEmailBody(recipient, summary)
+	if err != nil {
+		return fmt.Errorf("error generating email body: %w", err)
+	}
+
+	return s.send(ctx, recipient, consolidatedSummarySubject, emailBody)
+}
+
+// send delivers an HTML email to the recipient
+func (s *SMTPClient) send(ctx context.Context, recipient, subject, emailBody string) error {
+	// The dialer cannot be interrupted, so the email is not sent once the context is done
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+
 	// Create the email message using the factory
 	msg := s.messageFactory.NewMessage()
 	msg.SetHeader("From", s.sender)
 	msg.SetHeader("To", recipient)
-	msg.SetHeader("Subject", "Transaction Summary")
+	msg.SetHeader("Subject", subject)
 	msg.SetBody("text/html", emailBody)
 
 	// We need to create a concrete mail.Message for DialAndSend
@@ -97,7 +125,7 @@ func (s *SMTPClient) SendSummaryEmail(recipient string, summary ports.EmailSumma
 	realMsg := mail.NewMessage()
 	realMsg.SetHeader("From", s.sender)
 	realMsg.SetHeader("To", recipient)
-	realMsg.SetHeader("Subject", "Transaction Summary")
+	realMsg.SetHeader("Subject", subject)
 	realMsg.SetBody("text/html", emailBody)
 
 	// Send the email
@@ -108,27 +136,52 @@ func (s *SMTPClient) SendSummaryEmail(recipient string, summary ports.EmailSumma
 	return nil
 }
 
-// generateEmailBody generates the email content using the HTML template
-func (s *SMTPClient) generateEmailBody(recipient string, summary ports.EmailSummary) (string, error) {
-	if recipient == "" {
-		return "", fmt.Errorf("recipient cannot be empty")
-	}
+// emailTemplateFuncs holds the helper functions available to the email template
+var emailTemplateFuncs = template.FuncMap{
+	"neg": func(value float64) float64 {
+		return -value
+	},
+	"percent": func(ratio float64) string {
+		return fmt.Sprintf("%.0f%%", ratio*100)
+	},
+}
 
-	tmpl := `<!DOCTYPE html>
-<html>
-<head>
-    <title>Transaction Summary</title>
+// emailTemplates holds the summary email templates. The "account" template renders the sections of a
+// single account and is shared by the single account and the consolidated emails.
+var emailTemplates = template.Must(template.New("emailTemplate").Funcs(emailTemplateFuncs).Parse(`
+{{define "head"}}<head>
+    <title>{{.}}</title>
     <style>
         body { font-family: Arial, sans-serif; line-height: 1.6; }
         h1 { color: #2a5885; }
         table { border-collapse: collapse; width: 100%; }
         th, td { border: 1px solid #ddd; padding: 8px; text-align: left; }
         th { background-color: #f2f2f2; }
+        .warning { color: #b00020; }
     </style>
-</head>
-<body>
-    <h1>Transaction Summary</h1>
+</head>{{end}}
+
+{{define "account"}}
+    {{with .Period}}
+    <p><strong>Statement period:</strong> {{.Start.Format "January 2, 2006"}} to {{.End.Format "January 2, 2006"}}</p>
+    {{end}}
     <p><strong>Total balance is:</strong> ${{printf "%.2f" .TotalBalance}}</p>
+    {{with .StatementBalance}}
+    <p><strong>Statement ledger balance:</strong> ${{printf "%.2f" .Amount}} as of {{.Date.Format "January 2, 2006"}}</p>
+    {{end}}
+    {{if .PendingTransactionCount}}
+    <p><strong>Available balance is:</strong> ${{printf "%.2f" .AvailableBalance}} ({{.PendingTransactionCount}} pending transactions)</p>
+    {{end}}
+    {{if .StatementFiles}}
+
+    <h2>Statement Files</h2>
+    <table>
+        <tr><th>File</th><th>Transactions</th><th>Ledger balance</th></tr>
+        {{range .StatementFiles}}
+        <tr><td>{{.Source}}</td><td>{{.TransactionCount}}</td><td>{{with .ClosingBalance}}${{printf "%.2f" .Amount}} as of {{.Date.Format "January 2, 2006"}}{{else}}-{{end}}</td></tr>
+        {{end}}
+    </table>
+    {{end}}
 
     <h2>Monthly Transaction Count</h2>
     <table>
@@ -143,24 +196,101 @@ func (s *SMTPClient) generateEmailBody(recipient string, summary ports.EmailSumm
         <tr><td>Average debit amount:</td><td>$-{{printf "%.2f" .AverageDebitAmount}}</td></tr>
         <tr><td>Average credit amount:</td><td>${{printf "%.2f" .AverageCreditAmount}}</td></tr>
     </table>
+    {{if .Charges}}
+
+    <h2>Interest and Fees</h2>
+    <table>
+        <tr><th>Description</th><th>Amount</th></tr>
+        {{range .Charges}}
+        <tr><td>{{.Description}}</td><td>{{if .IsCredit}}${{else}}$-{{end}}{{printf "%.2f" .Amount}}</td></tr>
+        {{end}}
+        <tr><td><strong>Interest earned</strong></td><td>${{printf "%.2f" $.InterestEarned}}</td></tr>
+        <tr><td><strong>Fees charged</strong></td><td>$-{{printf "%.2f" $.FeesCharged}}</td></tr>
+    </table>
+    {{end}}
+    {{with .Forecast}}
+
+    <h2>Forecast for {{.Month}} {{.Year}}</h2>
+    <table>
+        <tr><td>Projected credits:</td><td>${{printf "%.2f" .ProjectedCredit}}</td></tr>
+        <tr><td>Projected debits:</td><td>$-{{printf "%.2f" .ProjectedDebit}}</td></tr>
+        <tr><td>Projected closing balance:</td><td>${{printf "%.2f" .ProjectedBalance}}</td></tr>
+        <tr><td>Expected range:</td><td>${{printf "%.2f" .LowerBound}} to ${{printf "%.2f" .UpperBound}}</td></tr>
+    </table>
+    {{if .IsProjectedNegative}}
+    <p class="warning"><strong>Warning:</strong> your balance is projected to be negative by the end of {{.Month}}.</p>
+    {{else if .MayGoNegative}}
+    <p class="warning"><strong>Warning:</strong> your balance could become negative by the end of {{.Month}}.</p>
+    {{end}}
+    {{end}}
+    {{if .Budgets}}
+
+    <h2>Budgets</h2>
+    {{range .GetBudgetWarnings}}
+    {{if .IsOverspent}}
+    <p class="warning"><strong>Warning:</strong> you are over your {{.Category}} budget by ${{printf "%.2f" (neg .Remaining)}}.</p>
+    {{else}}
+    <p class="warning"><strong>Warning:</strong> you have used {{percent .UsedRatio}} of your {{.Category}} budget.</p>
+    {{end}}
+    {{end}}
+    <table>
+        <tr><th>Category</th><th>Budget</th><th>Spent</th><th>Remaining</th></tr>
+        {{range .Budgets}}
+        <tr><td>{{.Category}}</td><td>${{printf "%.2f" .Limit}}</td><td>${{printf "%.2f" .Spent}}</td><td>${{printf "%.2f" .Remaining}}</td></tr>
+        {{end}}
+    </table>
+    {{end}}
+{{end}}
+
+{{define "summary"}}<!DOCTYPE html>
+<html>
+{{template "head" "Transaction Summary"}}
+<body>
+    <h1>Transaction Summary</h1>
+    {{template "account" .}}
+</body>
+</html>{{end}}
+
+{{define "consolidated"}}<!DOCTYPE html>
+<html>
+{{template "head" "Consolidated Transaction Summary"}}
+<body>
+    <h1>Consolidated Transaction Summary</h1>
+    {{if .CustomerName}}<p>Hello {{.CustomerName}},</p>{{end}}
+    <p><strong>Combined net worth:</strong> ${{printf "%.2f" .NetWorth}}</p>
+    <table>
+        <tr><th>Account</th><th>Balance</th></tr>
+        {{range .Accounts}}
+        <tr><td>{{.AccountID}}</td><td>${{printf "%.2f" .Summary.TotalBalance}}</td></tr>
+        {{end}}
+    </table>
+    {{range .Accounts}}
+
+    <h1>Account {{.AccountID}}</h1>
+    {{template "account" .Summary}}
+    {{end}}
 </body>
-</html>`
-
-	tmplData := struct {
-		TotalBalance             float64
-		MonthlyTransactionCounts map[string]int
-		AverageCreditAmount      float64
-		AverageDebitAmount       float64
-	}{
-		TotalBalance:             summary.TotalBalance,
-		MonthlyTransactionCounts: summary.MonthlyTransactionCounts,
-		AverageCreditAmount:      summary.AverageCreditAmount,
-		AverageDebitAmount:       summary.AverageDebitAmount,
+</html>{{end}}
+`))
+
+// generateEmailBody generates the email content using the HTML template
+func (s *SMTPClient) generateEmailBody(recipient string, summary ports.EmailSummary) (string, error) {
+	return renderEmail("summary", recipient, summary)
+}
+
+// generateConsolidatedEmailBody generates the consolidated email content using the HTML template
+func (s *SMTPClient) generateConsolidatedEmailBody(recipient string, summary ports.ConsolidatedSummary) (string, error) {
+	return renderEmail("consolidated", recipient, summary)
+}
+
+// renderEmail renders the email content of a recipient with the named HTML template
+func renderEmail(name, recipient string, data interface{}) (string, error) {
+	if recipient == "" {
+		return "", fmt.Errorf("recipient cannot be empty")
 	}
 
 	var emailBody bytes.Buffer
-	tmplObj := template.Must(template.New("emailTemplate").Parse(tmpl))
-	err := tmplObj.Execute(&emailBody, tmplData)
+	err := emailTemplates.ExecuteTemplate(&emailBody, name, data)
 	if err != nil {
 		return "", fmt.Errorf("error executing template: %w", err)
 	}
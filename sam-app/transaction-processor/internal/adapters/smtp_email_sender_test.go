@@ -1,9 +1,12 @@
 package adapters
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
 	"transaction-processor/internal/mocks"
 	"transaction-processor/internal/ports"
 
@@ -122,7 +125,7 @@ func TestSMTPClient_SendSummaryEmail(t *testing.T) {
 				"sender@example.com",
 			)
 
-			err := smtpClient.SendSummaryEmail(tt.recipient, tt.summary)
+			err := smtpClient.SendSummaryEmail(context.Background(), tt.recipient, tt.summary)
 
 			if tt.wantErr {
 				if err == nil {
@@ -172,6 +175,19 @@ func TestSMTPClient_generateEmailBody(t *testing.T) {
 				"Average Transaction Amounts",
 			},
 		},
+		{
+			name:      "monthly statement shows its period",
+			recipient: "test@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance: 70.0,
+				Period:       &model.StatementPeriod{Start: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Statement period:",
+				"June 1, 2025 to June 30, 2025",
+			},
+		},
 		{
 			name:      "empty recipient should fail",
 			recipient: "",
@@ -216,6 +232,133 @@ func TestSMTPClient_generateEmailBody(t *testing.T) {
 				"$-150.25",
 			},
 		},
+		{
+			name:      "forecast with negative projection",
+			recipient: "forecast@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             50.0,
+				MonthlyTransactionCounts: map[string]int{"July": 2},
+				AverageCreditAmount:      100.0,
+				AverageDebitAmount:       50.0,
+				Forecast: &model.Forecast{
+					Month:            time.August,
+					Year:             2025,
+					ProjectedCredit:  100.0,
+					ProjectedDebit:   175.0,
+					ProjectedBalance: -25.0,
+					LowerBound:       -40.0,
+					UpperBound:       -10.0,
+				},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Forecast for August 2025",
+				"$-25.00",
+				"$-40.00 to $-10.00",
+				"projected to be negative",
+			},
+		},
+		{
+			name:      "forecast with positive projection",
+			recipient: "forecast@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             500.0,
+				MonthlyTransactionCounts: map[string]int{"July": 2},
+				Forecast: &model.Forecast{
+					Month:            time.August,
+					Year:             2025,
+					ProjectedBalance: 600.0,
+					LowerBound:       550.0,
+					UpperBound:       650.0,
+				},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Forecast for August 2025",
+				"$600.00",
+			},
+			notExpectedInBody: []string{
+				"Warning",
+			},
+		},
+		{
+			name:      "budgets with overspend and near limit warnings",
+			recipient: "budget@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             100.0,
+				MonthlyTransactionCounts: map[string]int{"July": 3},
+				Budgets: []model.BudgetStatus{
+					{Category: "Groceries", Limit: 200, Spent: 250, Remaining: -50, UsedRatio: 1.25, State: model.BudgetStateOverspent},
+					{Category: "Leisure", Limit: 100, Spent: 85, Remaining: 15, UsedRatio: 0.85, State: model.BudgetStateNearLimit},
+					{Category: "Transport", Limit: 100, Spent: 10, Remaining: 90, UsedRatio: 0.1, State: model.BudgetStateOnTrack},
+				},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Budgets",
+				"over your Groceries budget by $50.00",
+				"used 85% of your Leisure budget",
+				"Transport",
+				"$90.00",
+			},
+			notExpectedInBody: []string{
+				"your Transport budget",
+			},
+		},
+		{
+			name:      "interest and fees shown separately",
+			recipient: "charges@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             271.61,
+				MonthlyTransactionCounts: map[string]int{"July": 3},
+				InterestEarned:           2.11,
+				FeesCharged:              25,
+				Charges: []*model.Transaction{
+					{ID: "fee-overdraft-2", Amount: 25, Description: "Overdraft fee for 2", Kind: model.TransactionKindFee},
+					{ID: "interest-2025-07-31", Amount: 2.11, IsCredit: true, Description: "simple interest (3.65% APR)", Kind: model.TransactionKindInterest},
+				},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Interest and Fees",
+				"Overdraft fee for 2",
+				"$-25.00",
+				"simple interest (3.65% APR)",
+				"$2.11",
+			},
+		},
+		{
+			name:      "statement ledger balance",
+			recipient: "ofx@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             150,
+				MonthlyTransactionCounts: map[string]int{"January": 2},
+				StatementBalance:         &model.DeclaredBalance{Amount: 1150.25, Date: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Statement ledger balance:",
+				"$1150.25 as of January 31, 2025",
+			},
+		},
+		{
+			name:      "archived statement files",
+			recipient: "archive@example.com",
+			summary: ports.EmailSummary{
+				TotalBalance:             150,
+				MonthlyTransactionCounts: map[string]int{"January": 3},
+				StatementFiles: []ports.StatementFileSummary{
+					{Source: "january/checking.sta", TransactionCount: 2, ClosingBalance: &model.DeclaredBalance{Amount: 2999.5, Date: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)}},
+					{Source: "january/card.csv", TransactionCount: 1},
+				},
+			},
+			wantErr: false,
+			expectedInBody: []string{
+				"Statement Files",
+				"<td>january/checking.sta</td><td>2</td><td>$2999.50 as of January 31, 2025</td>",
+				"<td>january/card.csv</td><td>1</td><td>-</td>",
+			},
+		},
 		{
 			name:      "single month transaction",
 			recipient: "single@example.com",
@@ -252,7 +395,7 @@ func TestSMTPClient_generateEmailBody(t *testing.T) {
 					"sender@example.com",
 				)
 
-				err := client.SendSummaryEmail(tt.recipient, tt.summary)
+				err := client.SendSummaryEmail(context.Background(), tt.recipient, tt.summary)
 				if err == nil {
 					t.Errorf("expected error for empty recipient, got nil")
 				}
@@ -292,7 +435,7 @@ func TestSMTPClient_generateEmailBody(t *testing.T) {
 				"sender@example.com",
 			)
 
-			err := client.SendSummaryEmail(tt.recipient, tt.summary)
+			err := client.SendSummaryEmail(context.Background(), tt.recipient, tt.summary)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -325,6 +468,54 @@ func TestSMTPClient_generateEmailBody(t *testing.T) {
 	}
 }
 
+func TestSMTPClient_SendConsolidatedSummaryEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDialer := mocks.NewMockMailDialer(ctrl)
+	mockFactory := mocks.NewMockMailMessageFactory(ctrl)
+	mockMsg := mocks.NewMockMailMessage(ctrl)
+
+	var capturedBody string
+	mockFactory.EXPECT().NewMessage().Return(mockMsg).Times(1)
+	mockMsg.EXPECT().SetHeader("From", "sender@example.com").Times(1)
+	mockMsg.EXPECT().SetHeader("To", "jane@example.com").Times(1)
+	mockMsg.EXPECT().SetHeader("Subject", "Consolidated Transaction Summary").Times(1)
+	mockMsg.EXPECT().SetBody("text/html", gomock.Any()).
+		Do(func(contentType, body string, settings ...interface{}) {
+			capturedBody = body
+		}).Times(1)
+	mockDialer.EXPECT().DialAndSend(gomock.Any()).Return(nil).Times(1)
+
+	client := NewSMTPEmailSenderWithDependencies(mockDialer, mockFactory, "sender@example.com")
+
+	summary := ports.NewConsolidatedSummary("Jane", []ports.AccountSummary{
+		{AccountID: "checking", Summary: ports.EmailSummary{TotalBalance: 150.25, MonthlyTransactionCounts: map[string]int{"July": 2}}},
+		{AccountID: "savings", Summary: ports.EmailSummary{TotalBalance: 2000, MonthlyTransactionCounts: map[string]int{"June": 1}}},
+	})
+
+	err := client.SendConsolidatedSummaryEmail(context.Background(), "jane@example.com", summary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Consolidated Transaction Summary",
+		"Hello Jane",
+		"Combined net worth:</strong> $2150.25",
+		"Account checking",
+		"Account savings",
+		"$150.25",
+		"$2000.00",
+		"July",
+		"June",
+	} {
+		if !strings.Contains(capturedBody, expected) {
+			t.Errorf("expected '%s' to be in email body, but it wasn't found.\nActual body:\n%s", expected, capturedBody)
+		}
+	}
+}
+
 func TestNewSMTPEmailSender(t *testing.T) {
 	conf := SMTPConfiguration{
 		Sender:     "test@example.com",
@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"transaction-processor/internal/ports"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// JobMessage is the body of the queue messages of asynchronous jobs. Dead-lettered jobs also carry
+// the reason of their last failure and the number of attempts.
+type JobMessage struct {
+	JobID    string `json:"jobId"`
+	Reason   string `json:"reason,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
+}
+
+// ParseJobMessage returns the job ID of a queue message body
+func ParseJobMessage(body string) (string, error) {
+	var message JobMessage
+	if err := json.Unmarshal([]byte(body), &message); err != nil {
+		return "", fmt.Errorf("error decoding job message: %w", err)
+	}
+	if message.JobID == "" {
+		return "", fmt.Errorf("invalid job message, missing jobId")
+	}
+	return message.JobID, nil
+}
+
+// SQSJobQueue implements the JobQueue and JobDeadLetterQueue ports using an SQS queue
+type SQSJobQueue struct {
+	sqsClient ports.SQSClient
+	queueURL  string
+}
+
+// NewSQSJobQueue creates a new SQSJobQueue
+func NewSQSJobQueue(sqsClient *sqs.Client, queueURL string) *SQSJobQueue {
+	return &SQSJobQueue{
+		sqsClient: sqsClient,
+		queueURL:  queueURL,
+	}
+}
+
+// Enqueue sends a message with the job ID to the queue
+func (q *SQSJobQueue) Enqueue(ctx context.Context, jobID string) error {
+	return q.send(ctx, JobMessage{JobID: jobID})
+}
+
+// DeadLetter sends a message with the job ID, the reason of its last failure and the number of
+// attempts to the queue
+func (q *SQSJobQueue) DeadLetter(ctx context.Context, jobID, reason string, attempts int) error {
+	return q.send(ctx, JobMessage{JobID: jobID, Reason: reason, Attempts: attempts})
+}
+
+// send sends a job message to the queue
+func (q *SQSJobQueue) send(ctx context.Context, message JobMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("error encoding job message: %w", err)
+	}
+
+	_, err = q.sqsClient.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	if err != nil {
+		return fmt.Errorf("error sending job message to SQS: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transaction-processor/internal/mocks"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSQSJobQueue_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQS := mocks.NewMockSQSClient(ctrl)
+
+	queue := &SQSJobQueue{
+		sqsClient: mockSQS,
+		queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/jobs",
+	}
+
+	mockSQS.
+		EXPECT().
+		SendMessage(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/jobs", *input.QueueUrl)
+
+			jobID, err := ParseJobMessage(*input.MessageBody)
+			assert.NoError(t, err)
+			assert.Equal(t, "job1", jobID)
+			return &sqs.SendMessageOutput{}, nil
+		})
+
+	assert.NoError(t, queue.Enqueue(context.Background(), "job1"))
+
+	mockSQS.EXPECT().SendMessage(gomock.Any(), gomock.Any()).Return(nil, errors.New("SQS error"))
+	assert.Error(t, queue.Enqueue(context.Background(), "job2"))
+}
+
+func TestSQSJobQueue_DeadLetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQS := mocks.NewMockSQSClient(ctrl)
+
+	queue := &SQSJobQueue{
+		sqsClient: mockSQS,
+		queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/jobs-dlq",
+	}
+
+	mockSQS.
+		EXPECT().
+		SendMessage(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, input *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/123456789012/jobs-dlq", *input.QueueUrl)
+			assert.JSONEq(t, `{"jobId": "job1", "reason": "gave up after 3 attempts: invalid amount", "attempts": 3}`, *input.MessageBody)
+			return &sqs.SendMessageOutput{}, nil
+		})
+
+	assert.NoError(t, queue.DeadLetter(context.Background(), "job1", "gave up after 3 attempts: invalid amount", 3))
+}
+
+func TestParseJobMessage_Invalid(t *testing.T) {
+	_, err := ParseJobMessage(`{"jobId": ""}`)
+	assert.Error(t, err)
+
+	_, err = ParseJobMessage(`not json`)
+	assert.Error(t, err)
+}
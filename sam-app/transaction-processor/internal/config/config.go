@@ -13,7 +13,68 @@ type Configuration struct {
 	SmtpPort          int    `json:"smtpPort"`
 	TransactionsTable string `json:"transactionsTable"`
 	AccountsTable     string `json:"accountsTable"`
+	BudgetsTable      string `json:"budgetsTable"`
+	CustomersTable    string `json:"customersTable"`
 	AccountID         string `json:"accountID"`
+	CSVProfilesPath   string `json:"csvProfilesPath"`
+	MaxUploadSize     int64  `json:"maxUploadSize"`
+
+	// Secrets Manager secret holding the SMTP password, read instead of EmailPassword when set
+	EmailPasswordSecret string `json:"emailPasswordSecret"`
+
+	// Transactions table of the single-account layout, keyed by ID only, migrated to TransactionsTable
+	// by the migrate-transactions admin event
+	LegacyTransactionsTable string `json:"legacyTransactionsTable"`
+
+	// Number of transactions of a statement persisted concurrently
+	PersistenceWorkers int `json:"persistenceWorkers"`
+
+	// Checkpoints of the processing of large files, taken every CheckpointInterval transactions so
+	// a retry resumes after them. Disabled unless CheckpointsTable is configured.
+	CheckpointsTable   string `json:"checkpointsTable"`
+	CheckpointInterval int    `json:"checkpointInterval"`
+
+	// Reconciliation of the stored transactions with the file once they are persisted: "flag" records
+	// mismatches with the account, "fail" fails the run and "off" skips it
+	ReconciliationMode string `json:"reconciliationMode"`
+
+	// Presigned statement uploads, disabled unless the bucket and the uploads table are configured.
+	// S3Endpoint points the S3 client to an S3-compatible stand-in, such as MinIO, for local testing.
+	// Uploaded objects over MaxObjectSize bytes are failed without being downloaded.
+	UploadsBucket   string `json:"uploadsBucket"`
+	UploadsTable    string `json:"uploadsTable"`
+	S3Endpoint      string `json:"s3Endpoint"`
+	UploadURLExpiry int    `json:"uploadUrlExpiry"`
+	MaxObjectSize   int64  `json:"maxObjectSize"`
+
+	// Asynchronous processing. Jobs are queued to SQS when JobsQueueURL is set and to an in-memory
+	// queue consumed by the same process otherwise. Jobs failing JobMaxAttempts times are set aside
+	// in the dead-letter queue at JobsDeadLetterQueueURL. LambdaFunctionName is set by the Lambda
+	// runtime, which freezes the process between invocations, so the in-memory queue is refused there.
+	JobsTable              string `json:"jobsTable"`
+	JobsQueueURL           string `json:"jobsQueueUrl"`
+	JobsDeadLetterQueueURL string `json:"jobsDeadLetterQueueUrl"`
+	JobMaxAttempts         int    `json:"jobMaxAttempts"`
+	LambdaFunctionName     string `json:"lambdaFunctionName"`
+
+	// Scheduled monthly statements, checkpointed per period in StatementRunsTable
+	StatementRunsTable string `json:"statementRunsTable"`
+
+	// Outbox of the summary emails. When OutboxTable is set, processing runs write the emails to it and
+	// a dispatcher delivers them, attempting each up to OutboxMaxAttempts times. OutboxPollInterval, in
+	// seconds, sweeps the outbox from the same process for local development, where no schedule does.
+	OutboxTable        string `json:"outboxTable"`
+	OutboxMaxAttempts  int    `json:"outboxMaxAttempts"`
+	OutboxPollInterval int    `json:"outboxPollInterval"`
+
+	// Interest and fee engine settings, disabled when every rate and fee is zero
+	InterestRate             float64 `json:"interestRate"`
+	InterestRateType         string  `json:"interestRateType"`
+	InterestMethod           string  `json:"interestMethod"`
+	OverdraftFee             float64 `json:"overdraftFee"`
+	PerTransactionFee        float64 `json:"perTransactionFee"`
+	MonthlyMaintenanceFee    float64 `json:"monthlyMaintenanceFee"`
+	MaintenanceWaiverBalance float64 `json:"maintenanceWaiverBalance"`
 }
 
 // Load loads configuration from environment variables
@@ -27,13 +88,45 @@ func Load() Configuration {
 	}
 
 	config := Configuration{
-		EmailSender:       os.Getenv("EMAIL_SENDER"),
-		EmailPassword:     os.Getenv("EMAIL_PASSWORD"),
-		SmtpServer:        os.Getenv("SMTP_SERVER"),
-		SmtpPort:          smtpPort,
-		TransactionsTable: os.Getenv("TRANSACTIONS_TABLE"),
-		AccountsTable:     os.Getenv("ACCOUNTS_TABLE"),
-		AccountID:         os.Getenv("ACCOUNT_ID"),
+		EmailSender:             os.Getenv("EMAIL_SENDER"),
+		EmailPassword:           os.Getenv("EMAIL_PASSWORD"),
+		EmailPasswordSecret:     os.Getenv("EMAIL_PASSWORD_SECRET"),
+		SmtpServer:              os.Getenv("SMTP_SERVER"),
+		SmtpPort:                smtpPort,
+		TransactionsTable:       os.Getenv("TRANSACTIONS_TABLE"),
+		AccountsTable:           os.Getenv("ACCOUNTS_TABLE"),
+		BudgetsTable:            os.Getenv("BUDGETS_TABLE"),
+		CustomersTable:          os.Getenv("CUSTOMERS_TABLE"),
+		AccountID:               os.Getenv("ACCOUNT_ID"),
+		CSVProfilesPath:         os.Getenv("CSV_PROFILES_PATH"),
+		MaxUploadSize:           getEnvInt64("MAX_UPLOAD_SIZE"),
+		LegacyTransactionsTable: os.Getenv("LEGACY_TRANSACTIONS_TABLE"),
+		PersistenceWorkers:      int(getEnvInt64("PERSISTENCE_WORKERS")),
+		CheckpointsTable:        os.Getenv("CHECKPOINTS_TABLE"),
+		CheckpointInterval:      int(getEnvInt64("CHECKPOINT_INTERVAL")),
+		ReconciliationMode:      os.Getenv("RECONCILIATION_MODE"),
+		UploadsBucket:           os.Getenv("UPLOADS_BUCKET"),
+		UploadsTable:            os.Getenv("UPLOADS_TABLE"),
+		S3Endpoint:              os.Getenv("S3_ENDPOINT"),
+		UploadURLExpiry:         int(getEnvInt64("UPLOAD_URL_EXPIRY")),
+		MaxObjectSize:           getEnvInt64("MAX_OBJECT_SIZE"),
+		JobsTable:               os.Getenv("JOBS_TABLE"),
+		JobsQueueURL:            os.Getenv("JOBS_QUEUE_URL"),
+		JobsDeadLetterQueueURL:  os.Getenv("JOBS_DLQ_URL"),
+		JobMaxAttempts:          int(getEnvInt64("JOB_MAX_ATTEMPTS")),
+		LambdaFunctionName:      os.Getenv("AWS_LAMBDA_FUNCTION_NAME"),
+		StatementRunsTable:      os.Getenv("STATEMENT_RUNS_TABLE"),
+		OutboxTable:             os.Getenv("OUTBOX_TABLE"),
+		OutboxMaxAttempts:       int(getEnvInt64("OUTBOX_MAX_ATTEMPTS")),
+		OutboxPollInterval:      int(getEnvInt64("OUTBOX_POLL_INTERVAL")),
+
+		InterestRate:             getEnvFloat("INTEREST_RATE"),
+		InterestRateType:         os.Getenv("INTEREST_RATE_TYPE"),
+		InterestMethod:           os.Getenv("INTEREST_METHOD"),
+		OverdraftFee:             getEnvFloat("OVERDRAFT_FEE"),
+		PerTransactionFee:        getEnvFloat("PER_TRANSACTION_FEE"),
+		MonthlyMaintenanceFee:    getEnvFloat("MONTHLY_MAINTENANCE_FEE"),
+		MaintenanceWaiverBalance: getEnvFloat("MAINTENANCE_WAIVER_BALANCE"),
 	}
 
 	// If ACCOUNT_ID is not set, use a default value
@@ -41,10 +134,73 @@ func Load() Configuration {
 		config.AccountID = "default"
 	}
 
+	// CSV profiles are bundled with the function by default
+	if config.CSVProfilesPath == "" {
+		config.CSVProfilesPath = "csv_profiles.yaml"
+	}
+
+	// Lambda rejects synchronous request payloads over 6 MB, so uploads default to 5 MB
+	if config.MaxUploadSize <= 0 {
+		config.MaxUploadSize = 5 << 20
+	}
+
+	// Transactions are persisted by 8 concurrent workers by default
+	if config.PersistenceWorkers <= 0 {
+		config.PersistenceWorkers = 8
+	}
+
+	// Checkpoints are taken every 500 transactions by default
+	if config.CheckpointInterval <= 0 {
+		config.CheckpointInterval = 500
+	}
+
+	// Mismatches are flagged without failing the run by default
+	if config.ReconciliationMode == "" {
+		config.ReconciliationMode = "flag"
+	}
+
+	// Presigned upload URLs are valid for 15 minutes by default
+	if config.UploadURLExpiry <= 0 {
+		config.UploadURLExpiry = 900
+	}
+
+	// Uploaded objects are limited to 100 MB by default, well within the temporary storage of Lambda
+	if config.MaxObjectSize <= 0 {
+		config.MaxObjectSize = 100 << 20
+	}
+
+	// Jobs are attempted three times before they are dead-lettered by default
+	if config.JobMaxAttempts <= 0 {
+		config.JobMaxAttempts = 3
+	}
+
+	// Notifications are attempted five times before they are marked failed by default
+	if config.OutboxMaxAttempts <= 0 {
+		config.OutboxMaxAttempts = 5
+	}
+
 	// Set default SMTP server if not provided
 	if config.SmtpServer == "" {
 		config.SmtpServer = "smtp.gmail.com"
 	}
 
 	return config
-}
\ No newline at end of file
+}
+
+// getEnvFloat reads a float environment variable, returning 0 if it is not set or invalid
+func getEnvFloat(key string) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// getEnvInt64 reads an integer environment variable, returning 0 if it is not set or invalid
+func getEnvInt64(key string) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
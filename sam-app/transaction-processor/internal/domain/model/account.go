@@ -17,9 +17,10 @@ type MonthlyStats struct {
 
 // Account represents a financial account with transactions
 type Account struct {
-	Transactions []*Transaction
-	Balance      float64
-	MonthlyStats map[string]*MonthlyStats // key is "YYYY-MM"
+	Transactions     []*Transaction
+	Balance          float64                  // ledger balance, posted transactions only
+	AvailableBalance float64                  // ledger balance minus pending debits
+	MonthlyStats     map[string]*MonthlyStats // key is "YYYY-MM"
 }
 
 // NewAccount creates a new empty account
@@ -31,15 +32,50 @@ func NewAccount() *Account {
 	}
 }
 
+// AccountAggregates are the balances and monthly stats an account accumulated from its transactions
+type AccountAggregates struct {
+	Balance          float64
+	AvailableBalance float64
+	MonthlyStats     map[string]*MonthlyStats
+}
+
+// ResumeAccount creates an account holding transactions whose balances and stats were already
+// accumulated into the aggregates, so they are not added again
+func ResumeAccount(aggregates AccountAggregates, transactions []*Transaction) *Account {
+	account := NewAccount()
+	account.Transactions = append(account.Transactions, transactions...)
+	account.Balance = aggregates.Balance
+	account.AvailableBalance = aggregates.AvailableBalance
+	for key, stats := range aggregates.MonthlyStats {
+		copied := *stats
+		account.MonthlyStats[key] = &copied
+	}
+	return account
+}
+
+// Aggregates returns a copy of the balances and monthly stats of the account
+func (a *Account) Aggregates() AccountAggregates {
+	aggregates := AccountAggregates{
+		Balance:          a.Balance,
+		AvailableBalance: a.AvailableBalance,
+		MonthlyStats:     make(map[string]*MonthlyStats, len(a.MonthlyStats)),
+	}
+	for key, stats := range a.MonthlyStats {
+		copied := *stats
+		aggregates.MonthlyStats[key] = &copied
+	}
+	return aggregates
+}
+
 // AddTransaction adds a transaction to the account and updates the balance and stats
 func (a *Account) AddTransaction(tx *Transaction) {
 	a.Transactions = append(a.Transactions, tx)
+	a.updateBalances(tx)
 
-	// Update balance
-	if tx.IsCredit {
-		a.Balance += tx.Amount
-	} else {
-		a.Balance -= tx.Amount
+	// Generated interest and fees are reported separately from the statement stats,
+	// and only posted transactions are part of them
+	if tx.IsGenerated() || !tx.IsPosted() {
+		return
 	}
 
 	// Update monthly stats
@@ -64,11 +100,48 @@ func (a *Account) AddTransaction(tx *Transaction) {
 	}
 }
 
+// CarryForward updates the balances with a transaction from before the statement period, which is
+// neither listed nor part of the stats of the statement
+func (a *Account) CarryForward(tx *Transaction) {
+	a.updateBalances(tx)
+}
+
+// updateBalances updates the balances with a transaction. Pending debits are held from the available
+// balance until they post.
+func (a *Account) updateBalances(tx *Transaction) {
+	switch {
+	case tx.IsPosted() && tx.IsCredit:
+		a.Balance += tx.Amount
+		a.AvailableBalance += tx.Amount
+	case tx.IsPosted():
+		a.Balance -= tx.Amount
+		a.AvailableBalance -= tx.Amount
+	case tx.IsPending() && !tx.IsCredit:
+		a.AvailableBalance -= tx.Amount
+	}
+}
+
 // GetTotalBalance returns the current account balance
 func (a *Account) GetTotalBalance() float64 {
 	return a.Balance
 }
 
+// GetAvailableBalance returns the balance available to spend, which excludes pending debits
+func (a *Account) GetAvailableBalance() float64 {
+	return a.AvailableBalance
+}
+
+// GetPendingTransactions returns the transactions waiting for settlement
+func (a *Account) GetPendingTransactions() []*Transaction {
+	var pending []*Transaction
+	for _, tx := range a.Transactions {
+		if tx.IsPending() {
+			pending = append(pending, tx)
+		}
+	}
+	return pending
+}
+
 // GetMonthlyTransactionCounts returns a map of month names to transaction counts
 func (a *Account) GetMonthlyTransactionCounts() map[string]int {
 	result := make(map[string]int)
@@ -115,6 +188,39 @@ func (a *Account) GetAverageDebitAmount() float64 {
 	return totalDebit / float64(debitCount)
 }
 
+// GetGeneratedTransactions returns the interest and fee transactions generated for the account
+func (a *Account) GetGeneratedTransactions() []*Transaction {
+	var generated []*Transaction
+	for _, tx := range a.Transactions {
+		if tx.IsGenerated() {
+			generated = append(generated, tx)
+		}
+	}
+	return generated
+}
+
+// GetInterestEarned returns the total interest credited to the account
+func (a *Account) GetInterestEarned() float64 {
+	var total float64
+	for _, tx := range a.Transactions {
+		if tx.Kind == TransactionKindInterest {
+			total += tx.Amount
+		}
+	}
+	return total
+}
+
+// GetFeesCharged returns the total fees debited from the account
+func (a *Account) GetFeesCharged() float64 {
+	var total float64
+	for _, tx := range a.Transactions {
+		if tx.Kind == TransactionKindFee {
+			total += tx.Amount
+		}
+	}
+	return total
+}
+
 // formatMonthKey formats a date as "YYYY-MM" for use as a map key
 func formatMonthKey(date time.Time) string {
 	return date.Format("2006-01")
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultBudgetAlertThreshold is the fraction of the limit at which a budget is considered close to overspent
+const DefaultBudgetAlertThreshold = 0.8
+
+// BudgetState describes how the spending of a category compares to its budget
+type BudgetState string
+
+const (
+	BudgetStateOnTrack   BudgetState = "on_track"
+	BudgetStateNearLimit BudgetState = "near_limit"
+	BudgetStateOverspent BudgetState = "overspent"
+)
+
+// Budget represents a monthly spending limit for a category of an account
+type Budget struct {
+	AccountID      string
+	Category       string
+	MonthlyLimit   float64
+	AlertThreshold float64
+}
+
+// NewBudget creates a new Budget, using the default alert threshold when none is provided
+func NewBudget(accountID, category string, monthlyLimit, alertThreshold float64) *Budget {
+	if alertThreshold <= 0 {
+		alertThreshold = DefaultBudgetAlertThreshold
+	}
+
+	return &Budget{
+		AccountID:      accountID,
+		Category:       strings.TrimSpace(category),
+		MonthlyLimit:   monthlyLimit,
+		AlertThreshold: alertThreshold,
+	}
+}
+
+// NormalizeCategory returns the form categories are stored and matched by, regardless of case and
+// surrounding whitespace
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// Key returns the normalized category the budget is stored and matched by. Category keeps the name
+// it was set with, for display.
+func (b *Budget) Key() string {
+	return NormalizeCategory(b.Category)
+}
+
+// BudgetStatus represents the spending of a category against its budget for a month
+type BudgetStatus struct {
+	Category  string
+	Limit     float64
+	Spent     float64
+	Remaining float64
+	UsedRatio float64
+	State     BudgetState
+}
+
+// IsOverspent reports whether the spending exceeded the budget limit
+func (s BudgetStatus) IsOverspent() bool {
+	return s.State == BudgetStateOverspent
+}
+
+// IsNearLimit reports whether the spending reached the alert threshold without exceeding the limit
+func (s BudgetStatus) IsNearLimit() bool {
+	return s.State == BudgetStateNearLimit
+}
+
+// Evaluate computes the status of the budget for the given spending
+func (b *Budget) Evaluate(spent float64) BudgetStatus {
+	status := BudgetStatus{
+		Category:  b.Category,
+		Limit:     b.MonthlyLimit,
+		Spent:     spent,
+		Remaining: b.MonthlyLimit - spent,
+		State:     BudgetStateOnTrack,
+	}
+
+	if b.MonthlyLimit > 0 {
+		status.UsedRatio = spent / b.MonthlyLimit
+	}
+
+	switch {
+	case spent > b.MonthlyLimit:
+		status.State = BudgetStateOverspent
+	case b.MonthlyLimit > 0 && status.UsedRatio >= b.AlertThreshold:
+		status.State = BudgetStateNearLimit
+	}
+
+	return status
+}
+
+// GetLatestMonthKey returns the "YYYY-MM" key of the most recent month with transactions
+func (a *Account) GetLatestMonthKey() string {
+	var latest string
+	for monthKey := range a.MonthlyStats {
+		if monthKey > latest {
+			latest = monthKey
+		}
+	}
+	return latest
+}
+
+// GetSpendingByCategory returns the total posted and pending debit amount per category for a "YYYY-MM" month key
+func (a *Account) GetSpendingByCategory(monthKey string) map[string]float64 {
+	result := make(map[string]float64)
+
+	for _, tx := range a.Transactions {
+		if tx.IsCredit || !tx.IsActive() || formatMonthKey(tx.Date) != monthKey {
+			continue
+		}
+		result[NormalizeCategory(tx.GetCategory())] += tx.Amount
+	}
+
+	return result
+}
+
+// EvaluateBudgets evaluates the budgets against the spending of the most recent month.
+// Categories are matched case-insensitively and the result is sorted by category.
+func (a *Account) EvaluateBudgets(budgets []*Budget) []BudgetStatus {
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	spending := a.GetSpendingByCategory(a.GetLatestMonthKey())
+
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		statuses = append(statuses, budget.Evaluate(spending[budget.Key()]))
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Category < statuses[j].Category
+	})
+
+	return statuses
+}
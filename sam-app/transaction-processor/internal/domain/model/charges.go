@@ -0,0 +1,310 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// InterestMethod defines how interest accrues over the statement period
+type InterestMethod string
+
+const (
+	InterestMethodSimple   InterestMethod = "simple"
+	InterestMethodCompound InterestMethod = "compound"
+)
+
+// RateType defines whether the configured interest rate is nominal (APR) or effective (APY)
+type RateType string
+
+const (
+	RateTypeAPR RateType = "APR"
+	RateTypeAPY RateType = "APY"
+)
+
+// defaultDayCountBasis is the number of days per year used to derive daily rates
+const defaultDayCountBasis = 365
+
+// InterestConfig holds the parameters used to compute interest on positive daily balances
+type InterestConfig struct {
+	Rate          float64
+	RateType      RateType
+	Method        InterestMethod
+	DayCountBasis int
+}
+
+// FeeConfig holds the rule-based fees charged over a statement period
+type FeeConfig struct {
+	OverdraftFee             float64
+	PerTransactionFee        float64
+	MonthlyMaintenanceFee    float64
+	MaintenanceWaiverBalance float64
+}
+
+// ChargesConfig holds the interest and fee configuration of the charges engine
+type ChargesConfig struct {
+	Interest InterestConfig
+	Fees     FeeConfig
+}
+
+// IsEnabled reports whether any interest or fee is configured
+func (c ChargesConfig) IsEnabled() bool {
+	return c.Interest.Rate != 0 ||
+		c.Fees.OverdraftFee != 0 ||
+		c.Fees.PerTransactionFee != 0 ||
+		c.Fees.MonthlyMaintenanceFee != 0
+}
+
+// StatementPeriod represents the inclusive range of days covered by a statement
+type StatementPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewMonthlyStatementPeriod creates a period spanning a calendar month
+func NewMonthlyStatementPeriod(year int, month time.Month) StatementPeriod {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return StatementPeriod{Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+// PreviousMonthStatementPeriod creates a period spanning the calendar month before the given time
+func PreviousMonthStatementPeriod(at time.Time) StatementPeriod {
+	previous := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	return NewMonthlyStatementPeriod(previous.Year(), previous.Month())
+}
+
+// Key returns the "YYYY-MM" key of the month the period starts in
+func (p StatementPeriod) Key() string {
+	return formatMonthKey(p.Start)
+}
+
+// IsBefore reports whether a date is before the first day of the period
+func (p StatementPeriod) IsBefore(date time.Time) bool {
+	return date.Before(p.Start)
+}
+
+// IsAfter reports whether a date is after the last day of the period
+func (p StatementPeriod) IsAfter(date time.Time) bool {
+	return !date.Before(p.End.AddDate(0, 0, 1))
+}
+
+// NewStatementPeriodFromAccount creates a period spanning from the first day of the earliest month
+// to the last day of the latest month with transactions. It returns false when the account is empty.
+func NewStatementPeriodFromAccount(account *Account) (StatementPeriod, bool) {
+	var start, end time.Time
+	for _, stats := range account.MonthlyStats {
+		monthStart := time.Date(stats.Year, stats.Month, 1, 0, 0, 0, 0, time.UTC)
+		if start.IsZero() || monthStart.Before(start) {
+			start = monthStart
+		}
+		if monthStart.After(end) {
+			end = monthStart
+		}
+	}
+
+	if start.IsZero() {
+		return StatementPeriod{}, false
+	}
+
+	return StatementPeriod{Start: start, End: end.AddDate(0, 1, -1)}, true
+}
+
+// ChargesEngine computes interest and fees for an account over a statement period
+type ChargesEngine struct {
+	config ChargesConfig
+}
+
+// NewChargesEngine creates a new ChargesEngine
+func NewChargesEngine(config ChargesConfig) *ChargesEngine {
+	if config.Interest.DayCountBasis <= 0 {
+		config.Interest.DayCountBasis = defaultDayCountBasis
+	}
+	if config.Interest.Method == "" {
+		config.Interest.Method = InterestMethodSimple
+	}
+	if config.Interest.RateType == "" {
+		config.Interest.RateType = RateTypeAPR
+	}
+
+	return &ChargesEngine{
+		config: config,
+	}
+}
+
+// Apply computes the interest and fees of the period from the account's posted regular transactions
+// and adds them to the account as generated transactions, which are also returned.
+func (e *ChargesEngine) Apply(account *Account, period StatementPeriod) []*Transaction {
+	var regular []*Transaction
+	for _, tx := range account.Transactions {
+		if !tx.IsGenerated() && tx.IsPosted() {
+			regular = append(regular, tx)
+		}
+	}
+
+	sort.SliceStable(regular, func(i, j int) bool {
+		return regular[i].Date.Before(regular[j].Date)
+	})
+
+	var charges []*Transaction
+	charges = append(charges, e.computeFees(regular, period)...)
+	if interest := e.computeInterest(regular, period); interest != nil {
+		charges = append(charges, interest)
+	}
+
+	for _, charge := range charges {
+		account.AddTransaction(charge)
+	}
+
+	return charges
+}
+
+// computeInterest accrues interest on the positive end-of-day balances of the period
+func (e *ChargesEngine) computeInterest(transactions []*Transaction, period StatementPeriod) *Transaction {
+	if e.config.Interest.Rate <= 0 {
+		return nil
+	}
+
+	dailyRate := e.dailyRate()
+	compound := e.config.Interest.Method == InterestMethodCompound
+
+	var accrued float64
+	for _, day := range dailyBalances(transactions, period) {
+		base := day.balance
+		if compound {
+			base += accrued
+		}
+		if base > 0 {
+			accrued += base * dailyRate
+		}
+	}
+
+	amount := roundToCents(accrued)
+	if amount <= 0 {
+		return nil
+	}
+
+	return &Transaction{
+		ID:          fmt.Sprintf("interest-%s", period.End.Format("2006-01-02")),
+		Date:        period.End,
+		Amount:      amount,
+		IsCredit:    true,
+		Category:    "Interest",
+		Description: fmt.Sprintf("%s interest (%.2f%% %s)", e.config.Interest.Method, e.config.Interest.Rate*100, e.config.Interest.RateType),
+		Kind:        TransactionKindInterest,
+	}
+}
+
+// dailyRate converts the configured annual rate into a daily rate
+func (e *ChargesEngine) dailyRate() float64 {
+	basis := float64(e.config.Interest.DayCountBasis)
+	if e.config.Interest.RateType == RateTypeAPY {
+		return math.Pow(1+e.config.Interest.Rate, 1/basis) - 1
+	}
+	return e.config.Interest.Rate / basis
+}
+
+// computeFees applies the overdraft, per-transaction and monthly maintenance fee rules
+func (e *ChargesEngine) computeFees(transactions []*Transaction, period StatementPeriod) []*Transaction {
+	fees := e.config.Fees
+	var charges []*Transaction
+
+	var balance float64
+	for _, tx := range transactions {
+		if tx.Date.After(period.End) {
+			break
+		}
+
+		wasOverdrawn := balance < 0
+		balance += signedAmount(tx)
+		if tx.Date.Before(period.Start) || tx.IsCredit {
+			continue
+		}
+
+		if fees.PerTransactionFee > 0 {
+			charges = append(charges, newFee(fmt.Sprintf("fee-transaction-%s", tx.ID), period.End, fees.PerTransactionFee,
+				fmt.Sprintf("Transaction fee for %s", tx.ID)))
+		}
+
+		// The overdraft fee is charged each time a debit takes the balance below zero
+		if fees.OverdraftFee > 0 && balance < 0 && !wasOverdrawn {
+			charges = append(charges, newFee(fmt.Sprintf("fee-overdraft-%s", tx.ID), period.End, fees.OverdraftFee,
+				fmt.Sprintf("Overdraft fee for %s", tx.ID)))
+		}
+	}
+
+	if fees.MonthlyMaintenanceFee > 0 {
+		minimums := make(map[string]float64)
+		var months []string
+		for _, day := range dailyBalances(transactions, period) {
+			monthKey := formatMonthKey(day.date)
+			minimum, seen := minimums[monthKey]
+			if !seen {
+				months = append(months, monthKey)
+			}
+			if !seen || day.balance < minimum {
+				minimums[monthKey] = day.balance
+			}
+		}
+
+		for _, monthKey := range months {
+			if fees.MaintenanceWaiverBalance > 0 && minimums[monthKey] >= fees.MaintenanceWaiverBalance {
+				continue
+			}
+			charges = append(charges, newFee(fmt.Sprintf("fee-maintenance-%s", monthKey), period.End, fees.MonthlyMaintenanceFee,
+				fmt.Sprintf("Monthly maintenance fee for %s", monthKey)))
+		}
+	}
+
+	return charges
+}
+
+// dailyBalance is the end-of-day balance of a day in a statement period
+type dailyBalance struct {
+	date    time.Time
+	balance float64
+}
+
+// dailyBalances returns the end-of-day balance of every day in the period for date sorted transactions
+func dailyBalances(transactions []*Transaction, period StatementPeriod) []dailyBalance {
+	var balances []dailyBalance
+
+	var balance float64
+	next := 0
+	for day := period.Start; !day.After(period.End); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1)
+		for next < len(transactions) && transactions[next].Date.Before(endOfDay) {
+			balance += signedAmount(transactions[next])
+			next++
+		}
+		balances = append(balances, dailyBalance{date: day, balance: balance})
+	}
+
+	return balances
+}
+
+// newFee creates a generated fee transaction
+func newFee(id string, date time.Time, amount float64, description string) *Transaction {
+	return &Transaction{
+		ID:          id,
+		Date:        date,
+		Amount:      roundToCents(amount),
+		IsCredit:    false,
+		Category:    "Fees",
+		Description: description,
+		Kind:        TransactionKindFee,
+	}
+}
+
+// signedAmount returns the amount as a positive value for credits and a negative value for debits
+func signedAmount(tx *Transaction) float64 {
+	if tx.IsCredit {
+		return tx.Amount
+	}
+	return -tx.Amount
+}
+
+// roundToCents rounds an amount to two decimals
+func roundToCents(amount float64) float64 {
+	return float64(toCents(amount)) / 100
+}
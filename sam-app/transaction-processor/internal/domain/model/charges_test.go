@@ -0,0 +1,230 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// day returns midnight UTC of a day of 2025
+func day(month time.Month, d int) time.Time {
+	return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// chargeIDs returns the IDs of the charges, in order
+func chargeIDs(charges []*Transaction) []string {
+	var ids []string
+	for _, charge := range charges {
+		ids = append(ids, charge.ID)
+	}
+	return ids
+}
+
+func TestChargesEngine_DailyRate(t *testing.T) {
+	t.Run("APR is divided by the day count basis", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Interest: InterestConfig{Rate: 0.36, RateType: RateTypeAPR, DayCountBasis: 360}})
+		assert.InDelta(t, 0.001, engine.dailyRate(), 1e-12)
+	})
+
+	t.Run("APY compounds back to the annual rate over the basis", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Interest: InterestConfig{Rate: 0.10, RateType: RateTypeAPY}})
+		rate := engine.dailyRate()
+		assert.Less(t, rate, 0.10/365)
+		assert.InDelta(t, 0.10, math.Pow(1+rate, 365)-1, 1e-12)
+	})
+
+	t.Run("defaults to a simple APR over 365 days", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Interest: InterestConfig{Rate: 0.365}})
+		assert.Equal(t, InterestMethodSimple, engine.config.Interest.Method)
+		assert.Equal(t, RateTypeAPR, engine.config.Interest.RateType)
+		assert.InDelta(t, 0.001, engine.dailyRate(), 1e-12)
+	})
+}
+
+func TestChargesEngine_ComputeInterest(t *testing.T) {
+	period := StatementPeriod{Start: day(time.January, 1), End: day(time.January, 10)}
+	deposit := []*Transaction{{ID: "1", Date: day(time.January, 1), Amount: 1000, IsCredit: true}}
+
+	tests := []struct {
+		name         string
+		interest     InterestConfig
+		period       StatementPeriod
+		transactions []*Transaction
+		expected     float64
+	}{
+		{
+			name:         "simple accrual on the daily balance",
+			interest:     InterestConfig{Rate: 0.365, Method: InterestMethodSimple},
+			period:       period,
+			transactions: deposit,
+			expected:     10,
+		},
+		{
+			name:         "compound accrual on the balance and the interest accrued",
+			interest:     InterestConfig{Rate: 0.365, Method: InterestMethodCompound},
+			period:       period,
+			transactions: deposit,
+			expected:     10.05, // 1000 * (1.001^10 - 1)
+		},
+		{
+			name:         "compounded APY over a year yields the rate",
+			interest:     InterestConfig{Rate: 0.10, RateType: RateTypeAPY, Method: InterestMethodCompound},
+			period:       StatementPeriod{Start: day(time.January, 1), End: day(time.December, 31)},
+			transactions: deposit,
+			expected:     100,
+		},
+		{
+			name:     "negative balances accrue nothing",
+			interest: InterestConfig{Rate: 0.365},
+			period:   period,
+			transactions: []*Transaction{
+				{ID: "1", Date: day(time.January, 1), Amount: 1000, IsCredit: true},
+				{ID: "2", Date: day(time.January, 2), Amount: 1500},
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interest := NewChargesEngine(ChargesConfig{Interest: tt.interest}).computeInterest(tt.transactions, tt.period)
+			if interest == nil {
+				t.Fatal("Expected interest, got nil")
+			}
+			assert.Equal(t, tt.expected, interest.Amount)
+			assert.True(t, interest.IsCredit)
+			assert.Equal(t, TransactionKindInterest, interest.Kind)
+			assert.Equal(t, tt.period.End, interest.Date)
+		})
+	}
+
+	t.Run("no interest without a rate or a positive balance", func(t *testing.T) {
+		assert.Nil(t, NewChargesEngine(ChargesConfig{}).computeInterest(deposit, period))
+
+		overdrawn := []*Transaction{{ID: "1", Date: day(time.January, 1), Amount: 1000}}
+		assert.Nil(t, NewChargesEngine(ChargesConfig{Interest: InterestConfig{Rate: 0.365}}).computeInterest(overdrawn, period))
+	})
+}
+
+func TestChargesEngine_ComputeFees(t *testing.T) {
+	january := NewMonthlyStatementPeriod(2025, time.January)
+
+	t.Run("overdraft fee each time the balance goes below zero", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Fees: FeeConfig{OverdraftFee: 35}})
+
+		charges := engine.computeFees([]*Transaction{
+			{ID: "salary", Date: day(time.January, 1), Amount: 100, IsCredit: true},
+			{ID: "rent", Date: day(time.January, 2), Amount: 150},  // -50, overdrawn
+			{ID: "coffee", Date: day(time.January, 3), Amount: 10}, // -60, still overdrawn
+			{ID: "refund", Date: day(time.January, 4), Amount: 110, IsCredit: true},
+			{ID: "groceries", Date: day(time.January, 5), Amount: 80}, // -30, overdrawn again
+			{ID: "transfer", Date: day(time.January, 6), Amount: 30, IsCredit: true},
+			{ID: "snack", Date: day(time.January, 7), Amount: 1},   // -1 from exactly zero
+			{ID: "late", Date: day(time.February, 1), Amount: 500}, // after the period
+		}, january)
+
+		assert.Equal(t, []string{"fee-overdraft-rent", "fee-overdraft-groceries", "fee-overdraft-snack"}, chargeIDs(charges))
+		for _, charge := range charges {
+			assert.Equal(t, 35.0, charge.Amount)
+			assert.False(t, charge.IsCredit)
+			assert.Equal(t, TransactionKindFee, charge.Kind)
+			assert.Equal(t, january.End, charge.Date)
+		}
+	})
+
+	t.Run("no overdraft fee for a balance overdrawn before the period", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Fees: FeeConfig{OverdraftFee: 35}})
+
+		charges := engine.computeFees([]*Transaction{
+			{ID: "december", Date: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), Amount: 50},
+			{ID: "coffee", Date: day(time.January, 3), Amount: 10},
+		}, january)
+
+		assert.Empty(t, charges)
+	})
+
+	t.Run("per-transaction fee on the debits of the period", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Fees: FeeConfig{PerTransactionFee: 0.5}})
+
+		charges := engine.computeFees([]*Transaction{
+			{ID: "december", Date: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), Amount: 5},
+			{ID: "salary", Date: day(time.January, 1), Amount: 100, IsCredit: true},
+			{ID: "coffee", Date: day(time.January, 3), Amount: 10},
+			{ID: "lunch", Date: day(time.January, 4), Amount: 20},
+		}, january)
+
+		assert.Equal(t, []string{"fee-transaction-coffee", "fee-transaction-lunch"}, chargeIDs(charges))
+	})
+
+	t.Run("maintenance fee waived at the minimum balance", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Fees: FeeConfig{MonthlyMaintenanceFee: 5, MaintenanceWaiverBalance: 1000}})
+		period := StatementPeriod{Start: day(time.January, 1), End: day(time.March, 31)}
+
+		charges := engine.computeFees([]*Transaction{
+			// The balance stays at the waiver limit through January
+			{ID: "opening", Date: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), Amount: 1000, IsCredit: true},
+			// One cent below it for a day of February
+			{ID: "coffee", Date: day(time.February, 10), Amount: 0.01},
+			{ID: "refund", Date: day(time.February, 11), Amount: 0.01, IsCredit: true},
+			// Back above it through March
+			{ID: "salary", Date: day(time.February, 28), Amount: 500, IsCredit: true},
+		}, period)
+
+		assert.Equal(t, []string{"fee-maintenance-2025-02"}, chargeIDs(charges))
+		assert.Equal(t, 5.0, charges[0].Amount)
+	})
+
+	t.Run("maintenance fee every month without a waiver", func(t *testing.T) {
+		engine := NewChargesEngine(ChargesConfig{Fees: FeeConfig{MonthlyMaintenanceFee: 5}})
+		period := StatementPeriod{Start: day(time.January, 1), End: day(time.February, 28)}
+
+		charges := engine.computeFees([]*Transaction{
+			{ID: "opening", Date: day(time.January, 1), Amount: 100000, IsCredit: true},
+		}, period)
+
+		assert.Equal(t, []string{"fee-maintenance-2025-01", "fee-maintenance-2025-02"}, chargeIDs(charges))
+	})
+}
+
+func TestChargesEngine_Apply(t *testing.T) {
+	engine := NewChargesEngine(ChargesConfig{
+		Interest: InterestConfig{Rate: 0.365},
+		Fees:     FeeConfig{PerTransactionFee: 1},
+	})
+	period := StatementPeriod{Start: day(time.January, 1), End: day(time.January, 10)}
+
+	account := NewAccount()
+	// Transactions are charged in date order, whatever order they were added in
+	account.AddTransaction(&Transaction{ID: "coffee", Date: day(time.January, 6), Amount: 500})
+	account.AddTransaction(&Transaction{ID: "salary", Date: day(time.January, 1), Amount: 1000, IsCredit: true})
+	// Pending transactions and earlier charges are left out
+	account.AddTransaction(&Transaction{ID: "hold", Date: day(time.January, 2), Amount: 900, Status: TransactionStatusPending})
+	account.AddTransaction(newFee("fee-old", day(time.January, 2), 3, "Earlier fee"))
+
+	charges := engine.Apply(account, period)
+
+	// 1000 accrues 1.00 a day for five days, then 500 accrues 0.50 a day for five more
+	assert.Equal(t, []string{"fee-transaction-coffee", "interest-2025-01-10"}, chargeIDs(charges))
+	assert.Equal(t, 7.5, charges[1].Amount)
+
+	// The charges are added to the account
+	assert.Len(t, account.Transactions, 6)
+	assert.InDelta(t, 1000-500-3-1+7.5, account.GetTotalBalance(), 1e-9)
+	assert.Equal(t, 7.5, account.GetInterestEarned())
+	assert.Equal(t, 4.0, account.GetFeesCharged())
+}
+
+func TestNewStatementPeriodFromAccount(t *testing.T) {
+	account := NewAccount()
+	_, ok := NewStatementPeriodFromAccount(account)
+	assert.False(t, ok)
+
+	account.AddTransaction(&Transaction{ID: "1", Date: day(time.March, 15), Amount: 10, IsCredit: true})
+	account.AddTransaction(&Transaction{ID: "2", Date: day(time.January, 20), Amount: 5})
+
+	period, ok := NewStatementPeriodFromAccount(account)
+	assert.True(t, ok)
+	assert.Equal(t, StatementPeriod{Start: day(time.January, 1), End: day(time.March, 31)}, period)
+}
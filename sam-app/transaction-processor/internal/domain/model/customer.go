@@ -0,0 +1,27 @@
+package model
+
+// Customer represents the owner of one or more accounts
+type Customer struct {
+	ID         string
+	Name       string
+	Email      string
+	AccountIDs []string
+}
+
+// OwnsAccount reports whether the account belongs to the customer
+func (c *Customer) OwnsAccount(accountID string) bool {
+	for _, id := range c.AccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountOwner records who an account belongs to: the customer owning it, if known, and the address
+// its summaries were last sent to
+type AccountOwner struct {
+	AccountID  string
+	CustomerID string
+	Email      string
+}
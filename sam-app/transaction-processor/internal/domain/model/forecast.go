@@ -0,0 +1,137 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// forecastConfidenceZ is the z-score used for the forecast confidence band (95%)
+const forecastConfidenceZ = 1.96
+
+// Forecast represents the projected cash flow for the month following the account history
+type Forecast struct {
+	Month            time.Month
+	Year             int
+	OpeningBalance   float64
+	ProjectedCredit  float64
+	ProjectedDebit   float64
+	ProjectedBalance float64
+	LowerBound       float64
+	UpperBound       float64
+	MonthsOfHistory  int
+	RecurringItems   []RecurringItem
+}
+
+// IsProjectedNegative reports whether the projected closing balance is below zero
+func (f *Forecast) IsProjectedNegative() bool {
+	return f.ProjectedBalance < 0
+}
+
+// MayGoNegative reports whether the lower bound of the confidence band is below zero
+func (f *Forecast) MayGoNegative() bool {
+	return f.LowerBound < 0
+}
+
+// Forecast projects next month's closing balance from the monthly stats and recurring items.
+// Recurring items are projected at their exact amount, while the remaining cash flow is projected
+// as the average of the historical months with a confidence band based on its standard deviation.
+// It returns nil when the account has no history.
+func (a *Account) Forecast() *Forecast {
+	if len(a.MonthlyStats) == 0 {
+		return nil
+	}
+
+	// Generated interest and fees and unsettled transactions are not part of the statement history
+	var regular []*Transaction
+	for _, tx := range a.Transactions {
+		if !tx.IsGenerated() && tx.IsPosted() {
+			regular = append(regular, tx)
+		}
+	}
+
+	recurringItems := DetectRecurringItems(regular)
+	recurring := make(map[int64]map[bool]bool)
+	for _, item := range recurringItems {
+		cents := toCents(item.Amount)
+		if recurring[cents] == nil {
+			recurring[cents] = make(map[bool]bool)
+		}
+		recurring[cents][item.IsCredit] = true
+	}
+
+	// Split each month's cash flow into its non recurring credit and debit parts
+	nonRecurringCredit := make(map[string]float64)
+	nonRecurringDebit := make(map[string]float64)
+	for _, tx := range regular {
+		if recurring[toCents(tx.Amount)][tx.IsCredit] {
+			continue
+		}
+		monthKey := formatMonthKey(tx.Date)
+		if tx.IsCredit {
+			nonRecurringCredit[monthKey] += tx.Amount
+		} else {
+			nonRecurringDebit[monthKey] += tx.Amount
+		}
+	}
+
+	var latest time.Time
+	var creditSum, debitSum float64
+	nets := make([]float64, 0, len(a.MonthlyStats))
+	for monthKey, stats := range a.MonthlyStats {
+		monthStart := time.Date(stats.Year, stats.Month, 1, 0, 0, 0, 0, time.UTC)
+		if monthStart.After(latest) {
+			latest = monthStart
+		}
+		creditSum += nonRecurringCredit[monthKey]
+		debitSum += nonRecurringDebit[monthKey]
+		nets = append(nets, nonRecurringCredit[monthKey]-nonRecurringDebit[monthKey])
+	}
+
+	months := float64(len(a.MonthlyStats))
+	projectedCredit := creditSum / months
+	projectedDebit := debitSum / months
+	for _, item := range recurringItems {
+		if item.IsCredit {
+			projectedCredit += item.Amount
+		} else {
+			projectedDebit += item.Amount
+		}
+	}
+
+	projectedBalance := a.Balance + projectedCredit - projectedDebit
+	margin := forecastConfidenceZ * standardDeviation(nets)
+	next := latest.AddDate(0, 1, 0)
+
+	return &Forecast{
+		Month:            next.Month(),
+		Year:             next.Year(),
+		OpeningBalance:   a.Balance,
+		ProjectedCredit:  projectedCredit,
+		ProjectedDebit:   projectedDebit,
+		ProjectedBalance: projectedBalance,
+		LowerBound:       projectedBalance - margin,
+		UpperBound:       projectedBalance + margin,
+		MonthsOfHistory:  len(a.MonthlyStats),
+		RecurringItems:   recurringItems,
+	}
+}
+
+// standardDeviation returns the sample standard deviation of values, or 0 with fewer than two values
+func standardDeviation(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(squares / float64(len(values)-1))
+}
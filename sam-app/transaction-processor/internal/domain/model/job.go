@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+)
+
+// JobStatus represents the processing state of an asynchronous job
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Job represents the asynchronous processing of a statement file for an account. It records who the
+// statement is processed for, how many of its transactions have been processed and the outcome.
+type Job struct {
+	ID         string
+	AccountID  string
+	CustomerID string
+	Email      string
+	CSVProfile string
+	Source     string
+	Status     JobStatus
+	Total      int
+	Processed  int
+	Attempts   int
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewJob creates a queued Job processing a statement file for an account
+func NewJob(id, accountID, source string, createdAt time.Time) *Job {
+	return &Job{
+		ID:        id,
+		AccountID: accountID,
+		Source:    source,
+		Status:    JobStatusQueued,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+// IsFinished reports whether the job succeeded or failed
+func (j *Job) IsFinished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
+
+// Start records that the job is running its given attempt. The error of the previous attempt, if
+// any, is kept until the attempt finishes.
+func (j *Job) Start(attempt int, at time.Time) {
+	j.Status = JobStatusRunning
+	j.Attempts = attempt
+	j.UpdatedAt = at
+}
+
+// Progress records how many of the transactions of the statement have been processed
+func (j *Job) Progress(processed, total int, at time.Time) {
+	j.Processed = processed
+	j.Total = total
+	j.UpdatedAt = at
+}
+
+// Succeed records that the job processed its statement
+func (j *Job) Succeed(at time.Time) {
+	j.Status = JobStatusSucceeded
+	j.Error = ""
+	j.UpdatedAt = at
+}
+
+// Retry records that an attempt of the job failed and that it is queued to be attempted again
+func (j *Job) Retry(err error, at time.Time) {
+	j.Status = JobStatusQueued
+	j.Error = err.Error()
+	j.UpdatedAt = at
+}
+
+// Fail records that the job could not process its statement and why
+func (j *Job) Fail(err error, at time.Time) {
+	j.Status = JobStatusFailed
+	j.Error = err.Error()
+	j.UpdatedAt = at
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotificationChanged is returned when saving a notification that was changed since it was read,
+// typically because another dispatcher claimed it first
+var ErrNotificationChanged = errors.New("notification was changed since it was read")
+
+// NotificationKind identifies the email a notification is delivered as
+type NotificationKind string
+
+const (
+	NotificationKindSummary             NotificationKind = "summary"
+	NotificationKindConsolidatedSummary NotificationKind = "consolidated-summary"
+)
+
+// NotificationStatus represents the delivery state of a notification
+type NotificationStatus string
+
+const (
+	NotificationStatusPending   NotificationStatus = "pending"
+	NotificationStatusDelivered NotificationStatus = "delivered"
+	NotificationStatusFailed    NotificationStatus = "failed"
+)
+
+// Notification is an email written to the outbox by a processing run, to be delivered by a dispatcher
+// apart from the run. The payload holds the summary the email is rendered from, encoded as JSON.
+// Version counts the saves of the notification, so concurrent dispatchers cannot both claim it.
+type Notification struct {
+	ID            string
+	Kind          NotificationKind
+	Recipient     string
+	Payload       []byte
+	Status        NotificationStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	Error         string
+	Version       int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewNotification creates a pending Notification, due as soon as it is created
+func NewNotification(id string, kind NotificationKind, recipient string, payload []byte, createdAt time.Time) *Notification {
+	return &Notification{
+		ID:            id,
+		Kind:          kind,
+		Recipient:     recipient,
+		Payload:       payload,
+		Status:        NotificationStatusPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+}
+
+// IsDue reports whether the notification is pending and its next attempt is not after at
+func (n *Notification) IsDue(at time.Time) bool {
+	return n.Status == NotificationStatusPending && !n.NextAttemptAt.After(at)
+}
+
+// Claim records a new delivery attempt and holds the notification back until the lease ends, so it is
+// attempted again if the attempt is interrupted before its outcome is recorded
+func (n *Notification) Claim(at time.Time, lease time.Duration) {
+	n.Attempts++
+	n.NextAttemptAt = at.Add(lease)
+	n.UpdatedAt = at
+}
+
+// Deliver records that the notification was delivered
+func (n *Notification) Deliver(at time.Time) {
+	n.Status = NotificationStatusDelivered
+	n.Error = ""
+	n.UpdatedAt = at
+}
+
+// Fail records that the last attempt failed. The notification is attempted again after a backoff
+// doubling with each attempt, or failed for good once maxAttempts were made.
+func (n *Notification) Fail(err error, at time.Time, maxAttempts int, backoff time.Duration) {
+	n.Error = err.Error()
+	n.UpdatedAt = at
+	if n.Attempts >= maxAttempts {
+		n.Status = NotificationStatusFailed
+		return
+	}
+	n.NextAttemptAt = at.Add(backoff << (n.Attempts - 1))
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+// ProcessingCheckpoint records how far the processing of a statement file for an account got. The
+// transactions before Row, in file order, are persisted and accumulated into the aggregates, so an
+// interrupted run resumes after them and builds the same account as a run from the first row.
+type ProcessingCheckpoint struct {
+	AccountID   string
+	Fingerprint string // SHA-256 of the file contents
+	Row         int
+	Persisted   []int // rows after Row persisted ahead of an earlier row
+	Aggregates  AccountAggregates
+	UpdatedAt   time.Time
+}
+
+// NewProcessingCheckpoint creates a checkpoint at the first row of the file with the given fingerprint
+func NewProcessingCheckpoint(accountID, fingerprint string, at time.Time) *ProcessingCheckpoint {
+	return &ProcessingCheckpoint{
+		AccountID:   accountID,
+		Fingerprint: fingerprint,
+		Aggregates:  NewAccount().Aggregates(),
+		UpdatedAt:   at,
+	}
+}
+
+// Advance moves the checkpoint to the given row, with the account built from the rows before it
+// and the later rows already persisted
+func (c *ProcessingCheckpoint) Advance(row int, account *Account, persisted []int, at time.Time) {
+	c.Row = row
+	c.Aggregates = account.Aggregates()
+	c.Persisted = persisted
+	c.UpdatedAt = at
+}
+
+// IsPersisted reports whether a row was persisted before the checkpoint was taken
+func (c *ProcessingCheckpoint) IsPersisted(row int) bool {
+	if row < c.Row {
+		return true
+	}
+	for _, persisted := range c.Persisted {
+		if persisted == row {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReconciliationMismatch is returned when the stored transactions of a statement do not match the
+// statement file or the totals it declares
+var ErrReconciliationMismatch = errors.New("statement does not reconcile")
+
+// ReconciliationTotals are the number of transactions, the credit and debit totals and the ledger
+// balance of a set of transactions
+type ReconciliationTotals struct {
+	Count       int
+	TotalCredit float64
+	TotalDebit  float64
+	Balance     float64
+}
+
+// Reconciliation compares the transactions of a statement file with the stored versions of them, the
+// control totals the file declares and its declared closing balance. Every difference is a mismatch.
+type Reconciliation struct {
+	File       ReconciliationTotals
+	Persisted  ReconciliationTotals
+	Mismatches []string
+}
+
+// IsReconciled reports whether the statement reconciles
+func (r Reconciliation) IsReconciled() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Err returns an ErrReconciliationMismatch describing the mismatches, or nil when the statement reconciles
+func (r Reconciliation) Err() error {
+	if r.IsReconciled() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrReconciliationMismatch, strings.Join(r.Mismatches, "; "))
+}
+
+// Reconcile reconciles a statement with the stored transactions of its account. A transaction repeated
+// in the file, such as a pending transaction and its settlement, is stored once in its last state, so
+// the file is compared with the last state of each of its transactions. Control totals count every
+// record of the file and are checked for the statement and each of its parts. Stored transactions that
+// are not in the file, such as generated charges or those of other files, are ignored.
+func Reconcile(statement *Statement, persisted []*Transaction) Reconciliation {
+	var reconciliation Reconciliation
+
+	// The last state of each transaction of the file, in file order
+	latest := make(map[string]*Transaction)
+	var ids []string
+	for _, tx := range statement.Transactions {
+		if _, ok := latest[tx.ID]; !ok {
+			ids = append(ids, tx.ID)
+		}
+		latest[tx.ID] = tx
+	}
+	fileTransactions := make([]*Transaction, 0, len(ids))
+	for _, id := range ids {
+		fileTransactions = append(fileTransactions, latest[id])
+	}
+	reconciliation.File = reconciliationTotals(fileTransactions)
+
+	stored := make(map[string]bool)
+	var persistedTransactions []*Transaction
+	for _, tx := range persisted {
+		if _, ok := latest[tx.ID]; ok && !tx.IsGenerated() {
+			stored[tx.ID] = true
+			persistedTransactions = append(persistedTransactions, tx)
+		}
+	}
+	reconciliation.Persisted = reconciliationTotals(persistedTransactions)
+
+	mismatch := func(format string, args ...interface{}) {
+		reconciliation.Mismatches = append(reconciliation.Mismatches, fmt.Sprintf(format, args...))
+	}
+
+	file, saved := reconciliation.File, reconciliation.Persisted
+	if file.Count != saved.Count {
+		var missing []string
+		for _, id := range ids {
+			if !stored[id] {
+				missing = append(missing, id)
+			}
+		}
+		mismatch("file has %d transactions, %d were persisted (missing %s)", file.Count, saved.Count, strings.Join(missing, ", "))
+	}
+	if toCents(file.TotalCredit) != toCents(saved.TotalCredit) {
+		mismatch("file credits total %.2f, persisted credits total %.2f", file.TotalCredit, saved.TotalCredit)
+	}
+	if toCents(file.TotalDebit) != toCents(saved.TotalDebit) {
+		mismatch("file debits total %.2f, persisted debits total %.2f", file.TotalDebit, saved.TotalDebit)
+	}
+	if toCents(file.Balance) != toCents(saved.Balance) {
+		mismatch("file balance is %.2f, persisted balance is %.2f", file.Balance, saved.Balance)
+	}
+
+	// Control totals are declared for the records of the file, repeated transactions included
+	for _, part := range append([]*Statement{statement}, statement.Parts...) {
+		if part.ControlTotals == nil {
+			continue
+		}
+
+		prefix := "control totals"
+		if part.Source != "" {
+			prefix = fmt.Sprintf("control totals of %s", part.Source)
+		}
+
+		records := reconciliationTotals(part.Transactions)
+		control := part.ControlTotals
+		if control.Count != records.Count {
+			mismatch("%s declare %d transactions, file has %d", prefix, control.Count, records.Count)
+		}
+		if toCents(control.TotalCredit) != toCents(records.TotalCredit) {
+			mismatch("%s declare credits of %.2f, file credits total %.2f", prefix, control.TotalCredit, records.TotalCredit)
+		}
+		if toCents(control.TotalDebit) != toCents(records.TotalDebit) {
+			mismatch("%s declare debits of %.2f, file debits total %.2f", prefix, control.TotalDebit, records.TotalDebit)
+		}
+	}
+
+	if statement.OpeningBalance != nil && statement.ClosingBalance != nil {
+		computed := statement.OpeningBalance.Amount + saved.Balance
+		if toCents(computed) != toCents(statement.ClosingBalance.Amount) {
+			mismatch("declared closing balance is %.2f, opening balance plus persisted transactions gives %.2f",
+				statement.ClosingBalance.Amount, computed)
+		}
+	}
+
+	return reconciliation
+}
+
+// reconciliationTotals adds up a set of transactions in cents, so amounts stored rounded to cents
+// reconcile with those read from the file. Like the ledger balance, the balance only counts posted
+// transactions.
+func reconciliationTotals(transactions []*Transaction) ReconciliationTotals {
+	var credit, debit, balance int64
+	for _, tx := range transactions {
+		amount := toCents(tx.Amount)
+		if tx.IsCredit {
+			credit += amount
+		} else {
+			debit += amount
+			amount = -amount
+		}
+		if tx.IsPosted() {
+			balance += amount
+		}
+	}
+
+	return ReconciliationTotals{
+		Count:       len(transactions),
+		TotalCredit: float64(credit) / 100,
+		TotalDebit:  float64(debit) / 100,
+		Balance:     float64(balance) / 100,
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"math"
+	"sort"
+)
+
+// minRecurringMonths is the number of distinct months an item must appear in to be considered recurring
+const minRecurringMonths = 2
+
+// RecurringItem represents a transaction that repeats with the same amount and direction every month
+type RecurringItem struct {
+	Amount      float64
+	IsCredit    bool
+	DayOfMonth  int
+	Occurrences int
+}
+
+// SignedAmount returns the amount as a positive value for credits and a negative value for debits
+func (r RecurringItem) SignedAmount() float64 {
+	if r.IsCredit {
+		return r.Amount
+	}
+	return -r.Amount
+}
+
+// DetectRecurringItems finds transactions that repeat with the same amount and direction
+// in at least two different months. The expected day of month is the median of the observed days.
+func DetectRecurringItems(transactions []*Transaction) []RecurringItem {
+	type recurringKey struct {
+		cents    int64
+		isCredit bool
+	}
+
+	months := make(map[recurringKey]map[string]bool)
+	days := make(map[recurringKey][]int)
+	for _, tx := range transactions {
+		key := recurringKey{cents: toCents(tx.Amount), isCredit: tx.IsCredit}
+		if months[key] == nil {
+			months[key] = make(map[string]bool)
+		}
+		months[key][formatMonthKey(tx.Date)] = true
+		days[key] = append(days[key], tx.Date.Day())
+	}
+
+	var items []RecurringItem
+	for key, seen := range months {
+		if len(seen) < minRecurringMonths {
+			continue
+		}
+		observed := days[key]
+		sort.Ints(observed)
+		items = append(items, RecurringItem{
+			Amount:      float64(key.cents) / 100,
+			IsCredit:    key.isCredit,
+			DayOfMonth:  observed[len(observed)/2],
+			Occurrences: len(observed),
+		})
+	}
+
+	// Keep the output deterministic regardless of map iteration order
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].DayOfMonth != items[j].DayOfMonth {
+			return items[i].DayOfMonth < items[j].DayOfMonth
+		}
+		if items[i].IsCredit != items[j].IsCredit {
+			return items[i].IsCredit
+		}
+		return items[i].Amount < items[j].Amount
+	})
+
+	return items
+}
+
+// toCents converts an amount to an integer number of cents
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeclaredBalance is a balance stated by the bank in a statement file
+type DeclaredBalance struct {
+	Amount float64
+	Date   time.Time
+}
+
+// ControlTotals are the totals a statement file declares for its transactions, such as a trailer row
+// or a transactions summary, so that readers of the file can check nothing was lost
+type ControlTotals struct {
+	Count       int
+	TotalCredit float64
+	TotalDebit  float64
+}
+
+// Statement represents the content of a bank statement file: its transactions
+// and, for formats that declare them, the account identifier, balances and control totals.
+// Statements read from an archive hold the statement of each file of the archive as parts.
+// Statements read with lenient balances hold the balance mismatches of the statements they were
+// merged from instead of failing.
+type Statement struct {
+	Source            string
+	AccountID         string
+	Currency          string
+	Transactions      []*Transaction
+	OpeningBalance    *DeclaredBalance
+	ClosingBalance    *DeclaredBalance
+	ControlTotals     *ControlTotals
+	Parts             []*Statement
+	BalanceMismatches []string
+}
+
+// ErrBalanceMismatch is returned when the declared balances of a statement do not match its transactions
+var ErrBalanceMismatch = errors.New("statement balances do not match its transactions")
+
+// ErrStatementMismatch is returned when merging statements of different accounts or currencies
+var ErrStatementMismatch = errors.New("statements belong to different accounts or currencies")
+
+// ValidateBalances checks that the declared closing balance equals the declared opening balance plus
+// the balance the account computes from the statement transactions. Statements that do not declare
+// both balances are considered valid.
+func (s *Statement) ValidateBalances() error {
+	if s.OpeningBalance == nil || s.ClosingBalance == nil {
+		return nil
+	}
+
+	account := NewAccount()
+	for _, tx := range s.Transactions {
+		account.AddTransaction(tx)
+	}
+
+	computed := s.OpeningBalance.Amount + account.GetTotalBalance()
+	if toCents(computed) != toCents(s.ClosingBalance.Amount) {
+		return fmt.Errorf("%w: opening balance %.2f plus transactions gives %.2f, declared closing balance is %.2f",
+			ErrBalanceMismatch, s.OpeningBalance.Amount, computed, s.ClosingBalance.Amount)
+	}
+
+	return nil
+}
+
+// MergeStatements merges consecutive statements of the same account into one, keeping the opening
+// balance of the first statement and the closing balance of the last one. Control totals are added
+// up when every statement declares them. Statements of another account or currency than the first
+// one, when they declare them, cannot be merged and return ErrStatementMismatch.
+func MergeStatements(statements []*Statement) (*Statement, error) {
+	merged := &Statement{}
+	for i, statement := range statements {
+		if i == 0 {
+			merged.AccountID = statement.AccountID
+			merged.Currency = statement.Currency
+			merged.OpeningBalance = statement.OpeningBalance
+			if statement.ControlTotals != nil {
+				merged.ControlTotals = &ControlTotals{}
+			}
+		}
+		if mismatch(merged.AccountID, statement.AccountID) || mismatch(merged.Currency, statement.Currency) {
+			return nil, fmt.Errorf("%w: statement %d is for account %s in %s, expected account %s in %s",
+				ErrStatementMismatch, i+1, statement.AccountID, statement.Currency, merged.AccountID, merged.Currency)
+		}
+		if merged.AccountID == "" {
+			merged.AccountID = statement.AccountID
+		}
+		if merged.Currency == "" {
+			merged.Currency = statement.Currency
+		}
+
+		merged.Transactions = append(merged.Transactions, statement.Transactions...)
+		merged.ClosingBalance = statement.ClosingBalance
+		merged.BalanceMismatches = append(merged.BalanceMismatches, statement.BalanceMismatches...)
+
+		switch {
+		case merged.ControlTotals == nil:
+		case statement.ControlTotals == nil:
+			merged.ControlTotals = nil
+		default:
+			merged.ControlTotals.Count += statement.ControlTotals.Count
+			merged.ControlTotals.TotalCredit += statement.ControlTotals.TotalCredit
+			merged.ControlTotals.TotalDebit += statement.ControlTotals.TotalDebit
+		}
+	}
+	return merged, nil
+}
+
+// mismatch reports whether two declared identifiers differ, ignoring identifiers that are not declared
+func mismatch(a, b string) bool {
+	return a != "" && b != "" && a != b
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"time"
+)
+
+// StatementRunStatus represents the state of the scheduled run sending the statements of a period
+type StatementRunStatus string
+
+const (
+	StatementRunStatusRunning   StatementRunStatus = "running"
+	StatementRunStatusCompleted StatementRunStatus = "completed"
+)
+
+// StatementRun is the checkpoint of the scheduled run sending the monthly statements of a period to
+// every account. The cursor is the last account whose statement was handled, so an interrupted run
+// resumes after it instead of sending the statements again. Accounts whose statement failed are kept
+// in FailedAccounts until a retry handles them, and Failed counts them.
+type StatementRun struct {
+	Period         string
+	Status         StatementRunStatus
+	Cursor         string
+	Sent           int
+	Skipped        int
+	Failed         int
+	FailedAccounts []string
+	Error          string
+	StartedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewStatementRun creates a running StatementRun for the period with the given "YYYY-MM" key
+func NewStatementRun(period string, startedAt time.Time) *StatementRun {
+	return &StatementRun{
+		Period:    period,
+		Status:    StatementRunStatusRunning,
+		StartedAt: startedAt,
+		UpdatedAt: startedAt,
+	}
+}
+
+// IsCompleted reports whether the statements of every account have been handled
+func (r *StatementRun) IsCompleted() bool {
+	return r.Status == StatementRunStatusCompleted
+}
+
+// HasFailed reports whether the statement of an account failed and was not retried successfully yet
+func (r *StatementRun) HasFailed(accountID string) bool {
+	for _, id := range r.FailedAccounts {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkSent records that the statement of an account was sent, clearing an earlier failure of it
+func (r *StatementRun) MarkSent(accountID string, at time.Time) {
+	r.Sent++
+	r.checkpoint(accountID, at)
+	r.clearFailure(accountID)
+}
+
+// MarkSkipped records that an account has no statement to send, clearing an earlier failure of it
+func (r *StatementRun) MarkSkipped(accountID string, at time.Time) {
+	r.Skipped++
+	r.checkpoint(accountID, at)
+	r.clearFailure(accountID)
+}
+
+// MarkFailed records that the statement of an account could not be sent and why, keeping the account
+// to be retried
+func (r *StatementRun) MarkFailed(accountID string, err error, at time.Time) {
+	r.Error = err.Error()
+	r.checkpoint(accountID, at)
+	if !r.HasFailed(accountID) {
+		r.Failed++
+		r.FailedAccounts = append(r.FailedAccounts, accountID)
+	}
+}
+
+// Complete records that the statements of every account have been handled
+func (r *StatementRun) Complete(at time.Time) {
+	r.Status = StatementRunStatusCompleted
+	r.UpdatedAt = at
+}
+
+// checkpoint moves the cursor past an account. Retried accounts were already passed, so they leave
+// the cursor where it is.
+func (r *StatementRun) checkpoint(accountID string, at time.Time) {
+	if !r.HasFailed(accountID) {
+		r.Cursor = accountID
+	}
+	r.UpdatedAt = at
+}
+
+// clearFailure forgets the failure of an account once it was retried successfully
+func (r *StatementRun) clearFailure(accountID string) {
+	for i, id := range r.FailedAccounts {
+		if id == accountID {
+			r.FailedAccounts = append(r.FailedAccounts[:i:i], r.FailedAccounts[i+1:]...)
+			r.Failed--
+			break
+		}
+	}
+	if len(r.FailedAccounts) == 0 {
+		r.Error = ""
+	}
+}
@@ -7,12 +7,46 @@ import (
 	"time"
 )
 
+// UncategorizedCategory is the category assigned to transactions without an explicit category
+const UncategorizedCategory = "Uncategorized"
+
+// TransactionKind distinguishes transactions read from a statement from the ones generated by the account
+type TransactionKind string
+
+const (
+	TransactionKindRegular  TransactionKind = ""
+	TransactionKindInterest TransactionKind = "interest"
+	TransactionKindFee      TransactionKind = "fee"
+)
+
 // Transaction represents a financial transaction
 type Transaction struct {
-	ID       string
-	Date     time.Time
-	Amount   float64
-	IsCredit bool
+	ID            string
+	AccountID     string
+	Date          time.Time
+	ValueDate     time.Time // date the funds are available, when declared by the bank
+	Amount        float64
+	IsCredit      bool
+	Category      string
+	Description   string
+	Type          string // transaction type code declared by the bank, e.g. OFX TRNTYPE
+	Reference     string // bank reference of the transaction
+	Kind          TransactionKind
+	Status        TransactionStatus
+	StatusHistory []StatusChange
+}
+
+// IsGenerated reports whether the transaction was generated by the interest and fee engine
+func (t *Transaction) IsGenerated() bool {
+	return t.Kind != TransactionKindRegular
+}
+
+// GetCategory returns the transaction category, or UncategorizedCategory when none was set
+func (t *Transaction) GetCategory() string {
+	if t.Category == "" {
+		return UncategorizedCategory
+	}
+	return t.Category
 }
 
 // NewTransaction creates a new Transaction from raw data
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TransactionStatus represents the settlement state of a transaction
+type TransactionStatus string
+
+const (
+	TransactionStatusPending  TransactionStatus = "pending"
+	TransactionStatusPosted   TransactionStatus = "posted"
+	TransactionStatusDeclined TransactionStatus = "declined"
+	TransactionStatusVoided   TransactionStatus = "voided"
+)
+
+// allowedStatusTransitions lists the statuses each status can move to
+var allowedStatusTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending: {TransactionStatusPosted, TransactionStatusDeclined, TransactionStatusVoided},
+}
+
+// StatusChange records a status a transaction entered and when
+type StatusChange struct {
+	Status    TransactionStatus
+	Timestamp time.Time
+}
+
+// ParseTransactionStatus parses a status name case-insensitively, defaulting to posted when empty
+func ParseTransactionStatus(value string) (TransactionStatus, error) {
+	status := TransactionStatus(strings.ToLower(strings.TrimSpace(value)))
+	switch status {
+	case "":
+		return TransactionStatusPosted, nil
+	case TransactionStatusPending, TransactionStatusPosted, TransactionStatusDeclined, TransactionStatusVoided:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid transaction status: %s", value)
+	}
+}
+
+// GetStatus returns the transaction status, transactions without an explicit status are posted
+func (t *Transaction) GetStatus() TransactionStatus {
+	if t.Status == "" {
+		return TransactionStatusPosted
+	}
+	return t.Status
+}
+
+// IsPosted reports whether the transaction is settled and part of the ledger balance
+func (t *Transaction) IsPosted() bool {
+	return t.GetStatus() == TransactionStatusPosted
+}
+
+// IsPending reports whether the transaction is an authorization waiting for settlement
+func (t *Transaction) IsPending() bool {
+	return t.GetStatus() == TransactionStatusPending
+}
+
+// IsActive reports whether the transaction is posted or pending, as opposed to declined or voided
+func (t *Transaction) IsActive() bool {
+	return t.IsPosted() || t.IsPending()
+}
+
+// InitStatusHistory records the current status as the first entry of the history if it is empty
+func (t *Transaction) InitStatusHistory(at time.Time) {
+	if len(t.StatusHistory) == 0 {
+		t.StatusHistory = []StatusChange{{Status: t.GetStatus(), Timestamp: at}}
+	}
+}
+
+// TransitionTo moves the transaction to a new status and records the change in its history.
+// Moving to the current status is a no-op.
+func (t *Transaction) TransitionTo(status TransactionStatus, at time.Time) error {
+	current := t.GetStatus()
+	if status == current {
+		return nil
+	}
+
+	allowed := false
+	for _, next := range allowedStatusTransitions[current] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("invalid status transition for transaction %s: %s to %s", t.ID, current, status)
+	}
+
+	t.InitStatusHistory(at)
+	t.Status = status
+	t.StatusHistory = append(t.StatusHistory, StatusChange{Status: status, Timestamp: at})
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// uploadKeyPrefix is the prefix of the object keys of uploaded statements
+const uploadKeyPrefix = "uploads/"
+
+// ErrUploadClaimed is returned when claiming an upload that is no longer pending, typically because a
+// repeated storage notification claimed it first
+var ErrUploadClaimed = errors.New("upload was already claimed")
+
+// ErrInvalidAccountID is returned when an account ID cannot be used as an element of an object key
+var ErrInvalidAccountID = errors.New("account ID cannot contain slashes or dot segments")
+
+// UploadStatus represents the processing state of an uploaded statement
+type UploadStatus string
+
+const (
+	UploadStatusPending    UploadStatus = "pending"
+	UploadStatusProcessing UploadStatus = "processing"
+	UploadStatusProcessed  UploadStatus = "processed"
+	UploadStatusFailed     UploadStatus = "failed"
+)
+
+// Upload represents a statement uploaded to object storage through a presigned URL. It records who
+// the statement is processed for until the object lands and its processing outcome afterwards. While
+// the statement is processed, the upload is claimed until ClaimedUntil.
+type Upload struct {
+	ID           string
+	AccountID    string
+	CustomerID   string
+	Email        string
+	CSVProfile   string
+	FileName     string
+	ContentType  string
+	Key          string
+	Status       UploadStatus
+	Error        string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	ClaimedUntil time.Time
+}
+
+// NewUpload creates a pending Upload whose presigned URL expires after expiresIn
+func NewUpload(id, accountID, fileName string, createdAt time.Time, expiresIn time.Duration) *Upload {
+	fileName = path.Base(strings.ReplaceAll(fileName, `\`, "/"))
+
+	return &Upload{
+		ID:        id,
+		AccountID: accountID,
+		FileName:  fileName,
+		Key:       UploadKey(accountID, id, fileName),
+		Status:    UploadStatusPending,
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(expiresIn),
+	}
+}
+
+// UploadKey returns the object key of an uploaded statement, scoped to its account and upload ID
+func UploadKey(accountID, uploadID, fileName string) string {
+	return uploadKeyPrefix + accountID + "/" + uploadID + "/" + fileName
+}
+
+// ValidateUploadAccountID checks that an account ID can scope the object keys of its uploads: an account
+// ID with slashes or dot segments would let its uploads land under the prefix of another account
+func ValidateUploadAccountID(accountID string) error {
+	if accountID == "" || strings.ContainsAny(accountID, `/\`) || strings.Contains(accountID, "..") || accountID == "." {
+		return fmt.Errorf("%w: %q", ErrInvalidAccountID, accountID)
+	}
+	return nil
+}
+
+// ParseUploadKey returns the account and upload ID of an uploaded statement object key
+func ParseUploadKey(key string) (accountID, uploadID string, ok bool) {
+	if !strings.HasPrefix(key, uploadKeyPrefix) {
+		return "", "", false
+	}
+
+	elements := strings.Split(strings.TrimPrefix(key, uploadKeyPrefix), "/")
+	if len(elements) != 3 || ValidateUploadAccountID(elements[0]) != nil || elements[1] == "" || elements[2] == "" {
+		return "", "", false
+	}
+	return elements[0], elements[1], true
+}
+
+// IsClaimable reports whether the statement can be processed at the given time: it has not been processed
+// yet, and no processing is under way or the one claiming it outlived its lease, e.g. when it was killed
+func (u *Upload) IsClaimable(at time.Time) bool {
+	switch u.Status {
+	case UploadStatusPending:
+		return true
+	case UploadStatusProcessing:
+		return !at.Before(u.ClaimedUntil)
+	default:
+		return false
+	}
+}
+
+// Claim records that the statement is being processed, holding it back from other processing until
+// the lease ends
+func (u *Upload) Claim(at time.Time, lease time.Duration) {
+	u.Status = UploadStatusProcessing
+	u.ClaimedUntil = at.Add(lease)
+}
+
+// Release returns a claimed upload to pending, so it is processed again
+func (u *Upload) Release() {
+	u.Status = UploadStatusPending
+	u.ClaimedUntil = time.Time{}
+}
+
+// MarkProcessed records that the statement was processed
+func (u *Upload) MarkProcessed() {
+	u.Status = UploadStatusProcessed
+	u.Error = ""
+	u.ClaimedUntil = time.Time{}
+}
+
+// MarkFailed records that the statement could not be processed and why
+func (u *Upload) MarkFailed(err error) {
+	u.Status = UploadStatusFailed
+	u.Error = err.Error()
+	u.ClaimedUntil = time.Time{}
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ValidationReport describes the transactions of a statement and what looks suspicious about them.
+// Warnings do not prevent the statement from being processed.
+type ValidationReport struct {
+	TransactionCount int
+	CreditCount      int
+	DebitCount       int
+	PendingCount     int
+	TotalCredit      float64
+	TotalDebit       float64
+	FirstDate        time.Time
+	LastDate         time.Time
+	BalancesVerified bool
+	Warnings         []string
+}
+
+// ValidateStatement builds the validation report of a statement. Transactions are flagged when they
+// have a zero amount, are dated after now or repeat the ID of an earlier transaction without
+// settling it. Declared balances are verified when the statement has both, and the balance mismatches
+// recorded while reading the statement are reported.
+func ValidateStatement(statement *Statement, now time.Time) ValidationReport {
+	report := ValidationReport{TransactionCount: len(statement.Transactions)}
+	if report.TransactionCount == 0 {
+		report.Warnings = append(report.Warnings, "the statement has no transactions")
+	}
+
+	seen := make(map[string]*Transaction)
+	var repeated []string
+	for _, tx := range statement.Transactions {
+		if tx.IsPending() {
+			report.PendingCount++
+		}
+		if tx.IsCredit {
+			report.CreditCount++
+			report.TotalCredit += tx.Amount
+		} else {
+			report.DebitCount++
+			report.TotalDebit += tx.Amount
+		}
+		if report.FirstDate.IsZero() || tx.Date.Before(report.FirstDate) {
+			report.FirstDate = tx.Date
+		}
+		if tx.Date.After(report.LastDate) {
+			report.LastDate = tx.Date
+		}
+
+		if tx.Amount == 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("transaction %s has a zero amount", tx.ID))
+		}
+		if tx.Date.After(now) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("transaction %s is dated in the future (%s)", tx.ID, tx.Date.Format("2006-01-02")))
+		}
+
+		// A later transaction may settle a pending one with the same ID, any other repeat is a duplicate
+		if earlier, ok := seen[tx.ID]; ok && !(earlier.IsPending() && !tx.IsPending()) {
+			repeated = append(repeated, tx.ID)
+		}
+		seen[tx.ID] = tx
+	}
+
+	sort.Strings(repeated)
+	for i, id := range repeated {
+		if i == 0 || repeated[i-1] != id {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("transaction ID %s is repeated", id))
+		}
+	}
+
+	// Mismatches found while reading replace the check of the merged balances, which they also fail
+	report.Warnings = append(report.Warnings, statement.BalanceMismatches...)
+	if statement.OpeningBalance != nil && statement.ClosingBalance != nil && len(statement.BalanceMismatches) == 0 {
+		if err := statement.ValidateBalances(); err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+		} else {
+			report.BalancesVerified = true
+		}
+	}
+
+	return report
+}
@@ -2,58 +2,498 @@ package factory
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
+	"time"
 
 	"transaction-processor/internal/adapters"
 	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
 	"transaction-processor/internal/services"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
 
+// localJobQueue queues jobs in memory when no SQS queue is configured
+var localJobQueue = adapters.NewMemoryJobQueue(100)
+
+// LocalJobQueue returns the in-memory job queue used when no SQS queue is configured, so its
+// consumer can be started in the same process
+func LocalJobQueue() *adapters.MemoryJobQueue {
+	return localJobQueue
+}
+
+// secretStore reads the secrets of the configuration, caching them for the lifetime of the process
+var (
+	secretStore     *adapters.SecretsManagerSecretStore
+	secretStoreOnce sync.Once
+)
+
+// sharedSecretStore returns the secret store of the process, creating it on first use
+func sharedSecretStore(awsConfig aws.Config) *adapters.SecretsManagerSecretStore {
+	secretStoreOnce.Do(func() {
+		secretStore = adapters.NewSecretsManagerSecretStore(secretsmanager.NewFromConfig(awsConfig))
+	})
+	return secretStore
+}
+
 // ServiceFactory creates and configures application services
 type ServiceFactory struct {
-	config config.Configuration
+	config  config.Configuration
+	readers *adapters.ReaderRegistry
 }
 
-// NewServiceFactory creates a new ServiceFactory
+// NewServiceFactory creates a new ServiceFactory with the built-in transaction file readers
 func NewServiceFactory(cfg config.Configuration) *ServiceFactory {
 	return &ServiceFactory{
-		config: cfg,
+		config:  cfg,
+		readers: adapters.NewDefaultReaderRegistry(),
+	}
+}
+
+// RegisterReader registers a custom transaction file reader, which takes precedence over the
+// built-in readers of the same extension or MIME type
+func (f *ServiceFactory) RegisterReader(format adapters.ReaderFormat) {
+	f.readers.Register(format)
+}
+
+// CreateTransactionService creates a fully configured TransactionService. CSV files are read with
+// the named CSV profile, or with the Id,Date,Transaction format when csvProfile is empty.
+func (f *ServiceFactory) CreateTransactionService(ctx context.Context, csvProfile string) (*services.TransactionService, error) {
+	fileReader, err := f.fileReader(csvProfile)
+	if err != nil {
+		return nil, err
 	}
+
+	return f.createTransactionService(ctx, fileReader, csvProfile, "")
 }
 
-// CreateTransactionService creates a fully configured TransactionService
-func (f *ServiceFactory) CreateTransactionService() (*services.TransactionService, error) {
+// CreateUploadTransactionService creates a fully configured TransactionService for an uploaded file,
+// whose reader is resolved with the MIME type of the upload before its extension and content
+func (f *ServiceFactory) CreateUploadTransactionService(ctx context.Context, csvProfile, mimeType string) (*services.TransactionService, error) {
+	fileReader, err := f.fileReader(csvProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.createTransactionService(ctx, fileReader.WithMIMEType(mimeType), csvProfile, mimeType)
+}
+
+// createTransactionService creates a TransactionService reading files with the given reader, which
+// was set up with the given CSV profile and MIME type
+func (f *ServiceFactory) createTransactionService(ctx context.Context, fileReader ports.FileReader, csvProfile, mimeType string) (*services.TransactionService, error) {
 	// Initialize AWS SDK clients
-	awsConfig, err := awsconfig.LoadDefaultConfig(context.TODO())
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
 	if err != nil {
 		log.Printf("Error loading AWS config: %v", err)
 		return nil, err
 	}
 
 	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	emailSender, err := f.summaryEmailSender(ctx, awsConfig, dynamoClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository *adapters.DynamoDBRepository
+	if f.config.TransactionsTable != "" && f.config.AccountsTable != "" {
+		repository = adapters.NewDynamoDBRepository(dynamoClient, f.config.TransactionsTable, f.config.AccountsTable)
+	}
+
+	var budgetRepository ports.BudgetRepository
+	if f.config.BudgetsTable != "" {
+		budgetRepository = adapters.NewDynamoDBBudgetRepository(dynamoClient, f.config.BudgetsTable)
+	}
+
+	var chargesEngine *model.ChargesEngine
+	if chargesConfig := f.chargesConfig(); chargesConfig.IsEnabled() {
+		chargesEngine = model.NewChargesEngine(chargesConfig)
+	}
+
+	// Create and return transaction service
+	service := services.NewTransactionService(fileReader, emailSender, repository, budgetRepository, chargesEngine)
+	service.SetWorkers(f.config.PersistenceWorkers)
+	service.SetFileFormat(csvProfile, mimeType)
+	if repository != nil && f.config.CheckpointsTable != "" {
+		service.SetCheckpoints(adapters.NewDynamoDBCheckpointRepository(dynamoClient, f.config.CheckpointsTable), f.config.CheckpointInterval)
+	}
+	if repository != nil {
+		switch mode := services.ReconciliationMode(f.config.ReconciliationMode); mode {
+		case services.ReconciliationFlag, services.ReconciliationFail:
+			service.SetReconciliation(mode)
+		case "off":
+		default:
+			log.Printf("Unknown reconciliation mode %q, reconciliation is disabled", mode)
+		}
+	}
+	return service, nil
+}
+
+// CreateDryRunService creates a DryRunService that processes files without storing transactions or
+// sending emails. Budgets are still read to preview their status in the summary.
+func (f *ServiceFactory) CreateDryRunService(ctx context.Context, csvProfile, mimeType string) (*services.DryRunService, error) {
+	fileReader, err := f.fileReader(csvProfile)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create adapters
-	fileReader := adapters.NewCSVFileReader()
+	var budgetRepository ports.BudgetRepository
+	if f.config.BudgetsTable != "" {
+		awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			log.Printf("Error loading AWS config: %v", err)
+			return nil, err
+		}
+		budgetRepository = adapters.NewDynamoDBBudgetRepository(dynamodb.NewFromConfig(awsConfig), f.config.BudgetsTable)
+	}
+
+	var chargesEngine *model.ChargesEngine
+	if chargesConfig := f.chargesConfig(); chargesConfig.IsEnabled() {
+		chargesEngine = model.NewChargesEngine(chargesConfig)
+	}
+
+	return services.NewDryRunService(
+		fileReader.WithMIMEType(mimeType),
+		adapters.NewPreviewEmailSender(),
+		adapters.NewNoopTransactionRepository(),
+		budgetRepository,
+		chargesEngine,
+	), nil
+}
+
+// emailSender creates the SMTP email sender. The password is read from Secrets Manager when a secret is
+// configured, and taken from the configuration otherwise, for local development.
+func (f *ServiceFactory) emailSender(ctx context.Context, awsConfig aws.Config) (*adapters.SMTPClient, error) {
+	password := f.config.EmailPassword
+	if f.config.EmailPasswordSecret != "" {
+		var err error
+		if password, err = sharedSecretStore(awsConfig).GetSecret(ctx, f.config.EmailPasswordSecret); err != nil {
+			return nil, fmt.Errorf("error reading the SMTP password: %w", err)
+		}
+	}
 
-	// Create SMTP email sender
 	smtpConfig := adapters.SMTPConfiguration{
 		Sender:     f.config.EmailSender,
-		Password:   f.config.EmailPassword,
+		Password:   password,
 		SmtpServer: f.config.SmtpServer,
 		SmtpPort:   f.config.SmtpPort,
 	}
-	emailSender := adapters.NewSMTPEmailSender(smtpConfig)
 
 	log.Printf("Using SMTP email sender with server: %s, port: %d", f.config.SmtpServer, f.config.SmtpPort)
+	return adapters.NewSMTPEmailSender(smtpConfig), nil
+}
 
-	var repository *adapters.DynamoDBRepository
-	if f.config.TransactionsTable != "" && f.config.AccountsTable != "" {
-		repository = adapters.NewDynamoDBRepository(dynamoClient, f.config.TransactionsTable, f.config.AccountsTable)
+// summaryEmailSender creates the sender of the summary emails, which writes them to the outbox when it
+// is configured and sends them over SMTP otherwise
+func (f *ServiceFactory) summaryEmailSender(ctx context.Context, awsConfig aws.Config, dynamoClient *dynamodb.Client) (ports.EmailSender, error) {
+	if f.config.OutboxTable == "" {
+		return f.emailSender(ctx, awsConfig)
 	}
+	return services.NewOutboxEmailSender(adapters.NewDynamoDBOutboxRepository(dynamoClient, f.config.OutboxTable)), nil
+}
 
-	// Create and return transaction service
-	return services.NewTransactionService(fileReader, emailSender, repository), nil
-}
\ No newline at end of file
+// CreateOutboxDispatcher creates an OutboxDispatcher delivering the summary emails of the outbox over SMTP
+func (f *ServiceFactory) CreateOutboxDispatcher(ctx context.Context) (*services.OutboxDispatcher, error) {
+	if f.config.OutboxTable == "" {
+		return nil, fmt.Errorf("outbox table must be configured to dispatch notifications")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	outboxRepository := adapters.NewDynamoDBOutboxRepository(dynamoClient, f.config.OutboxTable)
+
+	emailSender, err := f.emailSender(ctx, awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewOutboxDispatcher(outboxRepository, emailSender, f.config.OutboxMaxAttempts), nil
+}
+
+// CreateOutboxPoller creates an OutboxPoller sweeping the outbox from the same process every configured
+// poll interval, for local development where no schedule triggers the dispatcher
+func (f *ServiceFactory) CreateOutboxPoller() *adapters.OutboxPoller {
+	return adapters.NewOutboxPoller(time.Duration(f.config.OutboxPollInterval) * time.Second)
+}
+
+// CreateMonthlyStatementService creates a fully configured MonthlyStatementService
+func (f *ServiceFactory) CreateMonthlyStatementService(ctx context.Context) (*services.MonthlyStatementService, error) {
+	if f.config.TransactionsTable == "" || f.config.AccountsTable == "" || f.config.StatementRunsTable == "" {
+		return nil, fmt.Errorf("transactions, accounts and statement runs tables must be configured for monthly statements")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	repository := adapters.NewDynamoDBRepository(dynamoClient, f.config.TransactionsTable, f.config.AccountsTable)
+	runRepository := adapters.NewDynamoDBStatementRunRepository(dynamoClient, f.config.StatementRunsTable)
+
+	var customerRepository ports.CustomerRepository
+	if f.config.CustomersTable != "" {
+		customerRepository = adapters.NewDynamoDBCustomerRepository(dynamoClient, f.config.CustomersTable)
+	}
+
+	var budgetRepository ports.BudgetRepository
+	if f.config.BudgetsTable != "" {
+		budgetRepository = adapters.NewDynamoDBBudgetRepository(dynamoClient, f.config.BudgetsTable)
+	}
+
+	emailSender, err := f.summaryEmailSender(ctx, awsConfig, dynamoClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewMonthlyStatementService(repository, customerRepository, budgetRepository, emailSender, runRepository), nil
+}
+
+// CreateReplayService creates a fully configured ReplayService
+func (f *ServiceFactory) CreateReplayService(ctx context.Context) (*services.ReplayService, error) {
+	if f.config.TransactionsTable == "" || f.config.AccountsTable == "" {
+		return nil, fmt.Errorf("transactions and accounts tables must be configured to replay accounts")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	repository := adapters.NewDynamoDBRepository(dynamoClient, f.config.TransactionsTable, f.config.AccountsTable)
+
+	var customerRepository ports.CustomerRepository
+	if f.config.CustomersTable != "" {
+		customerRepository = adapters.NewDynamoDBCustomerRepository(dynamoClient, f.config.CustomersTable)
+	}
+
+	var budgetRepository ports.BudgetRepository
+	if f.config.BudgetsTable != "" {
+		budgetRepository = adapters.NewDynamoDBBudgetRepository(dynamoClient, f.config.BudgetsTable)
+	}
+
+	emailSender, err := f.summaryEmailSender(ctx, awsConfig, dynamoClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return services.NewReplayService(repository, customerRepository, budgetRepository, emailSender), nil
+}
+
+// CreateMigrationService creates a MigrationService copying the legacy transactions to the transactions table
+func (f *ServiceFactory) CreateMigrationService(ctx context.Context) (*services.MigrationService, error) {
+	if f.config.LegacyTransactionsTable == "" || f.config.TransactionsTable == "" {
+		return nil, fmt.Errorf("legacy and current transactions tables must be configured to migrate transactions")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	migrator := adapters.NewDynamoDBTransactionMigrator(dynamoClient, f.config.LegacyTransactionsTable, f.config.TransactionsTable)
+
+	return services.NewMigrationService(migrator), nil
+}
+
+// CreateForecastService creates a fully configured ForecastService
+func (f *ServiceFactory) CreateForecastService(ctx context.Context) (*services.ForecastService, error) {
+	if f.config.TransactionsTable == "" || f.config.AccountsTable == "" {
+		return nil, fmt.Errorf("transactions and accounts tables must be configured for forecasting")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	repository := adapters.NewDynamoDBRepository(dynamoClient, f.config.TransactionsTable, f.config.AccountsTable)
+
+	return services.NewForecastService(repository), nil
+}
+
+// CreateBudgetService creates a fully configured BudgetService
+func (f *ServiceFactory) CreateBudgetService(ctx context.Context) (*services.BudgetService, error) {
+	if f.config.BudgetsTable == "" {
+		return nil, fmt.Errorf("budgets table must be configured to manage budgets")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	budgetRepository := adapters.NewDynamoDBBudgetRepository(dynamoClient, f.config.BudgetsTable)
+
+	return services.NewBudgetService(budgetRepository), nil
+}
+
+// CreateCustomerService creates a fully configured CustomerService.
+// Callers are only resolved to customers when the customers table is configured.
+func (f *ServiceFactory) CreateCustomerService(ctx context.Context) (*services.CustomerService, error) {
+	if f.config.CustomersTable == "" {
+		return services.NewCustomerService(nil, f.config.AccountID), nil
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	customerRepository := adapters.NewDynamoDBCustomerRepository(dynamoClient, f.config.CustomersTable)
+
+	return services.NewCustomerService(customerRepository, f.config.AccountID), nil
+}
+
+// CreateUploadService creates a fully configured UploadService
+func (f *ServiceFactory) CreateUploadService(ctx context.Context) (*services.UploadService, error) {
+	if f.config.UploadsBucket == "" || f.config.UploadsTable == "" {
+		return nil, fmt.Errorf("uploads bucket and table must be configured for statement uploads")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	uploadRepository := adapters.NewDynamoDBUploadRepository(dynamoClient, f.config.UploadsTable)
+
+	// Local S3-compatible stand-ins are addressed by path rather than by bucket subdomain
+	s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if f.config.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(f.config.S3Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	storage := adapters.NewS3ObjectStorage(s3Client, f.config.UploadsBucket, f.config.MaxObjectSize)
+
+	urlExpiry := time.Duration(f.config.UploadURLExpiry) * time.Second
+	return services.NewUploadService(storage, uploadRepository, urlExpiry), nil
+}
+
+// CreateJobService creates a fully configured JobService
+func (f *ServiceFactory) CreateJobService(ctx context.Context) (*services.JobService, error) {
+	if f.config.JobsTable == "" {
+		return nil, fmt.Errorf("jobs table must be configured for asynchronous processing")
+	}
+
+	// Initialize AWS SDK clients
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Printf("Error loading AWS config: %v", err)
+		return nil, err
+	}
+
+	dynamoClient := dynamodb.NewFromConfig(awsConfig)
+	jobRepository := adapters.NewDynamoDBJobRepository(dynamoClient, f.config.JobsTable)
+
+	// Lambda freezes the process once a handler returns, so jobs queued in memory would never run
+	if f.config.JobsQueueURL == "" && f.config.LambdaFunctionName != "" {
+		return nil, fmt.Errorf("jobs queue URL must be configured for asynchronous processing in Lambda")
+	}
+
+	// Without a dead-letter queue URL, SQS jobs that keep failing are only recorded as failed
+	var jobQueue ports.JobQueue = localJobQueue
+	var deadLetterQueue ports.JobDeadLetterQueue = localJobQueue
+	if f.config.JobsQueueURL != "" {
+		sqsClient := sqs.NewFromConfig(awsConfig)
+		jobQueue = adapters.NewSQSJobQueue(sqsClient, f.config.JobsQueueURL)
+		deadLetterQueue = nil
+		if f.config.JobsDeadLetterQueueURL != "" {
+			deadLetterQueue = adapters.NewSQSJobQueue(sqsClient, f.config.JobsDeadLetterQueueURL)
+		}
+	}
+
+	return services.NewJobService(jobRepository, jobQueue, deadLetterQueue, f.config.JobMaxAttempts), nil
+}
+
+// ValidateCSVProfile checks that a CSV profile is defined, returning adapters.ErrCSVProfileNotFound
+// when it is not. An empty name refers to the default profile.
+func (f *ServiceFactory) ValidateCSVProfile(csvProfile string) error {
+	_, err := f.fileReader(csvProfile)
+	return err
+}
+
+// fileReader returns the reader registry, replacing the CSV reader when a CSV profile is requested
+func (f *ServiceFactory) fileReader(csvProfile string) (*adapters.ReaderRegistry, error) {
+	if csvProfile == "" {
+		return f.readers, nil
+	}
+
+	profile, err := adapters.LoadCSVProfile(f.config.CSVProfilesPath, csvProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	readers := f.readers.Clone()
+	readers.Register(adapters.NewCSVReaderFormat(adapters.NewCSVFileReaderWithProfile(profile)))
+	return readers, nil
+}
+
+// chargesConfig builds the interest and fee engine configuration from the Lambda configuration. Unknown
+// rate types and methods are logged and replaced by the defaults of the engine, APR and simple interest.
+func (f *ServiceFactory) chargesConfig() model.ChargesConfig {
+	rateType := model.RateType(strings.ToUpper(f.config.InterestRateType))
+	switch rateType {
+	case model.RateTypeAPR, model.RateTypeAPY, "":
+	default:
+		log.Printf("Unknown interest rate type %q, the interest rate is applied as APR", f.config.InterestRateType)
+		rateType = ""
+	}
+
+	method := model.InterestMethod(strings.ToLower(f.config.InterestMethod))
+	switch method {
+	case model.InterestMethodSimple, model.InterestMethodCompound, "":
+	default:
+		log.Printf("Unknown interest method %q, simple interest is applied", f.config.InterestMethod)
+		method = ""
+	}
+
+	return model.ChargesConfig{
+		Interest: model.InterestConfig{
+			Rate:     f.config.InterestRate,
+			RateType: rateType,
+			Method:   method,
+		},
+		Fees: model.FeeConfig{
+			OverdraftFee:             f.config.OverdraftFee,
+			PerTransactionFee:        f.config.PerTransactionFee,
+			MonthlyMaintenanceFee:    f.config.MonthlyMaintenanceFee,
+			MaintenanceWaiverBalance: f.config.MaintenanceWaiverBalance,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// callerID returns the identity of the authenticated caller, taken from the Cognito "sub" claim
+// or the principal ID of a Lambda authorizer. It is empty for anonymous requests.
+func callerID(request events.APIGatewayProxyRequest) string {
+	authorizer := request.RequestContext.Authorizer
+	if claims, ok := authorizer["claims"].(map[string]interface{}); ok {
+		if sub, ok := claims["sub"].(string); ok {
+			return sub
+		}
+	}
+	if principalID, ok := authorizer["principalId"].(string); ok {
+		return principalID
+	}
+	return ""
+}
+
+// resolveAccount resolves the customer and account a request targets. On failure it returns
+// the response to send back to the caller.
+func resolveAccount(
+	ctx context.Context,
+	serviceFactory *factory.ServiceFactory,
+	request events.APIGatewayProxyRequest,
+	requestedAccountID string,
+) (*model.Customer, string, *events.APIGatewayProxyResponse) {
+	customerService, err := serviceFactory.CreateCustomerService(ctx)
+	if err != nil {
+		log.Printf("Error creating customer service: %v", err)
+		return nil, "", &events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating customer service: %v", err),
+		}
+	}
+
+	customer, accountID, err := customerService.ResolveAccount(ctx, callerID(request), requestedAccountID)
+	switch {
+	case errors.Is(err, services.ErrCustomerNotFound), errors.Is(err, services.ErrAccountNotOwned):
+		return nil, "", &events.APIGatewayProxyResponse{
+			StatusCode: 403,
+			Body:       "You are not allowed to access this account.",
+		}
+	case errors.Is(err, services.ErrAuthenticationRequired):
+		return nil, "", &events.APIGatewayProxyResponse{
+			StatusCode: 401,
+			Body:       "Please sign in to access this account.",
+		}
+	case errors.Is(err, services.ErrAccountRequired):
+		return nil, "", &events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       "Please provide the accountId of one of your accounts.",
+		}
+	case err != nil:
+		log.Printf("Error resolving account: %v", err)
+		return nil, "", &events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error resolving account: %v", err),
+		}
+	}
+
+	return customer, accountID, nil
+}
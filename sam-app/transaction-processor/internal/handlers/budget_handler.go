@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/go-playground/validator/v10"
+)
+
+// BudgetHandler handles category budget requests
+type BudgetHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewBudgetHandler creates a new BudgetHandler
+func NewBudgetHandler(cfg config.Configuration) *BudgetHandler {
+	return &BudgetHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle processes the Lambda request that sets a category budget
+func (h *BudgetHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Parse and validate budget from request body
+	var requestBody models.BudgetRequestBody
+	if err := json.Unmarshal([]byte(request.Body), &requestBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       fmt.Sprintf("Invalid request body: %v", err),
+		}, nil
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(requestBody); err != nil {
+		log.Printf("Invalid budget: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       "Invalid budget. Please provide a category, a non negative monthly limit and an alert threshold between 0 and 1.",
+		}, nil
+	}
+
+	// Resolve the target account from the request and the authenticated caller
+	_, accountID, errResponse := resolveAccount(ctx, h.serviceFactory, request, requestBody.AccountID)
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+
+	// Create budget service using factory
+	service, err := h.serviceFactory.CreateBudgetService(ctx)
+	if err != nil {
+		log.Printf("Error creating budget service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating budget service: %v", err),
+		}, nil
+	}
+
+	_, err = service.SetBudget(ctx, accountID, requestBody.Category, requestBody.MonthlyLimit, requestBody.AlertThreshold)
+	if err != nil {
+		log.Printf("Error saving budget: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error saving budget: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Body:       "Budget saved successfully.",
+	}, nil
+}
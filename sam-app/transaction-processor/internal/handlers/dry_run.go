@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"transaction-processor/internal/adapters"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// dryRun processes a statement file without storing its transactions or sending the summary email,
+// and responds with the summary, the validation report and the email that would have been sent
+func dryRun(
+	ctx context.Context,
+	serviceFactory *factory.ServiceFactory,
+	csvProfile, mimeType, filePath, email, accountID string,
+) (events.APIGatewayProxyResponse, error) {
+	service, err := serviceFactory.CreateDryRunService(ctx, csvProfile, mimeType)
+	if errors.Is(err, adapters.ErrCSVProfileNotFound) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       err.Error(),
+		}, nil
+	}
+	if err != nil {
+		log.Printf("Error creating dry run service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating dry run service: %v", err),
+		}, nil
+	}
+
+	result, err := service.Preview(ctx, filePath, email, accountID)
+	switch {
+	case errors.Is(err, adapters.ErrUnsupportedFormat):
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Body:       err.Error(),
+		}, nil
+	case errors.Is(err, model.ErrStatementMismatch), errors.Is(err, adapters.ErrExtractedSizeExceeded):
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusUnprocessableEntity,
+			Body:       err.Error(),
+		}, nil
+	case err != nil:
+		log.Printf("Error previewing statement: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error previewing statement: %v", err),
+		}, nil
+	}
+
+	body, err := json.Marshal(models.NewDryRunResponse(result.Summary, result.Validation, result.Email))
+	if err != nil {
+		log.Printf("Error encoding dry run: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error encoding dry run: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}, nil
+}
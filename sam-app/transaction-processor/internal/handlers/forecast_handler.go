@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ForecastHandler handles cash-flow forecast requests
+type ForecastHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewForecastHandler creates a new ForecastHandler
+func NewForecastHandler(cfg config.Configuration) *ForecastHandler {
+	return &ForecastHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle processes the Lambda request for the next month's forecast
+func (h *ForecastHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Resolve the target account from the query string and the authenticated caller
+	_, accountID, errResponse := resolveAccount(ctx, h.serviceFactory, request, request.QueryStringParameters["accountId"])
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+
+	// Create forecast service using factory
+	service, err := h.serviceFactory.CreateForecastService(ctx)
+	if err != nil {
+		log.Printf("Error creating forecast service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating forecast service: %v", err),
+		}, nil
+	}
+
+	forecast, err := service.GetForecast(ctx, accountID)
+	if errors.Is(err, services.ErrNoTransactionHistory) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 404,
+			Body:       "No transaction history available for forecasting.",
+		}, nil
+	}
+	if err != nil {
+		log.Printf("Error computing forecast: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error computing forecast: %v", err),
+		}, nil
+	}
+
+	body, err := json.Marshal(models.NewForecastResponse(forecast))
+	if err != nil {
+		log.Printf("Error encoding forecast: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error encoding forecast: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}, nil
+}
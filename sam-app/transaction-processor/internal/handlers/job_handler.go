@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// JobHandler handles job status requests
+type JobHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewJobHandler creates a new JobHandler
+func NewJobHandler(cfg config.Configuration) *JobHandler {
+	return &JobHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle processes the Lambda request for the status, progress and errors of a job
+func (h *JobHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Create job service using factory
+	service, err := h.serviceFactory.CreateJobService(ctx)
+	if err != nil {
+		log.Printf("Error creating job service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating job service: %v", err),
+		}, nil
+	}
+
+	job, err := service.GetJob(ctx, request.PathParameters["id"])
+	if errors.Is(err, services.ErrJobNotFound) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 404,
+			Body:       "Job not found.",
+		}, nil
+	}
+	if err != nil {
+		log.Printf("Error getting job: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error getting job: %v", err),
+		}, nil
+	}
+
+	// Only callers allowed to access the account of the job may see it
+	if _, _, errResponse := resolveAccount(ctx, h.serviceFactory, request, job.AccountID); errResponse != nil {
+		return *errResponse, nil
+	}
+
+	return jobResponse(200, job)
+}
+
+// jobResponse encodes a job into a JSON response
+func jobResponse(statusCode int, job *model.Job) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(models.NewJobResponse(job))
+	if err != nil {
+		log.Printf("Error encoding job: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error encoding job: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"transaction-processor/internal/adapters"
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// JobWorker runs the queued transaction processing jobs
+type JobWorker struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewJobWorker creates a new JobWorker
+func NewJobWorker(cfg config.Configuration) *JobWorker {
+	return &JobWorker{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// HandleSQS runs the jobs of a batch of SQS messages. The messages whose job failed are reported as
+// batch item failures, so only they are delivered again, and their receive count is the attempt
+// number of the job. Messages left once the invocation deadline passed are reported without running.
+func (w *JobWorker) HandleSQS(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+	response := events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{}}
+	for _, record := range event.Records {
+		jobID, err := adapters.ParseJobMessage(record.Body)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil {
+			err = w.Run(ctx, jobID, receiveCount(record))
+		}
+		if err != nil {
+			log.Printf("Error running job of message %s: %v", record.MessageId, err)
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: record.MessageId})
+		}
+	}
+
+	return response, nil
+}
+
+// receiveCount returns how many times an SQS message has been received, 1 when it is unknown
+func receiveCount(record events.SQSMessage) int {
+	count, err := strconv.Atoi(record.Attributes["ApproximateReceiveCount"])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+// Run runs an attempt of a job, processing its statement with the TransactionService
+func (w *JobWorker) Run(ctx context.Context, jobID string, attempt int) error {
+	service, err := w.serviceFactory.CreateJobService(ctx)
+	if err != nil {
+		return err
+	}
+
+	return service.RunJob(ctx, jobID, attempt, w.process)
+}
+
+// process processes the statement of a job for the customer and account it was submitted for
+func (w *JobWorker) process(ctx context.Context, job *model.Job, progress services.ProgressFunc) error {
+	customerService, err := w.serviceFactory.CreateCustomerService(ctx)
+	if err != nil {
+		return err
+	}
+	customer, accountID, err := customerService.ResolveAccount(ctx, job.CustomerID, job.AccountID)
+	if err != nil {
+		return err
+	}
+
+	service, err := w.serviceFactory.CreateTransactionService(ctx, job.CSVProfile)
+	if err != nil {
+		return err
+	}
+	service.SetProgress(progress)
+
+	return service.ProcessTransactionsAndSendSummary(ctx, job.Source, job.Email, accountID, customer)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+)
+
+// MigrationHandler handles the admin events migrating the legacy transactions. They are invoked directly
+// on the function, so only callers allowed to invoke it can run the migration.
+type MigrationHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewMigrationHandler creates a new MigrationHandler
+func NewMigrationHandler(cfg config.Configuration) *MigrationHandler {
+	return &MigrationHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle copies the legacy transactions to the account of the request, or to the configured account,
+// and reports where to resume when the invocation stopped before copying them all
+func (h *MigrationHandler) Handle(ctx context.Context, request models.MigrationRequest) (models.MigrationResponse, error) {
+	accountID := request.AccountID
+	if accountID == "" {
+		accountID = h.config.AccountID
+	}
+
+	service, err := h.serviceFactory.CreateMigrationService(ctx)
+	if err != nil {
+		log.Printf("Error creating migration service: %v", err)
+		return models.MigrationResponse{}, err
+	}
+
+	result, err := service.MigrateLegacyTransactions(ctx, accountID, request.StartAfter)
+	log.Printf("Migrated legacy transactions to account %s: %d copied, %d skipped, done: %t", accountID, result.Copied, result.Skipped, result.Done)
+	if err != nil {
+		log.Printf("Error migrating legacy transactions: %v", err)
+		return models.MigrationResponse{}, err
+	}
+
+	return models.MigrationResponse{
+		AccountID: accountID,
+		Copied:    result.Copied,
+		Skipped:   result.Skipped,
+		Done:      result.Done,
+		Next:      result.Next,
+	}, nil
+}
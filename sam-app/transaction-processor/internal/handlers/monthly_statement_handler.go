@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// MonthlyStatementHandler handles the scheduled events sending the monthly statements
+type MonthlyStatementHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewMonthlyStatementHandler creates a new MonthlyStatementHandler
+func NewMonthlyStatementHandler(cfg config.Configuration) *MonthlyStatementHandler {
+	return &MonthlyStatementHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// HandleScheduled sends the statements of the calendar month before the scheduled event to every
+// account. The run stops ahead of the invocation deadline, or ends with accounts whose statement failed,
+// and returns an error, so the invocation is retried and the run resumes from its checkpoint.
+func (h *MonthlyStatementHandler) HandleScheduled(ctx context.Context, event events.CloudWatchEvent) error {
+	service, err := h.serviceFactory.CreateMonthlyStatementService(ctx)
+	if err != nil {
+		log.Printf("Error creating monthly statement service: %v", err)
+		return err
+	}
+
+	period := model.PreviousMonthStatementPeriod(event.Time)
+	run, err := service.SendMonthlyStatements(ctx, period)
+	if run != nil {
+		log.Printf("Statement run %s: %s, %d sent, %d skipped, %d failed", run.Period, run.Status, run.Sent, run.Skipped, run.Failed)
+	}
+	if err != nil {
+		log.Printf("Error sending monthly statements of %s: %v", period.Key(), err)
+		return err
+	}
+
+	return nil
+}
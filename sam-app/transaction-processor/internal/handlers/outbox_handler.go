@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+)
+
+// OutboxHandler handles the events sweeping the outbox of summary emails. They are sent by a schedule
+// on the worker function, or by the local poller when running outside of Lambda.
+type OutboxHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewOutboxHandler creates a new OutboxHandler
+func NewOutboxHandler(cfg config.Configuration) *OutboxHandler {
+	return &OutboxHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle delivers the due notifications of the outbox and reports how many were delivered, are retried
+// later, failed for good or were skipped because another sweep claimed them
+func (h *OutboxHandler) Handle(ctx context.Context) (models.OutboxDispatchResponse, error) {
+	dispatcher, err := h.serviceFactory.CreateOutboxDispatcher(ctx)
+	if err != nil {
+		log.Printf("Error creating outbox dispatcher: %v", err)
+		return models.OutboxDispatchResponse{}, err
+	}
+
+	result, err := dispatcher.Dispatch(ctx)
+	log.Printf("Outbox sweep: %d delivered, %d retrying, %d failed, %d skipped", result.Delivered, result.Retrying, result.Failed, result.Skipped)
+	if err != nil {
+		log.Printf("Error sweeping the outbox: %v", err)
+	}
+
+	return models.OutboxDispatchResponse{
+		Delivered: result.Delivered,
+		Retrying:  result.Retrying,
+		Failed:    result.Failed,
+		Skipped:   result.Skipped,
+	}, err
+}
+
+// Sweep delivers the due notifications of the outbox, for the local poller
+func (h *OutboxHandler) Sweep(ctx context.Context) error {
+	_, err := h.Handle(ctx)
+	return err
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+	"transaction-processor/internal/services"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ReplayHandler handles the admin events replaying the stored transactions of an account. They are
+// invoked directly on the function, so only callers allowed to invoke it can replay accounts.
+type ReplayHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewReplayHandler creates a new ReplayHandler
+func NewReplayHandler(cfg config.Configuration) *ReplayHandler {
+	return &ReplayHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle recomputes the summary of an account from its stored transactions, optionally bounded by the
+// from and to days of the request, saves it to the accounts table and resends the summary email when
+// requested
+func (h *ReplayHandler) Handle(ctx context.Context, request models.ReplayRequest) (models.ReplayResponse, error) {
+	validate := validator.New()
+	if err := validate.Struct(request); err != nil {
+		return models.ReplayResponse{}, fmt.Errorf("invalid replay request: %w", err)
+	}
+
+	options := services.ReplayOptions{
+		ResendEmail: request.ResendEmail,
+		Recipient:   request.Email,
+	}
+	if request.From != "" {
+		options.From, _ = time.Parse("2006-01-02", request.From)
+	}
+	if request.To != "" {
+		options.To, _ = time.Parse("2006-01-02", request.To)
+	}
+	if !options.From.IsZero() && !options.To.IsZero() && options.To.Before(options.From) {
+		return models.ReplayResponse{}, fmt.Errorf("invalid replay request: to %s is before from %s", request.To, request.From)
+	}
+
+	service, err := h.serviceFactory.CreateReplayService(ctx)
+	if err != nil {
+		log.Printf("Error creating replay service: %v", err)
+		return models.ReplayResponse{}, err
+	}
+
+	result, err := service.Replay(ctx, request.AccountID, options)
+	if err != nil {
+		log.Printf("Error replaying account %s: %v", request.AccountID, err)
+		return models.ReplayResponse{}, err
+	}
+
+	log.Printf("Replayed %d transactions of account %s", result.TransactionCount, request.AccountID)
+	return models.NewReplayResponse(result.Owner, result.TransactionCount, result.Summary, result.Saved, result.Recipient), nil
+}
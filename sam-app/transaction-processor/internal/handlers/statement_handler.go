@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"transaction-processor/internal/adapters"
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/go-playground/validator/v10"
+)
+
+// StatementHandler handles statement upload requests
+type StatementHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewStatementHandler creates a new StatementHandler
+func NewStatementHandler(cfg config.Configuration) *StatementHandler {
+	return &StatementHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle processes the Lambda request that uploads a statement file as multipart/form-data, with the
+// email, accountId, csvProfile and dryRun form fields and the statement in the file field
+func (h *StatementHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Parse the uploaded file and form fields
+	upload, errResponse := parseUpload(request, h.config.MaxUploadSize)
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+	defer upload.cleanup()
+
+	requestBody := models.StatementUploadRequest{
+		Email:      upload.field("email"),
+		AccountID:  upload.field("accountId"),
+		CSVProfile: upload.field("csvProfile"),
+		DryRun:     upload.field("dryRun") == "true",
+	}
+
+	// Validate email format using validator
+	validate := validator.New()
+	if err := validate.Struct(requestBody); err != nil {
+		log.Printf("Invalid email format: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       "Invalid email format. Please provide a valid email address.",
+		}, nil
+	}
+
+	// Resolve the target account from the request and the authenticated caller
+	customer, accountID, errResponse := resolveAccount(ctx, h.serviceFactory, request, requestBody.AccountID)
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+
+	// Preview the summary without storing anything or sending the email
+	if requestBody.DryRun {
+		return dryRun(ctx, h.serviceFactory, requestBody.CSVProfile, upload.mimeType, upload.filePath, requestBody.Email, accountID)
+	}
+
+	// Create transaction service using factory, resolving the reader with the content type of the upload
+	service, err := h.serviceFactory.CreateUploadTransactionService(ctx, requestBody.CSVProfile, upload.mimeType)
+	if errors.Is(err, adapters.ErrCSVProfileNotFound) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       err.Error(),
+		}, nil
+	}
+	if err != nil {
+		log.Printf("Error creating transaction service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating transaction service: %v", err),
+		}, nil
+	}
+
+	// Process the uploaded statement and send summary
+	err = service.ProcessTransactionsAndSendSummary(ctx, upload.filePath, requestBody.Email, accountID, customer)
+	var partial *services.PartialResultError
+	switch {
+	case errors.As(err, &partial):
+		log.Printf("Statement partially processed: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusServiceUnavailable,
+			Body: fmt.Sprintf("Statement partially processed, %d of %d transactions stored before the time limit. "+
+				"Upload the statement again to resume.", partial.Processed, partial.Total),
+		}, nil
+	case errors.Is(err, adapters.ErrUnsupportedFormat):
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Body:       err.Error(),
+		}, nil
+	case errors.Is(err, model.ErrBalanceMismatch), errors.Is(err, model.ErrReconciliationMismatch),
+		errors.Is(err, model.ErrStatementMismatch), errors.Is(err, adapters.ErrExtractedSizeExceeded):
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusUnprocessableEntity,
+			Body:       err.Error(),
+		}, nil
+	case err != nil:
+		log.Printf("Error processing statement: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error processing statement: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 200,
+		Body:       processedBody(h.config),
+	}, nil
+}
+
+// processedBody returns the body answering a processed statement. With an outbox, the summary email
+// is only queued for the dispatcher and may still fail to be delivered.
+func processedBody(cfg config.Configuration) string {
+	if cfg.OutboxTable != "" {
+		return "Statement processed successfully. Summary email queued for delivery."
+	}
+	return "Statement processed successfully. Summary email sent."
+}
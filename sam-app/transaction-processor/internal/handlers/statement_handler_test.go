@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+	"transaction-processor/internal/config"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const statementCSVContent = "Id,Date,Transaction\n0,7/15,+60.5\n1,7/28,-10.3\n"
+
+// formPart is a part of a test multipart/form-data body. Parts with a file name are files.
+type formPart struct {
+	name        string
+	fileName    string
+	contentType string
+	content     string
+}
+
+// multipartBody encodes the parts into a multipart/form-data body, returning it with its content type
+func multipartBody(t *testing.T, parts ...formPart) (string, string) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		disposition := `form-data; name="` + part.name + `"`
+		if part.fileName != "" {
+			disposition += `; filename="` + part.fileName + `"`
+		}
+		header.Set("Content-Disposition", disposition)
+		if part.contentType != "" {
+			header.Set("Content-Type", part.contentType)
+		}
+
+		field, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatalf("Failed to create form part: %v", err)
+		}
+		if _, err := field.Write([]byte(part.content)); err != nil {
+			t.Fatalf("Failed to write form part: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close multipart writer: %v", err)
+	}
+	return buffer.String(), writer.FormDataContentType()
+}
+
+func TestParseUpload(t *testing.T) {
+	body, contentType := multipartBody(t,
+		formPart{name: "email", content: " user@example.com "},
+		formPart{name: "file", fileName: `C:\exports\july.csv`, contentType: "application/octet-stream", content: statementCSVContent},
+		formPart{name: "file", fileName: "second.csv", content: "ignored"},
+	)
+
+	upload, errResponse := parseUpload(events.APIGatewayProxyRequest{
+		Headers: map[string]string{"content-type": contentType},
+		Body:    body,
+	}, 1<<20)
+	if errResponse != nil {
+		t.Fatalf("parseUpload failed: %d %s", errResponse.StatusCode, errResponse.Body)
+	}
+	defer upload.cleanup()
+
+	assert.Equal(t, "user@example.com", upload.field("email"))
+	assert.True(t, strings.HasSuffix(upload.filePath, "july.csv"), "unexpected file path %s", upload.filePath)
+	assert.Empty(t, upload.mimeType)
+
+	content, err := os.ReadFile(upload.filePath)
+	if err != nil {
+		t.Fatalf("Failed to read uploaded file: %v", err)
+	}
+	assert.Equal(t, statementCSVContent, string(content))
+}
+
+func TestStatementHandler_Handle(t *testing.T) {
+	const maxUploadSize = 1024
+
+	statementBody, contentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "dryRun", content: "true"},
+		formPart{name: "file", fileName: "july.csv", contentType: "text/csv", content: statementCSVContent},
+	)
+	largeBody, largeContentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "file", fileName: "july.csv", content: strings.Repeat("0,7/15,+60.5\n", 100)},
+	)
+	missingFileBody, missingFileContentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "dryRun", content: "true"},
+	)
+	invalidEmailBody, invalidEmailContentType := multipartBody(t,
+		formPart{name: "email", content: "not-an-email"},
+		formPart{name: "file", fileName: "july.csv", content: statementCSVContent},
+	)
+	unsupportedBody, unsupportedContentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "dryRun", content: "true"},
+		formPart{name: "file", fileName: "notes.txt", content: "hello"},
+	)
+	mismatchBody, mismatchContentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "dryRun", content: "true"},
+		formPart{name: "file", fileName: "statement.sta", content: ":20:REF\n:25:ACC1\n:60F:C250101EUR100,00\n:62F:C250101EUR100,00\n-\n" +
+			":20:REF\n:25:ACC2\n:60F:C250102EUR100,00\n:62F:C250102EUR100,00\n-"},
+	)
+	balanceMismatchBody, balanceMismatchContentType := multipartBody(t,
+		formPart{name: "email", content: "user@example.com"},
+		formPart{name: "dryRun", content: "true"},
+		formPart{name: "file", fileName: "statement.sta", content: ":20:REF\n:25:ACC1\n:60F:C250101EUR100,00\n" +
+			":61:250102D10,00NMSCNONREF\n:62F:C250102EUR100,00\n-"},
+	)
+
+	tests := []struct {
+		name         string
+		request      events.APIGatewayProxyRequest
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "dry run of a multipart upload",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": contentType},
+				Body:    statementBody,
+			},
+			expectedCode: 200,
+			expectedBody: `"transactionCount":2`,
+		},
+		{
+			name: "base64-encoded body",
+			request: events.APIGatewayProxyRequest{
+				MultiValueHeaders: map[string][]string{"content-type": {contentType}},
+				Body:              base64.StdEncoding.EncodeToString([]byte(statementBody)),
+				IsBase64Encoded:   true,
+			},
+			expectedCode: 200,
+			expectedBody: `"transactionCount":2`,
+		},
+		{
+			name: "invalid base64 body",
+			request: events.APIGatewayProxyRequest{
+				Headers:         map[string]string{"Content-Type": contentType},
+				Body:            "not base64!",
+				IsBase64Encoded: true,
+			},
+			expectedCode: 400,
+			expectedBody: "Invalid request body",
+		},
+		{
+			name: "body over the maximum upload size",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": largeContentType},
+				Body:    largeBody,
+			},
+			expectedCode: 413,
+		},
+		{
+			name: "base64-encoded body over the maximum upload size",
+			request: events.APIGatewayProxyRequest{
+				Headers:         map[string]string{"Content-Type": largeContentType},
+				Body:            base64.StdEncoding.EncodeToString([]byte(largeBody)),
+				IsBase64Encoded: true,
+			},
+			expectedCode: 413,
+		},
+		{
+			name: "not a multipart body",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"email":"user@example.com"}`,
+			},
+			expectedCode: 415,
+		},
+		{
+			name: "multipart body without boundary",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "multipart/form-data"},
+				Body:    statementBody,
+			},
+			expectedCode: 415,
+		},
+		{
+			name: "missing file part",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": missingFileContentType},
+				Body:    missingFileBody,
+			},
+			expectedCode: 400,
+			expectedBody: `"file" form field`,
+		},
+		{
+			name: "malformed multipart body",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": contentType},
+				Body:    "--not-the-boundary\r\n",
+			},
+			expectedCode: 400,
+		},
+		{
+			name: "invalid email",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": invalidEmailContentType},
+				Body:    invalidEmailBody,
+			},
+			expectedCode: 400,
+			expectedBody: "Invalid email format",
+		},
+		{
+			name: "unsupported statement format",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": unsupportedContentType},
+				Body:    unsupportedBody,
+			},
+			expectedCode: 415,
+			expectedBody: "notes.txt",
+		},
+		{
+			name: "dry run of a statement whose balances do not match",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": balanceMismatchContentType},
+				Body:    balanceMismatchBody,
+			},
+			expectedCode: 200,
+			expectedBody: "statement REF: statement balances do not match its transactions",
+		},
+		{
+			name: "statements that cannot be merged",
+			request: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": mismatchContentType},
+				Body:    mismatchBody,
+			},
+			expectedCode: 422,
+		},
+	}
+
+	handler := NewStatementHandler(config.Configuration{AccountID: "default", MaxUploadSize: maxUploadSize})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler.Handle(context.Background(), tt.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, response.StatusCode, response.Body)
+			assert.Contains(t, response.Body, tt.expectedBody)
+		})
+	}
+}
+
+func TestProcessedBody(t *testing.T) {
+	assert.Equal(t, "Statement processed successfully. Summary email sent.", processedBody(config.Configuration{}))
+	assert.Equal(t, "Statement processed successfully. Summary email queued for delivery.", processedBody(config.Configuration{OutboxTable: "NotificationOutbox"}))
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
+	"transaction-processor/internal/adapters"
 	"transaction-processor/internal/config"
 	"transaction-processor/internal/factory"
 	"transaction-processor/internal/models"
@@ -27,8 +30,10 @@ func NewTransactionHandler(cfg config.Configuration) *TransactionHandler {
 	}
 }
 
-// Handle processes the Lambda request for transaction processing
-func (h *TransactionHandler) Handle(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// Handle processes the Lambda request for transaction processing. The transactions are processed
+// asynchronously by the job worker, and the response holds the job ID to poll at GET /jobs/{id}.
+// Dry runs are processed right away and respond with the summary that would be emailed.
+func (h *TransactionHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Parse and validate email from request body
 	var requestBody models.RequestBody
 	if err := json.Unmarshal([]byte(request.Body), &requestBody); err != nil {
@@ -49,29 +54,51 @@ func (h *TransactionHandler) Handle(request events.APIGatewayProxyRequest) (even
 		}, nil
 	}
 
-	// Create transaction service using factory
-	service, err := h.serviceFactory.CreateTransactionService()
-	if err != nil {
-		log.Printf("Error creating transaction service: %v", err)
+	// Resolve the target account from the request and the authenticated caller
+	customer, accountID, errResponse := resolveAccount(ctx, h.serviceFactory, request, requestBody.AccountID)
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+
+	// Reject unknown CSV profiles before queueing the job
+	if err := h.serviceFactory.ValidateCSVProfile(requestBody.CSVProfile); errors.Is(err, adapters.ErrCSVProfileNotFound) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       err.Error(),
+		}, nil
+	} else if err != nil {
+		log.Printf("Error loading CSV profile: %v", err)
 		return events.APIGatewayProxyResponse{
 			StatusCode: 500,
-			Body:       fmt.Sprintf("Error creating transaction service: %v", err),
+			Body:       fmt.Sprintf("Error loading CSV profile: %v", err),
 		}, nil
 	}
 
-	// Process transactions and send summary
+	// Preview the summary without storing anything or sending the email
 	filePath := "transactions.csv"
-	err = service.ProcessTransactionsAndSendSummary(filePath, requestBody.Email, h.config.AccountID)
+	if requestBody.DryRun {
+		return dryRun(ctx, h.serviceFactory, requestBody.CSVProfile, "", filePath, requestBody.Email, accountID)
+	}
+
+	// Create job service using factory
+	service, err := h.serviceFactory.CreateJobService(ctx)
+	if err != nil {
+		log.Printf("Error creating job service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating job service: %v", err),
+		}, nil
+	}
+
+	// Queue the transactions for processing, the worker sends the summary
+	job, err := service.SubmitJob(ctx, accountID, customer, requestBody.Email, requestBody.CSVProfile, filePath)
 	if err != nil {
-		log.Printf("Error processing transactions: %v", err)
+		log.Printf("Error queueing job: %v", err)
 		return events.APIGatewayProxyResponse{
 			StatusCode: 500,
-			Body:       fmt.Sprintf("Error processing transactions: %v", err),
+			Body:       fmt.Sprintf("Error queueing job: %v", err),
 		}, nil
 	}
 
-	return events.APIGatewayProxyResponse{
-		StatusCode: 200,
-		Body:       "Transactions processed successfully. Summary email sent.",
-	}, nil
+	return jobResponse(202, job)
 }
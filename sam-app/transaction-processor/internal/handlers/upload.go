@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// uploadFileField is the form field holding the uploaded statement file
+const uploadFileField = "file"
+
+// maxUploadFieldSize limits the size of each non-file form field
+const maxUploadFieldSize = 4096
+
+// upload is a file uploaded with a multipart/form-data request, stored in a temporary directory
+type upload struct {
+	fields   map[string]string
+	filePath string
+	mimeType string
+	dir      string
+}
+
+// field returns the trimmed value of a form field
+func (u *upload) field(name string) string {
+	return strings.TrimSpace(u.fields[name])
+}
+
+// cleanup removes the temporary directory of the uploaded file
+func (u *upload) cleanup() {
+	os.RemoveAll(u.dir)
+}
+
+// header returns a request header, ignoring the case of its name
+func header(request events.APIGatewayProxyRequest, name string) string {
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	for key, values := range request.MultiValueHeaders {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// parseUpload reads the form fields and the file of a multipart/form-data request. API Gateway
+// base64-encodes binary bodies, which are decoded first. Bodies over maxSize bytes are rejected.
+// On failure it returns the response to send back to the caller.
+func parseUpload(request events.APIGatewayProxyRequest, maxSize int64) (*upload, *events.APIGatewayProxyResponse) {
+	tooLarge := &events.APIGatewayProxyResponse{
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Body:       fmt.Sprintf("The uploaded statement exceeds the maximum size of %d bytes.", maxSize),
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header(request, "Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode: http.StatusUnsupportedMediaType,
+			Body:       "Please upload the statement as multipart/form-data.",
+		}
+	}
+
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		if int64(base64.StdEncoding.DecodedLen(len(body))) > maxSize+3 {
+			return nil, tooLarge
+		}
+		body, err = base64.StdEncoding.DecodeString(request.Body)
+		if err != nil {
+			return nil, &events.APIGatewayProxyResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       fmt.Sprintf("Invalid request body: %v", err),
+			}
+		}
+	}
+	if int64(len(body)) > maxSize {
+		return nil, tooLarge
+	}
+
+	dir, err := os.MkdirTemp("", "upload-*")
+	if err != nil {
+		log.Printf("Error creating upload directory: %v", err)
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode: http.StatusInternalServerError,
+			Body:       fmt.Sprintf("Error storing upload: %v", err),
+		}
+	}
+	result := &upload{fields: map[string]string{}, dir: dir}
+
+	if err := result.read(multipart.NewReader(bytes.NewReader(body), params["boundary"])); err != nil {
+		result.cleanup()
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode: http.StatusBadRequest,
+			Body:       fmt.Sprintf("Invalid multipart body: %v", err),
+		}
+	}
+	if result.filePath == "" {
+		result.cleanup()
+		return nil, &events.APIGatewayProxyResponse{
+			StatusCode: http.StatusBadRequest,
+			Body:       fmt.Sprintf("Please provide the statement file in the %q form field.", uploadFileField),
+		}
+	}
+
+	return result, nil
+}
+
+// read stores the form fields and writes the file part into the temporary directory
+func (u *upload) read(reader *multipart.Reader) error {
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := part.FormName()
+		if name == uploadFileField && u.filePath == "" {
+			err = u.writeFile(part)
+		} else if name != "" {
+			var value []byte
+			value, err = io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+			if err == nil && len(value) > maxUploadFieldSize {
+				err = fmt.Errorf("form field %s is too long", name)
+			}
+			u.fields[name] = string(value)
+		}
+		part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeFile writes the file part, keeping the base name of the uploaded file so its extension can be
+// used to detect the format, and its content type as a MIME type hint
+func (u *upload) writeFile(part *multipart.Part) error {
+	name := filepath.Base(strings.ReplaceAll(part.FileName(), `\`, "/"))
+	if name == "." || name == "/" || name == "" {
+		name = "statement"
+	}
+
+	filePath := filepath.Join(u.dir, name)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, part); err != nil {
+		return err
+	}
+
+	// Generic content types carry no format information and are left to extension and content detection
+	if mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type")); err == nil && mediaType != "application/octet-stream" {
+		u.mimeType = mediaType
+	}
+	u.filePath = filePath
+	return nil
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"transaction-processor/internal/config"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/factory"
+	"transaction-processor/internal/models"
+	"transaction-processor/internal/services"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/go-playground/validator/v10"
+)
+
+// UploadHandler handles presigned statement upload requests
+type UploadHandler struct {
+	config         config.Configuration
+	serviceFactory *factory.ServiceFactory
+}
+
+// NewUploadHandler creates a new UploadHandler
+func NewUploadHandler(cfg config.Configuration) *UploadHandler {
+	return &UploadHandler{
+		config:         cfg,
+		serviceFactory: factory.NewServiceFactory(cfg),
+	}
+}
+
+// Handle processes the Lambda request that issues a presigned URL to upload a large statement. The
+// statement is processed for the requested email and account once the object lands in the bucket.
+func (h *UploadHandler) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Parse and validate the upload from request body
+	var requestBody models.UploadRequestBody
+	if err := json.Unmarshal([]byte(request.Body), &requestBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       fmt.Sprintf("Invalid request body: %v", err),
+		}, nil
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(requestBody); err != nil {
+		log.Printf("Invalid upload: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       "Invalid upload. Please provide a valid email address and the name of the statement file.",
+		}, nil
+	}
+
+	// Resolve the target account from the request and the authenticated caller
+	customer, accountID, errResponse := resolveAccount(ctx, h.serviceFactory, request, requestBody.AccountID)
+	if errResponse != nil {
+		return *errResponse, nil
+	}
+
+	// Create upload service using factory
+	service, err := h.serviceFactory.CreateUploadService(ctx)
+	if err != nil {
+		log.Printf("Error creating upload service: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating upload service: %v", err),
+		}, nil
+	}
+
+	upload, url, err := service.CreateUpload(ctx, accountID, customer, requestBody.Email, requestBody.CSVProfile, requestBody.FileName, requestBody.ContentType)
+	if errors.Is(err, model.ErrInvalidAccountID) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 400,
+			Body:       err.Error(),
+		}, nil
+	}
+	if err != nil {
+		log.Printf("Error creating upload: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error creating upload: %v", err),
+		}, nil
+	}
+
+	body, err := json.Marshal(models.NewUploadResponse(upload, url))
+	if err != nil {
+		log.Printf("Error encoding upload: %v", err)
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       fmt.Sprintf("Error encoding upload: %v", err),
+		}, nil
+	}
+
+	return events.APIGatewayProxyResponse{
+		StatusCode: 201,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}, nil
+}
+
+// HandleObjectCreated processes the statements uploaded through presigned URLs once S3 notifies that
+// their objects landed. Objects that do not belong to a recorded upload are skipped.
+func (h *UploadHandler) HandleObjectCreated(ctx context.Context, event events.S3Event) error {
+	service, err := h.serviceFactory.CreateUploadService(ctx)
+	if err != nil {
+		log.Printf("Error creating upload service: %v", err)
+		return err
+	}
+
+	var errs []error
+	for _, record := range event.Records {
+		key := record.S3.Object.URLDecodedKey
+		if key == "" {
+			key = record.S3.Object.Key
+		}
+
+		err := service.ProcessUpload(ctx, key, h.processUpload)
+		if errors.Is(err, services.ErrUploadNotFound) {
+			log.Printf("Skipping object %s: %v", key, err)
+			continue
+		}
+		if err != nil {
+			log.Printf("Error processing upload %s: %v", key, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// processUpload processes an uploaded statement for the customer and account it was uploaded for
+func (h *UploadHandler) processUpload(ctx context.Context, upload *model.Upload, filePath string) error {
+	customerService, err := h.serviceFactory.CreateCustomerService(ctx)
+	if err != nil {
+		return err
+	}
+	customer, accountID, err := customerService.ResolveAccount(ctx, upload.CustomerID, upload.AccountID)
+	if err != nil {
+		return err
+	}
+
+	service, err := h.serviceFactory.CreateUploadTransactionService(ctx, upload.CSVProfile, upload.ContentType)
+	if err != nil {
+		return err
+	}
+
+	return service.ProcessTransactionsAndSendSummary(ctx, filePath, upload.Email, accountID, customer)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/budget_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/budget_repository.go -destination=internal/mocks/mock_budget_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetRepository is a mock of BudgetRepository interface.
+type MockBudgetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBudgetRepositoryMockRecorder is the mock recorder for MockBudgetRepository.
+type MockBudgetRepositoryMockRecorder struct {
+	mock *MockBudgetRepository
+}
+
+// NewMockBudgetRepository creates a new mock instance.
+func NewMockBudgetRepository(ctrl *gomock.Controller) *MockBudgetRepository {
+	mock := &MockBudgetRepository{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepository) EXPECT() *MockBudgetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBudgets mocks base method.
+func (m *MockBudgetRepository) GetBudgets(ctx context.Context, accountID string) ([]*model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgets", ctx, accountID)
+	ret0, _ := ret[0].([]*model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgets indicates an expected call of GetBudgets.
+func (mr *MockBudgetRepositoryMockRecorder) GetBudgets(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgets", reflect.TypeOf((*MockBudgetRepository)(nil).GetBudgets), ctx, accountID)
+}
+
+// SaveBudget mocks base method.
+func (m *MockBudgetRepository) SaveBudget(ctx context.Context, budget *model.Budget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBudget", ctx, budget)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBudget indicates an expected call of SaveBudget.
+func (mr *MockBudgetRepositoryMockRecorder) SaveBudget(ctx, budget any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBudget", reflect.TypeOf((*MockBudgetRepository)(nil).SaveBudget), ctx, budget)
+}
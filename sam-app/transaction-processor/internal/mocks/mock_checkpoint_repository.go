@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/checkpoint_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/checkpoint_repository.go -destination=internal/mocks/mock_checkpoint_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCheckpointRepository is a mock of CheckpointRepository interface.
+type MockCheckpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckpointRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCheckpointRepositoryMockRecorder is the mock recorder for MockCheckpointRepository.
+type MockCheckpointRepositoryMockRecorder struct {
+	mock *MockCheckpointRepository
+}
+
+// NewMockCheckpointRepository creates a new mock instance.
+func NewMockCheckpointRepository(ctrl *gomock.Controller) *MockCheckpointRepository {
+	mock := &MockCheckpointRepository{ctrl: ctrl}
+	mock.recorder = &MockCheckpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckpointRepository) EXPECT() *MockCheckpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCheckpoint mocks base method.
+func (m *MockCheckpointRepository) DeleteCheckpoint(ctx context.Context, accountID, fingerprint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCheckpoint", ctx, accountID, fingerprint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCheckpoint indicates an expected call of DeleteCheckpoint.
+func (mr *MockCheckpointRepositoryMockRecorder) DeleteCheckpoint(ctx, accountID, fingerprint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCheckpoint", reflect.TypeOf((*MockCheckpointRepository)(nil).DeleteCheckpoint), ctx, accountID, fingerprint)
+}
+
+// GetCheckpoint mocks base method.
+func (m *MockCheckpointRepository) GetCheckpoint(ctx context.Context, accountID, fingerprint string) (*model.ProcessingCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpoint", ctx, accountID, fingerprint)
+	ret0, _ := ret[0].(*model.ProcessingCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpoint indicates an expected call of GetCheckpoint.
+func (mr *MockCheckpointRepositoryMockRecorder) GetCheckpoint(ctx, accountID, fingerprint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockCheckpointRepository)(nil).GetCheckpoint), ctx, accountID, fingerprint)
+}
+
+// SaveCheckpoint mocks base method.
+func (m *MockCheckpointRepository) SaveCheckpoint(ctx context.Context, checkpoint *model.ProcessingCheckpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCheckpoint", ctx, checkpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCheckpoint indicates an expected call of SaveCheckpoint.
+func (mr *MockCheckpointRepositoryMockRecorder) SaveCheckpoint(ctx, checkpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCheckpoint", reflect.TypeOf((*MockCheckpointRepository)(nil).SaveCheckpoint), ctx, checkpoint)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/customer_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/customer_repository.go -destination=internal/mocks/mock_customer_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCustomer mocks base method.
+func (m *MockCustomerRepository) GetCustomer(ctx context.Context, customerID string) (*model.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomer", ctx, customerID)
+	ret0, _ := ret[0].(*model.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomer indicates an expected call of GetCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomer(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomer), ctx, customerID)
+}
+
+// SaveCustomer mocks base method.
+func (m *MockCustomerRepository) SaveCustomer(ctx context.Context, customer *model.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCustomer", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCustomer indicates an expected call of SaveCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) SaveCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).SaveCustomer), ctx, customer)
+}
@@ -3,13 +3,14 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/ports/email_sender.go -destination=internal/adapters/mocks/mock_email_sender.go -package=mocks
+//	mockgen -source=internal/ports/email_sender.go -destination=internal/mocks/mock_email_sender.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	ports "transaction-processor/internal/ports"
 
@@ -41,18 +42,98 @@ func (m *MockEmailSender) EXPECT() *MockEmailSenderMockRecorder {
 	return m.recorder
 }
 
+// SendConsolidatedSummaryEmail mocks base method.
+func (m *MockEmailSender) SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ports.ConsolidatedSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConsolidatedSummaryEmail", ctx, recipient, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConsolidatedSummaryEmail indicates an expected call of SendConsolidatedSummaryEmail.
+func (mr *MockEmailSenderMockRecorder) SendConsolidatedSummaryEmail(ctx, recipient, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConsolidatedSummaryEmail", reflect.TypeOf((*MockEmailSender)(nil).SendConsolidatedSummaryEmail), ctx, recipient, summary)
+}
+
+// SendSummaryEmail mocks base method.
+func (m *MockEmailSender) SendSummaryEmail(ctx context.Context, recipient string, summary ports.EmailSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSummaryEmail", ctx, recipient, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSummaryEmail indicates an expected call of SendSummaryEmail.
+func (mr *MockEmailSenderMockRecorder) SendSummaryEmail(ctx, recipient, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSummaryEmail", reflect.TypeOf((*MockEmailSender)(nil).SendSummaryEmail), ctx, recipient, summary)
+}
+
+// MockPreviewEmailSender is a mock of PreviewEmailSender interface.
+type MockPreviewEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewEmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockPreviewEmailSenderMockRecorder is the mock recorder for MockPreviewEmailSender.
+type MockPreviewEmailSenderMockRecorder struct {
+	mock *MockPreviewEmailSender
+}
+
+// NewMockPreviewEmailSender creates a new mock instance.
+func NewMockPreviewEmailSender(ctrl *gomock.Controller) *MockPreviewEmailSender {
+	mock := &MockPreviewEmailSender{ctrl: ctrl}
+	mock.recorder = &MockPreviewEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewEmailSender) EXPECT() *MockPreviewEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// Previews mocks base method.
+func (m *MockPreviewEmailSender) Previews() []ports.EmailPreview {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Previews")
+	ret0, _ := ret[0].([]ports.EmailPreview)
+	return ret0
+}
+
+// Previews indicates an expected call of Previews.
+func (mr *MockPreviewEmailSenderMockRecorder) Previews() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Previews", reflect.TypeOf((*MockPreviewEmailSender)(nil).Previews))
+}
+
+// SendConsolidatedSummaryEmail mocks base method.
+func (m *MockPreviewEmailSender) SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ports.ConsolidatedSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendConsolidatedSummaryEmail", ctx, recipient, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendConsolidatedSummaryEmail indicates an expected call of SendConsolidatedSummaryEmail.
+func (mr *MockPreviewEmailSenderMockRecorder) SendConsolidatedSummaryEmail(ctx, recipient, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendConsolidatedSummaryEmail", reflect.TypeOf((*MockPreviewEmailSender)(nil).SendConsolidatedSummaryEmail), ctx, recipient, summary)
+}
+
 // SendSummaryEmail mocks base method.
-func (m *MockEmailSender) SendSummaryEmail(recipient string, summary ports.EmailSummary) error {
+func (m *MockPreviewEmailSender) SendSummaryEmail(ctx context.Context, recipient string, summary ports.EmailSummary) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendSummaryEmail", recipient, summary)
+	ret := m.ctrl.Call(m, "SendSummaryEmail", ctx, recipient, summary)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendSummaryEmail indicates an expected call of SendSummaryEmail.
-func (mr *MockEmailSenderMockRecorder) SendSummaryEmail(recipient, summary any) *gomock.Call {
+func (mr *MockPreviewEmailSenderMockRecorder) SendSummaryEmail(ctx, recipient, summary any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSummaryEmail", reflect.TypeOf((*MockEmailSender)(nil).SendSummaryEmail), recipient, summary)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSummaryEmail", reflect.TypeOf((*MockPreviewEmailSender)(nil).SendSummaryEmail), ctx, recipient, summary)
 }
 
 // MockMailDialer is a mock of MailDialer interface.
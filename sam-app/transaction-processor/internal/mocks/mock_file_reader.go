@@ -3,13 +3,14 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/ports/file_reader.go -destination=internal/adapters/mocks/mock_file_reader.go -package=mocks
+//	mockgen -source=internal/ports/file_reader.go -destination=internal/mocks/mock_file_reader.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	model "transaction-processor/internal/domain/model"
 
@@ -41,16 +42,70 @@ func (m *MockFileReader) EXPECT() *MockFileReaderMockRecorder {
 }
 
 // ReadTransactions mocks base method.
-func (m *MockFileReader) ReadTransactions(filePath string) ([]*model.Transaction, error) {
+func (m *MockFileReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadTransactions", filePath)
+	ret := m.ctrl.Call(m, "ReadTransactions", ctx, filePath)
 	ret0, _ := ret[0].([]*model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadTransactions indicates an expected call of ReadTransactions.
-func (mr *MockFileReaderMockRecorder) ReadTransactions(filePath any) *gomock.Call {
+func (mr *MockFileReaderMockRecorder) ReadTransactions(ctx, filePath any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTransactions", reflect.TypeOf((*MockFileReader)(nil).ReadTransactions), filePath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTransactions", reflect.TypeOf((*MockFileReader)(nil).ReadTransactions), ctx, filePath)
+}
+
+// MockStatementReader is a mock of StatementReader interface.
+type MockStatementReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementReaderMockRecorder is the mock recorder for MockStatementReader.
+type MockStatementReaderMockRecorder struct {
+	mock *MockStatementReader
+}
+
+// NewMockStatementReader creates a new mock instance.
+func NewMockStatementReader(ctrl *gomock.Controller) *MockStatementReader {
+	mock := &MockStatementReader{ctrl: ctrl}
+	mock.recorder = &MockStatementReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementReader) EXPECT() *MockStatementReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadStatement mocks base method.
+func (m *MockStatementReader) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadStatement", ctx, filePath)
+	ret0, _ := ret[0].(*model.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadStatement indicates an expected call of ReadStatement.
+func (mr *MockStatementReaderMockRecorder) ReadStatement(ctx, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadStatement", reflect.TypeOf((*MockStatementReader)(nil).ReadStatement), ctx, filePath)
+}
+
+// ReadTransactions mocks base method.
+func (m *MockStatementReader) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTransactions", ctx, filePath)
+	ret0, _ := ret[0].([]*model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadTransactions indicates an expected call of ReadTransactions.
+func (mr *MockStatementReaderMockRecorder) ReadTransactions(ctx, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTransactions", reflect.TypeOf((*MockStatementReader)(nil).ReadTransactions), ctx, filePath)
 }
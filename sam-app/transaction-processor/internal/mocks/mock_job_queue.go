@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/job_queue.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/job_queue.go -destination=internal/mocks/mock_job_queue.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSQSClient is a mock of SQSClient interface.
+type MockSQSClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSQSClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSQSClientMockRecorder is the mock recorder for MockSQSClient.
+type MockSQSClientMockRecorder struct {
+	mock *MockSQSClient
+}
+
+// NewMockSQSClient creates a new mock instance.
+func NewMockSQSClient(ctrl *gomock.Controller) *MockSQSClient {
+	mock := &MockSQSClient{ctrl: ctrl}
+	mock.recorder = &MockSQSClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSQSClient) EXPECT() *MockSQSClientMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *MockSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendMessage", varargs...)
+	ret0, _ := ret[0].(*sqs.SendMessageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockSQSClientMockRecorder) SendMessage(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockSQSClient)(nil).SendMessage), varargs...)
+}
+
+// MockJobQueue is a mock of JobQueue interface.
+type MockJobQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockJobQueueMockRecorder is the mock recorder for MockJobQueue.
+type MockJobQueueMockRecorder struct {
+	mock *MockJobQueue
+}
+
+// NewMockJobQueue creates a new mock instance.
+func NewMockJobQueue(ctrl *gomock.Controller) *MockJobQueue {
+	mock := &MockJobQueue{ctrl: ctrl}
+	mock.recorder = &MockJobQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobQueue) EXPECT() *MockJobQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockJobQueue) Enqueue(ctx context.Context, jobID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, jobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobQueueMockRecorder) Enqueue(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobQueue)(nil).Enqueue), ctx, jobID)
+}
+
+// MockJobDeadLetterQueue is a mock of JobDeadLetterQueue interface.
+type MockJobDeadLetterQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobDeadLetterQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockJobDeadLetterQueueMockRecorder is the mock recorder for MockJobDeadLetterQueue.
+type MockJobDeadLetterQueueMockRecorder struct {
+	mock *MockJobDeadLetterQueue
+}
+
+// NewMockJobDeadLetterQueue creates a new mock instance.
+func NewMockJobDeadLetterQueue(ctrl *gomock.Controller) *MockJobDeadLetterQueue {
+	mock := &MockJobDeadLetterQueue{ctrl: ctrl}
+	mock.recorder = &MockJobDeadLetterQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobDeadLetterQueue) EXPECT() *MockJobDeadLetterQueueMockRecorder {
+	return m.recorder
+}
+
+// DeadLetter mocks base method.
+func (m *MockJobDeadLetterQueue) DeadLetter(ctx context.Context, jobID, reason string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetter", ctx, jobID, reason, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetter indicates an expected call of DeadLetter.
+func (mr *MockJobDeadLetterQueueMockRecorder) DeadLetter(ctx, jobID, reason, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetter", reflect.TypeOf((*MockJobDeadLetterQueue)(nil).DeadLetter), ctx, jobID, reason, attempts)
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/object_storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/object_storage.go -destination=internal/mocks/mock_object_storage.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	s3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockS3Client is a mock of S3Client interface.
+type MockS3Client struct {
+	ctrl     *gomock.Controller
+	recorder *MockS3ClientMockRecorder
+	isgomock struct{}
+}
+
+// MockS3ClientMockRecorder is the mock recorder for MockS3Client.
+type MockS3ClientMockRecorder struct {
+	mock *MockS3Client
+}
+
+// NewMockS3Client creates a new mock instance.
+func NewMockS3Client(ctrl *gomock.Controller) *MockS3Client {
+	mock := &MockS3Client{ctrl: ctrl}
+	mock.recorder = &MockS3ClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockS3Client) EXPECT() *MockS3ClientMockRecorder {
+	return m.recorder
+}
+
+// GetObject mocks base method.
+func (m *MockS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetObject", varargs...)
+	ret0, _ := ret[0].(*s3.GetObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockS3ClientMockRecorder) GetObject(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockS3Client)(nil).GetObject), varargs...)
+}
+
+// MockS3Presigner is a mock of S3Presigner interface.
+type MockS3Presigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockS3PresignerMockRecorder
+	isgomock struct{}
+}
+
+// MockS3PresignerMockRecorder is the mock recorder for MockS3Presigner.
+type MockS3PresignerMockRecorder struct {
+	mock *MockS3Presigner
+}
+
+// NewMockS3Presigner creates a new mock instance.
+func NewMockS3Presigner(ctrl *gomock.Controller) *MockS3Presigner {
+	mock := &MockS3Presigner{ctrl: ctrl}
+	mock.recorder = &MockS3PresignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockS3Presigner) EXPECT() *MockS3PresignerMockRecorder {
+	return m.recorder
+}
+
+// PresignPutObject mocks base method.
+func (m *MockS3Presigner) PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PresignPutObject", varargs...)
+	ret0, _ := ret[0].(*v4.PresignedHTTPRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignPutObject indicates an expected call of PresignPutObject.
+func (mr *MockS3PresignerMockRecorder) PresignPutObject(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignPutObject", reflect.TypeOf((*MockS3Presigner)(nil).PresignPutObject), varargs...)
+}
+
+// MockObjectStorage is a mock of ObjectStorage interface.
+type MockObjectStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockObjectStorageMockRecorder is the mock recorder for MockObjectStorage.
+type MockObjectStorageMockRecorder struct {
+	mock *MockObjectStorage
+}
+
+// NewMockObjectStorage creates a new mock instance.
+func NewMockObjectStorage(ctrl *gomock.Controller) *MockObjectStorage {
+	mock := &MockObjectStorage{ctrl: ctrl}
+	mock.recorder = &MockObjectStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectStorage) EXPECT() *MockObjectStorageMockRecorder {
+	return m.recorder
+}
+
+// DownloadObject mocks base method.
+func (m *MockObjectStorage) DownloadObject(ctx context.Context, key, filePath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadObject", ctx, key, filePath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadObject indicates an expected call of DownloadObject.
+func (mr *MockObjectStorageMockRecorder) DownloadObject(ctx, key, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadObject", reflect.TypeOf((*MockObjectStorage)(nil).DownloadObject), ctx, key, filePath)
+}
+
+// PresignUpload mocks base method.
+func (m *MockObjectStorage) PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUpload", ctx, key, contentType, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignUpload indicates an expected call of PresignUpload.
+func (mr *MockObjectStorageMockRecorder) PresignUpload(ctx, key, contentType, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUpload", reflect.TypeOf((*MockObjectStorage)(nil).PresignUpload), ctx, key, contentType, expires)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/outbox_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/outbox_repository.go -destination=internal/mocks/mock_outbox_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetNotification mocks base method.
+func (m *MockOutboxRepository) GetNotification(ctx context.Context, notificationID string) (*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotification", ctx, notificationID)
+	ret0, _ := ret[0].(*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotification indicates an expected call of GetNotification.
+func (mr *MockOutboxRepositoryMockRecorder) GetNotification(ctx, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotification", reflect.TypeOf((*MockOutboxRepository)(nil).GetNotification), ctx, notificationID)
+}
+
+// ListDueNotifications mocks base method.
+func (m *MockOutboxRepository) ListDueNotifications(ctx context.Context, at time.Time, limit int) ([]*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueNotifications", ctx, at, limit)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueNotifications indicates an expected call of ListDueNotifications.
+func (mr *MockOutboxRepositoryMockRecorder) ListDueNotifications(ctx, at, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueNotifications", reflect.TypeOf((*MockOutboxRepository)(nil).ListDueNotifications), ctx, at, limit)
+}
+
+// SaveNotification mocks base method.
+func (m *MockOutboxRepository) SaveNotification(ctx context.Context, notification *model.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotification indicates an expected call of SaveNotification.
+func (mr *MockOutboxRepositoryMockRecorder) SaveNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockOutboxRepository)(nil).SaveNotification), ctx, notification)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/secret_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/secret_store.go -destination=internal/mocks/mock_secret_store.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSecretsManagerClient is a mock of SecretsManagerClient interface.
+type MockSecretsManagerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsManagerClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSecretsManagerClientMockRecorder is the mock recorder for MockSecretsManagerClient.
+type MockSecretsManagerClientMockRecorder struct {
+	mock *MockSecretsManagerClient
+}
+
+// NewMockSecretsManagerClient creates a new mock instance.
+func NewMockSecretsManagerClient(ctrl *gomock.Controller) *MockSecretsManagerClient {
+	mock := &MockSecretsManagerClient{ctrl: ctrl}
+	mock.recorder = &MockSecretsManagerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsManagerClient) EXPECT() *MockSecretsManagerClientMockRecorder {
+	return m.recorder
+}
+
+// GetSecretValue mocks base method.
+func (m *MockSecretsManagerClient) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSecretValue", varargs...)
+	ret0, _ := ret[0].(*secretsmanager.GetSecretValueOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretValue indicates an expected call of GetSecretValue.
+func (mr *MockSecretsManagerClientMockRecorder) GetSecretValue(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretsManagerClient)(nil).GetSecretValue), varargs...)
+}
+
+// MockSecretStore is a mock of SecretStore interface.
+type MockSecretStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSecretStoreMockRecorder is the mock recorder for MockSecretStore.
+type MockSecretStoreMockRecorder struct {
+	mock *MockSecretStore
+}
+
+// NewMockSecretStore creates a new mock instance.
+func NewMockSecretStore(ctrl *gomock.Controller) *MockSecretStore {
+	mock := &MockSecretStore{ctrl: ctrl}
+	mock.recorder = &MockSecretStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretStore) EXPECT() *MockSecretStoreMockRecorder {
+	return m.recorder
+}
+
+// GetSecret mocks base method.
+func (m *MockSecretStore) GetSecret(ctx context.Context, secretID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", ctx, secretID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockSecretStoreMockRecorder) GetSecret(ctx, secretID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretStore)(nil).GetSecret), ctx, secretID)
+}
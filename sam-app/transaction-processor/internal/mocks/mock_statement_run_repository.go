@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/statement_run_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/statement_run_repository.go -destination=internal/mocks/mock_statement_run_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementRunRepository is a mock of StatementRunRepository interface.
+type MockStatementRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementRunRepositoryMockRecorder is the mock recorder for MockStatementRunRepository.
+type MockStatementRunRepositoryMockRecorder struct {
+	mock *MockStatementRunRepository
+}
+
+// NewMockStatementRunRepository creates a new mock instance.
+func NewMockStatementRunRepository(ctrl *gomock.Controller) *MockStatementRunRepository {
+	mock := &MockStatementRunRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRunRepository) EXPECT() *MockStatementRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetStatementRun mocks base method.
+func (m *MockStatementRunRepository) GetStatementRun(ctx context.Context, period string) (*model.StatementRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementRun", ctx, period)
+	ret0, _ := ret[0].(*model.StatementRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementRun indicates an expected call of GetStatementRun.
+func (mr *MockStatementRunRepositoryMockRecorder) GetStatementRun(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementRun", reflect.TypeOf((*MockStatementRunRepository)(nil).GetStatementRun), ctx, period)
+}
+
+// SaveStatementRun mocks base method.
+func (m *MockStatementRunRepository) SaveStatementRun(ctx context.Context, run *model.StatementRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveStatementRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveStatementRun indicates an expected call of SaveStatementRun.
+func (mr *MockStatementRunRepositoryMockRecorder) SaveStatementRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStatementRun", reflect.TypeOf((*MockStatementRunRepository)(nil).SaveStatementRun), ctx, run)
+}
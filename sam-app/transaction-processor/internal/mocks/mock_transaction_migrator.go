@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/transaction_migrator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/transaction_migrator.go -destination=internal/mocks/mock_transaction_migrator.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionMigrator is a mock of TransactionMigrator interface.
+type MockTransactionMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionMigratorMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionMigratorMockRecorder is the mock recorder for MockTransactionMigrator.
+type MockTransactionMigratorMockRecorder struct {
+	mock *MockTransactionMigrator
+}
+
+// NewMockTransactionMigrator creates a new mock instance.
+func NewMockTransactionMigrator(ctrl *gomock.Controller) *MockTransactionMigrator {
+	mock := &MockTransactionMigrator{ctrl: ctrl}
+	mock.recorder = &MockTransactionMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionMigrator) EXPECT() *MockTransactionMigratorMockRecorder {
+	return m.recorder
+}
+
+// MigrateTransactions mocks base method.
+func (m *MockTransactionMigrator) MigrateTransactions(ctx context.Context, accountID, startAfter string, limit int) (int, int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateTransactions", ctx, accountID, startAfter, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// MigrateTransactions indicates an expected call of MigrateTransactions.
+func (mr *MockTransactionMigratorMockRecorder) MigrateTransactions(ctx, accountID, startAfter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateTransactions", reflect.TypeOf((*MockTransactionMigrator)(nil).MigrateTransactions), ctx, accountID, startAfter, limit)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: transaction-processor/internal/ports/transaction_repository.go
+// Source: internal/ports/transaction_repository.go
 //
 // Generated by this command:
 //
-//	mockgen -source=transaction-processor/internal/ports/transaction_repository.go -destination=transaction-processor/internal/mocks/mock_transaction_repository.go -package=mocks
+//	mockgen -source=internal/ports/transaction_repository.go -destination=internal/mocks/mock_transaction_repository.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -43,6 +43,66 @@ func (m *MockDynamoDBClient) EXPECT() *MockDynamoDBClientMockRecorder {
 	return m.recorder
 }
 
+// BatchGetItem mocks base method.
+func (m *MockDynamoDBClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchGetItem", varargs...)
+	ret0, _ := ret[0].(*dynamodb.BatchGetItemOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetItem indicates an expected call of BatchGetItem.
+func (mr *MockDynamoDBClientMockRecorder) BatchGetItem(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetItem", reflect.TypeOf((*MockDynamoDBClient)(nil).BatchGetItem), varargs...)
+}
+
+// DeleteItem mocks base method.
+func (m *MockDynamoDBClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteItem", varargs...)
+	ret0, _ := ret[0].(*dynamodb.DeleteItemOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockDynamoDBClientMockRecorder) DeleteItem(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockDynamoDBClient)(nil).DeleteItem), varargs...)
+}
+
+// GetItem mocks base method.
+func (m *MockDynamoDBClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetItem", varargs...)
+	ret0, _ := ret[0].(*dynamodb.GetItemOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockDynamoDBClientMockRecorder) GetItem(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockDynamoDBClient)(nil).GetItem), varargs...)
+}
+
 // PutItem mocks base method.
 func (m *MockDynamoDBClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +123,26 @@ func (mr *MockDynamoDBClientMockRecorder) PutItem(ctx, params any, optFns ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutItem", reflect.TypeOf((*MockDynamoDBClient)(nil).PutItem), varargs...)
 }
 
+// Query mocks base method.
+func (m *MockDynamoDBClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Query", varargs...)
+	ret0, _ := ret[0].(*dynamodb.QueryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockDynamoDBClientMockRecorder) Query(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDynamoDBClient)(nil).Query), varargs...)
+}
+
 // Scan mocks base method.
 func (m *MockDynamoDBClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
 	m.ctrl.T.Helper()
@@ -107,45 +187,91 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 	return m.recorder
 }
 
+// GetAccountOwner mocks base method.
+func (m *MockTransactionRepository) GetAccountOwner(ctx context.Context, accountID string) (*model.AccountOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountOwner", ctx, accountID)
+	ret0, _ := ret[0].(*model.AccountOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountOwner indicates an expected call of GetAccountOwner.
+func (mr *MockTransactionRepositoryMockRecorder) GetAccountOwner(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountOwner", reflect.TypeOf((*MockTransactionRepository)(nil).GetAccountOwner), ctx, accountID)
+}
+
 // GetTransactions mocks base method.
-func (m *MockTransactionRepository) GetTransactions(accountID string) ([]*model.Transaction, error) {
+func (m *MockTransactionRepository) GetTransactions(ctx context.Context, accountID string) ([]*model.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactions", accountID)
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, accountID)
 	ret0, _ := ret[0].([]*model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTransactions indicates an expected call of GetTransactions.
-func (mr *MockTransactionRepositoryMockRecorder) GetTransactions(accountID any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) GetTransactions(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactions), ctx, accountID)
+}
+
+// GetTransactionsByID mocks base method.
+func (m *MockTransactionRepository) GetTransactionsByID(ctx context.Context, accountID string, ids []string) (map[string]*model.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionsByID", ctx, accountID, ids)
+	ret0, _ := ret[0].(map[string]*model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionsByID indicates an expected call of GetTransactionsByID.
+func (mr *MockTransactionRepositoryMockRecorder) GetTransactionsByID(ctx, accountID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByID", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactionsByID), ctx, accountID, ids)
+}
+
+// ListAccounts mocks base method.
+func (m *MockTransactionRepository) ListAccounts(ctx context.Context, startAfter string, limit int) ([]model.AccountOwner, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, startAfter, limit)
+	ret0, _ := ret[0].([]model.AccountOwner)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockTransactionRepositoryMockRecorder) ListAccounts(ctx, startAfter, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactions), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockTransactionRepository)(nil).ListAccounts), ctx, startAfter, limit)
 }
 
 // SaveAccount mocks base method.
-func (m *MockTransactionRepository) SaveAccount(accountID string, summary ports.EmailSummary) error {
+func (m *MockTransactionRepository) SaveAccount(ctx context.Context, owner model.AccountOwner, summary ports.EmailSummary) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveAccount", accountID, summary)
+	ret := m.ctrl.Call(m, "SaveAccount", ctx, owner, summary)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveAccount indicates an expected call of SaveAccount.
-func (mr *MockTransactionRepositoryMockRecorder) SaveAccount(accountID, summary any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) SaveAccount(ctx, owner, summary any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockTransactionRepository)(nil).SaveAccount), accountID, summary)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockTransactionRepository)(nil).SaveAccount), ctx, owner, summary)
 }
 
 // SaveTransaction mocks base method.
-func (m *MockTransactionRepository) SaveTransaction(tx *model.Transaction) error {
+func (m *MockTransactionRepository) SaveTransaction(ctx context.Context, tx *model.Transaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveTransaction", tx)
+	ret := m.ctrl.Call(m, "SaveTransaction", ctx, tx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveTransaction indicates an expected call of SaveTransaction.
-func (mr *MockTransactionRepositoryMockRecorder) SaveTransaction(tx any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) SaveTransaction(ctx, tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).SaveTransaction), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).SaveTransaction), ctx, tx)
 }
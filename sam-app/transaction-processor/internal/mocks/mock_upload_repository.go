@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/upload_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/upload_repository.go -destination=internal/mocks/mock_upload_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "transaction-processor/internal/domain/model"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadRepository is a mock of UploadRepository interface.
+type MockUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUploadRepositoryMockRecorder is the mock recorder for MockUploadRepository.
+type MockUploadRepositoryMockRecorder struct {
+	mock *MockUploadRepository
+}
+
+// NewMockUploadRepository creates a new mock instance.
+func NewMockUploadRepository(ctrl *gomock.Controller) *MockUploadRepository {
+	mock := &MockUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadRepository) EXPECT() *MockUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimUpload mocks base method.
+func (m *MockUploadRepository) ClaimUpload(ctx context.Context, upload *model.Upload, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUpload", ctx, upload, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimUpload indicates an expected call of ClaimUpload.
+func (mr *MockUploadRepositoryMockRecorder) ClaimUpload(ctx, upload, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUpload", reflect.TypeOf((*MockUploadRepository)(nil).ClaimUpload), ctx, upload, at)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadRepository) GetUpload(ctx context.Context, uploadID string) (*model.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, uploadID)
+	ret0, _ := ret[0].(*model.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadRepositoryMockRecorder) GetUpload(ctx, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadRepository)(nil).GetUpload), ctx, uploadID)
+}
+
+// SaveUpload mocks base method.
+func (m *MockUploadRepository) SaveUpload(ctx context.Context, upload *model.Upload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUpload indicates an expected call of SaveUpload.
+func (mr *MockUploadRepositoryMockRecorder) SaveUpload(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUpload", reflect.TypeOf((*MockUploadRepository)(nil).SaveUpload), ctx, upload)
+}
@@ -2,5 +2,53 @@ package models
 
 // RequestBody represents the expected structure of the POST request body
 type RequestBody struct {
-	Email string `json:"email" validate:"required,email"`
-}
\ No newline at end of file
+	Email      string `json:"email" validate:"required,email"`
+	AccountID  string `json:"accountId,omitempty"`
+	CSVProfile string `json:"csvProfile,omitempty"`
+	DryRun     bool   `json:"dryRun,omitempty"`
+}
+
+// StatementUploadRequest represents the form fields of the statement upload POST request
+type StatementUploadRequest struct {
+	Email      string `validate:"required,email"`
+	AccountID  string
+	CSVProfile string
+	DryRun     bool
+}
+
+// UploadRequestBody represents the expected structure of the presigned upload POST request body
+type UploadRequestBody struct {
+	Email       string `json:"email" validate:"required,email"`
+	AccountID   string `json:"accountId,omitempty"`
+	CSVProfile  string `json:"csvProfile,omitempty"`
+	FileName    string `json:"fileName" validate:"required"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// BudgetRequestBody represents the expected structure of the budget PUT request body
+type BudgetRequestBody struct {
+	AccountID      string  `json:"accountId,omitempty"`
+	Category       string  `json:"category" validate:"required"`
+	MonthlyLimit   float64 `json:"monthlyLimit" validate:"gte=0"`
+	AlertThreshold float64 `json:"alertThreshold" validate:"gte=0,lte=1"`
+}
+
+// ReplayRequest represents the admin event replaying the stored transactions of an account. From and
+// To are optional YYYY-MM-DD days bounding the replayed transactions.
+type ReplayRequest struct {
+	Operation   string `json:"operation"`
+	AccountID   string `json:"accountId" validate:"required"`
+	From        string `json:"from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	To          string `json:"to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ResendEmail bool   `json:"resendEmail,omitempty"`
+	Email       string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// MigrationRequest represents the admin event copying the legacy transactions, stored before transactions
+// were partitioned by account, to an account. The account defaults to the configured ACCOUNT_ID and
+// StartAfter resumes a migration that stopped before the deadline.
+type MigrationRequest struct {
+	Operation  string `json:"operation"`
+	AccountID  string `json:"accountId,omitempty"`
+	StartAfter string `json:"startAfter,omitempty"`
+}
@@ -0,0 +1,229 @@
+package models
+
+import (
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// RecurringItemResponse represents a recurring item in the forecast response
+type RecurringItemResponse struct {
+	Amount     float64 `json:"amount"`
+	IsCredit   bool    `json:"isCredit"`
+	DayOfMonth int     `json:"dayOfMonth"`
+}
+
+// ForecastResponse represents the body returned by the forecast endpoint
+type ForecastResponse struct {
+	Month            string                  `json:"month"`
+	Year             int                     `json:"year"`
+	OpeningBalance   float64                 `json:"openingBalance"`
+	ProjectedCredit  float64                 `json:"projectedCredit"`
+	ProjectedDebit   float64                 `json:"projectedDebit"`
+	ProjectedBalance float64                 `json:"projectedBalance"`
+	LowerBound       float64                 `json:"lowerBound"`
+	UpperBound       float64                 `json:"upperBound"`
+	MonthsOfHistory  int                     `json:"monthsOfHistory"`
+	RecurringItems   []RecurringItemResponse `json:"recurringItems"`
+	Warnings         []string                `json:"warnings,omitempty"`
+}
+
+// NewForecastResponse creates a ForecastResponse from a Forecast
+func NewForecastResponse(forecast *model.Forecast) ForecastResponse {
+	response := ForecastResponse{
+		Month:            forecast.Month.String(),
+		Year:             forecast.Year,
+		OpeningBalance:   forecast.OpeningBalance,
+		ProjectedCredit:  forecast.ProjectedCredit,
+		ProjectedDebit:   forecast.ProjectedDebit,
+		ProjectedBalance: forecast.ProjectedBalance,
+		LowerBound:       forecast.LowerBound,
+		UpperBound:       forecast.UpperBound,
+		MonthsOfHistory:  forecast.MonthsOfHistory,
+		RecurringItems:   []RecurringItemResponse{},
+	}
+
+	for _, item := range forecast.RecurringItems {
+		response.RecurringItems = append(response.RecurringItems, RecurringItemResponse{
+			Amount:     item.Amount,
+			IsCredit:   item.IsCredit,
+			DayOfMonth: item.DayOfMonth,
+		})
+	}
+
+	if forecast.IsProjectedNegative() {
+		response.Warnings = append(response.Warnings, "projected closing balance is negative")
+	} else if forecast.MayGoNegative() {
+		response.Warnings = append(response.Warnings, "closing balance could become negative")
+	}
+
+	return response
+}
+
+// UploadResponse represents the body returned by the presigned upload endpoint
+type UploadResponse struct {
+	UploadID  string            `json:"uploadId"`
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+// NewUploadResponse creates an UploadResponse from an Upload and its presigned URL
+func NewUploadResponse(upload *model.Upload, url string) UploadResponse {
+	response := UploadResponse{
+		UploadID:  upload.ID,
+		Key:       upload.Key,
+		URL:       url,
+		Method:    "PUT",
+		ExpiresAt: upload.ExpiresAt,
+	}
+
+	// The statement is stored with the content type it was registered with
+	if upload.ContentType != "" {
+		response.Headers = map[string]string{"Content-Type": upload.ContentType}
+	}
+
+	return response
+}
+
+// JobResponse represents the body returned by the asynchronous processing and job status endpoints
+type JobResponse struct {
+	JobID     string    `json:"jobId"`
+	Status    string    `json:"status"`
+	Processed int       `json:"processed"`
+	Total     int       `json:"total"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewJobResponse creates a JobResponse from a Job
+func NewJobResponse(job *model.Job) JobResponse {
+	return JobResponse{
+		JobID:     job.ID,
+		Status:    string(job.Status),
+		Processed: job.Processed,
+		Total:     job.Total,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+}
+
+// SummaryResponse represents the summary of an account in the dry run and replay responses
+type SummaryResponse struct {
+	TotalBalance             float64        `json:"totalBalance"`
+	AvailableBalance         float64        `json:"availableBalance"`
+	PendingTransactionCount  int            `json:"pendingTransactionCount"`
+	MonthlyTransactionCounts map[string]int `json:"monthlyTransactionCounts"`
+	AverageCreditAmount      float64        `json:"averageCreditAmount"`
+	AverageDebitAmount       float64        `json:"averageDebitAmount"`
+	InterestEarned           float64        `json:"interestEarned"`
+	FeesCharged              float64        `json:"feesCharged"`
+}
+
+// NewSummaryResponse creates a SummaryResponse from an EmailSummary
+func NewSummaryResponse(summary ports.EmailSummary) SummaryResponse {
+	return SummaryResponse{
+		TotalBalance:             summary.TotalBalance,
+		AvailableBalance:         summary.AvailableBalance,
+		PendingTransactionCount:  summary.PendingTransactionCount,
+		MonthlyTransactionCounts: summary.MonthlyTransactionCounts,
+		AverageCreditAmount:      summary.AverageCreditAmount,
+		AverageDebitAmount:       summary.AverageDebitAmount,
+		InterestEarned:           summary.InterestEarned,
+		FeesCharged:              summary.FeesCharged,
+	}
+}
+
+// ValidationResponse represents the validation report in the dry run response
+type ValidationResponse struct {
+	TransactionCount int       `json:"transactionCount"`
+	CreditCount      int       `json:"creditCount"`
+	DebitCount       int       `json:"debitCount"`
+	PendingCount     int       `json:"pendingCount"`
+	TotalCredit      float64   `json:"totalCredit"`
+	TotalDebit       float64   `json:"totalDebit"`
+	FirstDate        time.Time `json:"firstDate"`
+	LastDate         time.Time `json:"lastDate"`
+	BalancesVerified bool      `json:"balancesVerified"`
+	Warnings         []string  `json:"warnings"`
+}
+
+// DryRunResponse represents the body returned when a statement is processed as a dry run
+type DryRunResponse struct {
+	Summary    SummaryResponse    `json:"summary"`
+	Validation ValidationResponse `json:"validation"`
+	Subject    string             `json:"subject"`
+	EmailHTML  string             `json:"emailHtml"`
+}
+
+// NewDryRunResponse creates a DryRunResponse from the summary, the validation report and the rendered
+// email of a dry run
+func NewDryRunResponse(summary ports.EmailSummary, report model.ValidationReport, email ports.EmailPreview) DryRunResponse {
+	response := DryRunResponse{
+		Summary: NewSummaryResponse(summary),
+		Validation: ValidationResponse{
+			TransactionCount: report.TransactionCount,
+			CreditCount:      report.CreditCount,
+			DebitCount:       report.DebitCount,
+			PendingCount:     report.PendingCount,
+			TotalCredit:      report.TotalCredit,
+			TotalDebit:       report.TotalDebit,
+			FirstDate:        report.FirstDate,
+			LastDate:         report.LastDate,
+			BalancesVerified: report.BalancesVerified,
+			Warnings:         []string{},
+		},
+		Subject:   email.Subject,
+		EmailHTML: email.HTML,
+	}
+
+	response.Validation.Warnings = append(response.Validation.Warnings, report.Warnings...)
+
+	return response
+}
+
+// ReplayResponse represents the result of the admin event replaying the stored transactions of an account
+type ReplayResponse struct {
+	AccountID        string          `json:"accountId"`
+	TransactionCount int             `json:"transactionCount"`
+	Summary          SummaryResponse `json:"summary"`
+	Saved            bool            `json:"saved"`
+	EmailSent        bool            `json:"emailSent"`
+	Recipient        string          `json:"recipient,omitempty"`
+}
+
+// NewReplayResponse creates a ReplayResponse from the owner, summary and recipient of a replayed account,
+// and whether its summary was saved
+func NewReplayResponse(owner model.AccountOwner, transactionCount int, summary ports.EmailSummary, saved bool, recipient string) ReplayResponse {
+	return ReplayResponse{
+		AccountID:        owner.AccountID,
+		TransactionCount: transactionCount,
+		Summary:          NewSummaryResponse(summary),
+		Saved:            saved,
+		EmailSent:        recipient != "",
+		Recipient:        recipient,
+	}
+}
+
+// OutboxDispatchResponse represents the outcome of the admin event sweeping the outbox
+type OutboxDispatchResponse struct {
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+// MigrationResponse represents the outcome of the admin event migrating the legacy transactions. When
+// Done is false, the event is sent again with StartAfter set to Next to resume the migration.
+type MigrationResponse struct {
+	AccountID string `json:"accountId"`
+	Copied    int    `json:"copied"`
+	Skipped   int    `json:"skipped"`
+	Done      bool   `json:"done"`
+	Next      string `json:"next,omitempty"`
+}
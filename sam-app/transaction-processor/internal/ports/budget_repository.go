@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+)
+
+// BudgetRepository defines the interface for storing and retrieving category budgets
+type BudgetRepository interface {
+	// SaveBudget creates or replaces the budget of a category
+	SaveBudget(ctx context.Context, budget *model.Budget) error
+
+	// GetBudgets retrieves all budgets for an account
+	GetBudgets(ctx context.Context, accountID string) ([]*model.Budget, error)
+}
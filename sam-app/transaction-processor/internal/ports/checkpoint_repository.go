@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+)
+
+// CheckpointRepository defines the interface for storing and retrieving the checkpoints of the
+// processing of large statement files
+type CheckpointRepository interface {
+	// SaveCheckpoint creates or replaces the checkpoint of a file for an account
+	SaveCheckpoint(ctx context.Context, checkpoint *model.ProcessingCheckpoint) error
+	// GetCheckpoint retrieves the checkpoint of the file with the given fingerprint for an account,
+	// returning nil if it does not exist
+	GetCheckpoint(ctx context.Context, accountID, fingerprint string) (*model.ProcessingCheckpoint, error)
+	// DeleteCheckpoint removes the checkpoint of a file once it was fully processed
+	DeleteCheckpoint(ctx context.Context, accountID, fingerprint string) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+)
+
+// CustomerRepository defines the interface for storing and retrieving customers
+type CustomerRepository interface {
+	// SaveCustomer creates or replaces a customer and the list of accounts it owns
+	SaveCustomer(ctx context.Context, customer *model.Customer) error
+
+	// GetCustomer retrieves a customer by ID, returning nil if it does not exist
+	GetCustomer(ctx context.Context, customerID string) (*model.Customer, error)
+}
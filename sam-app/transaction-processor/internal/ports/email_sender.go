@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"context"
 	"gopkg.in/mail.v2"
 	"transaction-processor/internal/domain/model"
 )
@@ -8,24 +9,135 @@ import (
 // EmailSummary contains the data to be included in the summary email
 type EmailSummary struct {
 	TotalBalance             float64
+	AvailableBalance         float64
+	PendingTransactionCount  int
 	MonthlyTransactionCounts map[string]int
 	AverageCreditAmount      float64
 	AverageDebitAmount       float64
+	Forecast                 *model.Forecast
+	Budgets                  []model.BudgetStatus
+	InterestEarned           float64
+	FeesCharged              float64
+	Charges                  []*model.Transaction
+	StatementBalance         *model.DeclaredBalance
+	StatementFiles           []StatementFileSummary
+	Period                   *model.StatementPeriod
+	Reconciliation           *model.Reconciliation
+}
+
+// StatementFileSummary summarizes one of the files of an archived statement
+type StatementFileSummary struct {
+	Source           string
+	TransactionCount int
+	ClosingBalance   *model.DeclaredBalance
+}
+
+// NewStatementFileSummaries summarizes each part of a statement read from an archive
+func NewStatementFileSummaries(statement *model.Statement) []StatementFileSummary {
+	var files []StatementFileSummary
+	for _, part := range statement.Parts {
+		files = append(files, StatementFileSummary{
+			Source:           part.Source,
+			TransactionCount: len(part.Transactions),
+			ClosingBalance:   part.ClosingBalance,
+		})
+	}
+	return files
+}
+
+// GetBudgetWarnings returns the budgets that are close to or over their limit
+func (s EmailSummary) GetBudgetWarnings() []model.BudgetStatus {
+	var warnings []model.BudgetStatus
+	for _, status := range s.Budgets {
+		if status.IsOverspent() || status.IsNearLimit() {
+			warnings = append(warnings, status)
+		}
+	}
+	return warnings
+}
+
+// AccountSummary is the summary of one of the accounts of a consolidated summary
+type AccountSummary struct {
+	AccountID string
+	Summary   EmailSummary
+}
+
+// ConsolidatedSummary contains the data to be included in the summary email of a customer with several accounts
+type ConsolidatedSummary struct {
+	CustomerName string
+	Accounts     []AccountSummary
+	NetWorth     float64
+}
+
+// NewConsolidatedSummary creates a ConsolidatedSummary whose net worth is the sum of the account balances
+func NewConsolidatedSummary(customerName string, accounts []AccountSummary) ConsolidatedSummary {
+	var netWorth float64
+	for _, account := range accounts {
+		netWorth += account.Summary.TotalBalance
+	}
+
+	return ConsolidatedSummary{
+		CustomerName: customerName,
+		Accounts:     accounts,
+		NetWorth:     netWorth,
+	}
 }
 
 // EmailSender defines the interface for sending summary emails
 type EmailSender interface {
 	// SendSummaryEmail sends a summary email with account information
-	SendSummaryEmail(recipient string, summary EmailSummary) error
+	SendSummaryEmail(ctx context.Context, recipient string, summary EmailSummary) error
+
+	// SendConsolidatedSummaryEmail sends a summary email with a section per account of a customer
+	SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ConsolidatedSummary) error
+}
+
+// notificationKeyKey is the context key of WithNotificationKey
+type notificationKeyKey struct{}
+
+// WithNotificationKey returns a context in which the email sent identifies itself with key, such as the
+// account and file of a processing run. Senders queueing emails queue the email of a key once, so a
+// retried run does not send it twice.
+func WithNotificationKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, notificationKeyKey{}, key)
+}
+
+// NotificationKey returns the key the email sent with ctx identifies itself with, or "" if it has none
+func NotificationKey(ctx context.Context) string {
+	key, _ := ctx.Value(notificationKeyKey{}).(string)
+	return key
+}
+
+// EmailPreview is an email rendered by a PreviewEmailSender instead of being sent. The summary is
+// only set for single account summaries.
+type EmailPreview struct {
+	Recipient string
+	Subject   string
+	HTML      string
+	Summary   *EmailSummary
+}
+
+// PreviewEmailSender is an EmailSender that renders the emails and keeps them instead of sending them
+type PreviewEmailSender interface {
+	EmailSender
+
+	// Previews returns the emails rendered so far
+	Previews() []EmailPreview
 }
 
 // NewEmailSummaryFromAccount creates an EmailSummary from an Account
 func NewEmailSummaryFromAccount(account *model.Account) EmailSummary {
 	return EmailSummary{
 		TotalBalance:             account.GetTotalBalance(),
+		AvailableBalance:         account.GetAvailableBalance(),
+		PendingTransactionCount:  len(account.GetPendingTransactions()),
 		MonthlyTransactionCounts: account.GetMonthlyTransactionCounts(),
 		AverageCreditAmount:      account.GetAverageCreditAmount(),
 		AverageDebitAmount:       account.GetAverageDebitAmount(),
+		Forecast:                 account.Forecast(),
+		InterestEarned:           account.GetInterestEarned(),
+		FeesCharged:              account.GetFeesCharged(),
+		Charges:                  account.GetGeneratedTransactions(),
 	}
 }
 
@@ -1,11 +1,37 @@
 package ports
 
 import (
+	"context"
 	"transaction-processor/internal/domain/model"
 )
 
 // FileReader defines the interface for reading transaction data from a file
 type FileReader interface {
 	// ReadTransactions reads transactions from a file and returns them
-	ReadTransactions(filePath string) ([]*model.Transaction, error)
+	ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error)
+}
+
+// StatementReader is implemented by readers of statement formats that declare balances
+// alongside the transactions, such as OFX
+type StatementReader interface {
+	FileReader
+
+	// ReadStatement reads the transactions and declared balances of a statement file
+	ReadStatement(ctx context.Context, filePath string) (*model.Statement, error)
+}
+
+// lenientBalancesKey is the context key of WithLenientBalances
+type lenientBalancesKey struct{}
+
+// WithLenientBalances returns a context in which statement readers do not fail with
+// model.ErrBalanceMismatch. Statements whose declared balances do not match their transactions are
+// read anyway, with the mismatches recorded in their BalanceMismatches, so a preview can warn about them.
+func WithLenientBalances(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lenientBalancesKey{}, true)
+}
+
+// LenientBalances reports whether statements are read with lenient balances
+func LenientBalances(ctx context.Context) bool {
+	lenient, _ := ctx.Value(lenientBalancesKey{}).(bool)
+	return lenient
 }
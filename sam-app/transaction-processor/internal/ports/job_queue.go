@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+type SQSClient interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+// JobQueue defines the interface for queueing asynchronous jobs to the worker
+type JobQueue interface {
+	// Enqueue queues a job by ID
+	Enqueue(ctx context.Context, jobID string) error
+}
+
+// JobDeadLetterQueue defines the interface for setting aside the jobs that exhausted their attempts
+type JobDeadLetterQueue interface {
+	// DeadLetter sets a job aside with the reason of its last failure
+	DeadLetter(ctx context.Context, jobID, reason string, attempts int) error
+}
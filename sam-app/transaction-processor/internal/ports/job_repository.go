@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+)
+
+// JobRepository defines the interface for storing and retrieving asynchronous jobs
+type JobRepository interface {
+	// SaveJob creates or replaces a job
+	SaveJob(ctx context.Context, job *model.Job) error
+
+	// GetJob retrieves a job by ID, returning nil if it does not exist
+	GetJob(ctx context.Context, jobID string) (*model.Job, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+type S3Presigner interface {
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// ObjectStorage defines the interface for the storage of uploaded statement files
+type ObjectStorage interface {
+	// PresignUpload returns a URL that uploads an object with a PUT request until it expires
+	PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error)
+
+	// DownloadObject writes the content of an object into a file
+	DownloadObject(ctx context.Context, key, filePath string) error
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+	"transaction-processor/internal/domain/model"
+)
+
+// OutboxRepository defines the interface for storing the notifications waiting to be delivered
+type OutboxRepository interface {
+	// SaveNotification creates a notification, or replaces it if it was not changed since it was read,
+	// returning model.ErrNotificationChanged otherwise. The version of the notification is advanced.
+	SaveNotification(ctx context.Context, notification *model.Notification) error
+
+	// GetNotification retrieves a notification by ID, returning nil if it does not exist
+	GetNotification(ctx context.Context, notificationID string) (*model.Notification, error)
+
+	// ListDueNotifications returns up to limit pending notifications whose next attempt is not after at,
+	// the earliest first
+	ListDueNotifications(ctx context.Context, at time.Time, limit int) ([]*model.Notification, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// SecretStore defines the interface for reading credentials kept out of the configuration
+type SecretStore interface {
+	// GetSecret returns the value of a secret by name or ARN
+	GetSecret(ctx context.Context, secretID string) (string, error)
+}
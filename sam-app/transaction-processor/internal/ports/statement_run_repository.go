@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"transaction-processor/internal/domain/model"
+)
+
+// StatementRunRepository defines the interface for storing and retrieving the checkpoints of the
+// scheduled monthly statement runs
+type StatementRunRepository interface {
+	// SaveStatementRun creates or replaces the checkpoint of a run
+	SaveStatementRun(ctx context.Context, run *model.StatementRun) error
+	// GetStatementRun retrieves the run of a "YYYY-MM" period, returning nil if it does not exist
+	GetStatementRun(ctx context.Context, period string) (*model.StatementRun, error)
+}
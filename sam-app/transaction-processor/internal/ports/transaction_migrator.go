@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+)
+
+// TransactionMigrator defines the interface for copying the transactions of the single-account
+// transactions table, keyed by ID only, to the table partitioned by account
+type TransactionMigrator interface {
+	// MigrateTransactions copies a page of up to limit legacy transactions to the given account, starting
+	// after the given ID or at the first transaction when it is empty. Transactions the account already
+	// stores are left untouched and counted as skipped. The returned ID is where the next page starts
+	// after, empty after the last page.
+	MigrateTransactions(ctx context.Context, accountID, startAfter string, limit int) (copied, skipped int, next string, err error)
+}
@@ -9,16 +9,32 @@ import (
 type DynamoDBClient interface {
 	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
 	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
 }
 
 // TransactionRepository defines the interface for storing and retrieving transactions
 type TransactionRepository interface {
 	// SaveTransaction saves a transaction to the database
-	SaveTransaction(tx *model.Transaction) error
+	SaveTransaction(ctx context.Context, tx *model.Transaction) error
 
-	// SaveAccount saves account information to the database
-	SaveAccount(accountID string, summary EmailSummary) error
+	// GetTransactionsByID retrieves the transactions of an account with the given IDs, keyed by ID.
+	// Transactions that do not exist are left out.
+	GetTransactionsByID(ctx context.Context, accountID string, ids []string) (map[string]*model.Transaction, error)
+
+	// SaveAccount saves account information and its owner to the database
+	SaveAccount(ctx context.Context, owner model.AccountOwner, summary EmailSummary) error
+
+	// GetAccountOwner retrieves the owner of an account, returning nil if the account does not exist
+	GetAccountOwner(ctx context.Context, accountID string) (*model.AccountOwner, error)
+
+	// ListAccounts retrieves a page of up to limit account owners, starting after the given account
+	// or at the first account when it is empty. The returned account is where the next page starts
+	// after, empty after the last page.
+	ListAccounts(ctx context.Context, startAfter string, limit int) ([]model.AccountOwner, string, error)
 
 	// GetTransactions retrieves all transactions for an account
-	GetTransactions(accountID string) ([]*model.Transaction, error)
+	GetTransactions(ctx context.Context, accountID string) ([]*model.Transaction, error)
 }
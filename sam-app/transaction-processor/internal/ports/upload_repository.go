@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+	"transaction-processor/internal/domain/model"
+)
+
+// UploadRepository defines the interface for storing and retrieving statement uploads
+type UploadRepository interface {
+	// SaveUpload creates or replaces an upload
+	SaveUpload(ctx context.Context, upload *model.Upload) error
+
+	// ClaimUpload saves an upload claimed at the given time, provided the stored upload is still pending
+	// or its previous claim ended, returning model.ErrUploadClaimed otherwise
+	ClaimUpload(ctx context.Context, upload *model.Upload, at time.Time) error
+
+	// GetUpload retrieves an upload by ID, returning nil if it does not exist
+	GetUpload(ctx context.Context, uploadID string) (*model.Upload, error)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// BudgetService orchestrates the category budget management use case
+type BudgetService struct {
+	budgetRepository ports.BudgetRepository
+}
+
+// NewBudgetService creates a new BudgetService
+func NewBudgetService(budgetRepository ports.BudgetRepository) *BudgetService {
+	return &BudgetService{
+		budgetRepository: budgetRepository,
+	}
+}
+
+// SetBudget creates or replaces the monthly budget of a category for an account
+func (s *BudgetService) SetBudget(ctx context.Context, accountID, category string, monthlyLimit, alertThreshold float64) (*model.Budget, error) {
+	if model.NormalizeCategory(category) == "" {
+		return nil, fmt.Errorf("budget category cannot be empty")
+	}
+	if monthlyLimit < 0 {
+		return nil, fmt.Errorf("budget monthly limit cannot be negative")
+	}
+
+	budget := model.NewBudget(accountID, category, monthlyLimit, alertThreshold)
+	if err := s.budgetRepository.SaveBudget(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// GetBudgets returns the budgets configured for an account
+func (s *BudgetService) GetBudgets(ctx context.Context, accountID string) ([]*model.Budget, error) {
+	return s.budgetRepository.GetBudgets(ctx, accountID)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+var (
+	// ErrCustomerNotFound is returned when the authenticated caller is not a known customer
+	ErrCustomerNotFound = errors.New("customer not found")
+
+	// ErrAccountNotOwned is returned when the requested account does not belong to the caller
+	ErrAccountNotOwned = errors.New("account does not belong to the customer")
+
+	// ErrAuthenticationRequired is returned when an anonymous caller targets an account other than the default one
+	ErrAuthenticationRequired = errors.New("authentication is required to access this account")
+
+	// ErrAccountRequired is returned when the account cannot be inferred from the caller
+	ErrAccountRequired = errors.New("account ID is required for customers with several accounts")
+)
+
+// CustomerService resolves which account a request targets and who owns it
+type CustomerService struct {
+	customerRepository ports.CustomerRepository
+	defaultAccountID   string
+}
+
+// NewCustomerService creates a new CustomerService.
+// The customer repository may be nil, in which case callers are not resolved to customers.
+func NewCustomerService(customerRepository ports.CustomerRepository, defaultAccountID string) *CustomerService {
+	return &CustomerService{
+		customerRepository: customerRepository,
+		defaultAccountID:   defaultAccountID,
+	}
+}
+
+// ResolveAccount returns the customer of the authenticated caller, if any, and the account the request targets.
+// Anonymous requests can only target the default account, as nothing proves they own any other. Authenticated
+// customers may only target accounts they own, and may omit the account when they own exactly one.
+func (s *CustomerService) ResolveAccount(ctx context.Context, callerID, requestedAccountID string) (*model.Customer, string, error) {
+	if callerID == "" || s.customerRepository == nil {
+		if requestedAccountID != "" && requestedAccountID != s.defaultAccountID {
+			return nil, "", ErrAuthenticationRequired
+		}
+		return nil, s.defaultAccountID, nil
+	}
+
+	customer, err := s.customerRepository.GetCustomer(ctx, callerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if customer == nil {
+		return nil, "", ErrCustomerNotFound
+	}
+
+	if requestedAccountID != "" {
+		if !customer.OwnsAccount(requestedAccountID) {
+			return nil, "", ErrAccountNotOwned
+		}
+		return customer, requestedAccountID, nil
+	}
+
+	if len(customer.AccountIDs) != 1 {
+		return nil, "", ErrAccountRequired
+	}
+
+	return customer, customer.AccountIDs[0], nil
+}
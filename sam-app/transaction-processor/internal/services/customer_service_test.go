@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCustomerService_ResolveAccount(t *testing.T) {
+	customer := &model.Customer{ID: "cust1", AccountIDs: []string{"checking", "savings"}}
+	singleAccountCustomer := &model.Customer{ID: "cust2", AccountIDs: []string{"checking"}}
+
+	tests := []struct {
+		name              string
+		callerID          string
+		requestedAccount  string
+		storedCustomer    *model.Customer
+		lookup            bool
+		expectedAccountID string
+		expectedCustomer  *model.Customer
+		expectedErr       error
+	}{
+		{
+			name:              "anonymous request falls back to the default account",
+			expectedAccountID: "default",
+		},
+		{
+			name:              "anonymous request may name the default account",
+			requestedAccount:  "default",
+			expectedAccountID: "default",
+		},
+		{
+			name:             "anonymous request cannot target another account",
+			requestedAccount: "savings",
+			expectedErr:      ErrAuthenticationRequired,
+		},
+		{
+			name:              "customer targets an owned account",
+			callerID:          "cust1",
+			requestedAccount:  "savings",
+			storedCustomer:    customer,
+			lookup:            true,
+			expectedAccountID: "savings",
+			expectedCustomer:  customer,
+		},
+		{
+			name:             "customer targets another customer's account",
+			callerID:         "cust1",
+			requestedAccount: "other",
+			storedCustomer:   customer,
+			lookup:           true,
+			expectedErr:      ErrAccountNotOwned,
+		},
+		{
+			name:              "customer with a single account may omit it",
+			callerID:          "cust2",
+			storedCustomer:    singleAccountCustomer,
+			lookup:            true,
+			expectedAccountID: "checking",
+			expectedCustomer:  singleAccountCustomer,
+		},
+		{
+			name:           "customer with several accounts must choose one",
+			callerID:       "cust1",
+			storedCustomer: customer,
+			lookup:         true,
+			expectedErr:    ErrAccountRequired,
+		},
+		{
+			name:        "unknown caller",
+			callerID:    "ghost",
+			lookup:      true,
+			expectedErr: ErrCustomerNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockCustomerRepository(ctrl)
+			if tt.lookup {
+				mockRepo.EXPECT().GetCustomer(gomock.Any(), tt.callerID).Return(tt.storedCustomer, nil)
+			}
+
+			service := NewCustomerService(mockRepo, "default")
+
+			resolvedCustomer, accountID, err := service.ResolveAccount(context.Background(), tt.callerID, tt.requestedAccount)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAccountID, accountID)
+			assert.Equal(t, tt.expectedCustomer, resolvedCustomer)
+		})
+	}
+}
+
+func TestCustomerService_ResolveAccount_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCustomerRepository(ctrl)
+	mockRepo.EXPECT().GetCustomer(gomock.Any(), "cust1").Return(nil, errors.New("boom"))
+
+	service := NewCustomerService(mockRepo, "default")
+
+	_, _, err := service.ResolveAccount(context.Background(), "cust1", "")
+	assert.EqualError(t, err, "boom")
+}
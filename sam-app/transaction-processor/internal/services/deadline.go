@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// deadlineMargin is the time left before the deadline of a request at which processing stops
+// starting work, enough to finish the writes in flight and record the outcome
+const deadlineMargin = 10 * time.Second
+
+// ErrDeadlineReached is returned when processing stops early to finish before the deadline of its request
+var ErrDeadlineReached = errors.New("processing stopped before the deadline")
+
+// PartialResultError reports a statement whose processing stopped before the deadline, with how many
+// of its transactions were persisted. Processed transactions are stored, so processing the statement
+// again resumes it and settles them into the same state.
+type PartialResultError struct {
+	Processed int
+	Total     int
+	Err       error
+}
+
+// Error describes how much of the statement was processed
+func (e *PartialResultError) Error() string {
+	message := fmt.Sprintf("%v after processing %d of %d transactions", ErrDeadlineReached, e.Processed, e.Total)
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns ErrDeadlineReached and the errors of the transactions that were processed, if any
+func (e *PartialResultError) Unwrap() []error {
+	return []error{ErrDeadlineReached, e.Err}
+}
+
+// withDeadlineMargin returns a context cancelled deadlineMargin before the deadline of ctx. Contexts
+// without a deadline are only cancelled with ctx.
+func withDeadlineMargin(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-deadlineMargin))
+}
+
+// stoppedEarly reports whether work scheduled with stop was cut short by the deadline margin while
+// ctx, which the work in flight runs with, is still live
+func stoppedEarly(ctx, stop context.Context) bool {
+	return stop.Err() != nil && ctx.Err() == nil
+}
+
+// onlyStopped reports whether err, returned by work scheduled with stop, only reports the work left
+// undone once stop was done. Joined errors are checked one by one, so a failure of the work that did
+// run is not mistaken for the deadline.
+func onlyStopped(err error, stop context.Context) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			if !onlyStopped(err, stop) {
+				return false
+			}
+		}
+		return true
+	}
+	return stop.Err() != nil && errors.Is(err, stop.Err())
+}
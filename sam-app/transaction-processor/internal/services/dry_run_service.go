@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// DryRunResult is what processing a statement file would produce
+type DryRunResult struct {
+	Summary    ports.EmailSummary
+	Validation model.ValidationReport
+	Email      ports.EmailPreview
+}
+
+// DryRunService previews what processing a statement file would produce without writing anything
+// or emailing anyone. It runs the TransactionService with a repository that drops every write and
+// an email sender that only renders the summary email.
+type DryRunService struct {
+	fileReader            ports.FileReader
+	emailSender           ports.PreviewEmailSender
+	transactionRepository ports.TransactionRepository
+	budgetRepository      ports.BudgetRepository
+	chargesEngine         *model.ChargesEngine
+	now                   func() time.Time
+}
+
+// NewDryRunService creates a new DryRunService. The budget repository, only read from, and the charges
+// engine may be nil.
+func NewDryRunService(
+	fileReader ports.FileReader,
+	emailSender ports.PreviewEmailSender,
+	transactionRepository ports.TransactionRepository,
+	budgetRepository ports.BudgetRepository,
+	chargesEngine *model.ChargesEngine,
+) *DryRunService {
+	return &DryRunService{
+		fileReader:            fileReader,
+		emailSender:           emailSender,
+		transactionRepository: transactionRepository,
+		budgetRepository:      budgetRepository,
+		chargesEngine:         chargesEngine,
+		now:                   time.Now,
+	}
+}
+
+// Preview processes a statement file for an account and returns the summary, the validation report of
+// the statement and the summary email that would be sent. Statements whose declared balances do not
+// match their transactions are previewed with the mismatch as a warning of the report. The account is
+// previewed on its own, so no consolidated summary is rendered for customers owning several accounts.
+func (s *DryRunService) Preview(ctx context.Context, filePath, emailRecipient, accountID string) (*DryRunResult, error) {
+	// Declared balances that do not match are reported in the validation report instead of failing
+	ctx = ports.WithLenientBalances(ctx)
+
+	reader := &statementRecorder{reader: s.fileReader}
+	service := NewTransactionService(reader, s.emailSender, s.transactionRepository, s.budgetRepository, s.chargesEngine)
+	if err := service.ProcessTransactionsAndSendSummary(ctx, filePath, emailRecipient, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	previews := s.emailSender.Previews()
+	if len(previews) == 0 || previews[len(previews)-1].Summary == nil {
+		return nil, fmt.Errorf("no summary email was rendered")
+	}
+	email := previews[len(previews)-1]
+
+	return &DryRunResult{
+		Summary:    *email.Summary,
+		Validation: model.ValidateStatement(reader.statement, s.now().UTC()),
+		Email:      email,
+	}, nil
+}
+
+// statementRecorder reads statements with another reader and keeps the last statement read
+type statementRecorder struct {
+	reader    ports.FileReader
+	statement *model.Statement
+}
+
+// ReadTransactions reads the transactions of a statement
+func (r *statementRecorder) ReadTransactions(ctx context.Context, filePath string) ([]*model.Transaction, error) {
+	statement, err := r.ReadStatement(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return statement.Transactions, nil
+}
+
+// ReadStatement reads a statement, with the declared balances when the reader provides them
+func (r *statementRecorder) ReadStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	var statement *model.Statement
+	if statementReader, ok := r.reader.(ports.StatementReader); ok {
+		read, err := statementReader.ReadStatement(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		statement = read
+	} else {
+		transactions, err := r.reader.ReadTransactions(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		statement = &model.Statement{Transactions: transactions}
+	}
+
+	r.statement = statement
+	return statement, nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"transaction-processor/internal/adapters"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+)
+
+func TestDryRunService_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileReader := mocks.NewMockFileReader(ctrl)
+	emailSender := adapters.NewPreviewEmailSender()
+
+	service := NewDryRunService(mockFileReader, emailSender, adapters.NewNoopTransactionRepository(), nil, nil)
+	service.now = func() time.Time { return time.Date(2025, time.July, 31, 0, 0, 0, 0, time.UTC) }
+
+	july := time.Date(2025, time.July, 10, 0, 0, 0, 0, time.UTC)
+	mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{
+		{ID: "1", Date: july, Amount: 200, IsCredit: true},
+		{ID: "2", Date: july, Amount: 50, IsCredit: false},
+		{ID: "2", Date: july, Amount: 50, IsCredit: false},
+		{ID: "3", Date: july.AddDate(0, 1, 0), Amount: 0, IsCredit: false},
+	}, nil)
+
+	result, err := service.Preview(context.Background(), "transactions.csv", "user@example.com", "acc123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, result.Summary.TotalBalance)
+	assert.Equal(t, "user@example.com", result.Email.Recipient)
+	assert.Contains(t, result.Email.HTML, "100.00")
+
+	assert.Equal(t, 4, result.Validation.TransactionCount)
+	assert.Equal(t, 1, result.Validation.CreditCount)
+	assert.Equal(t, 3, result.Validation.DebitCount)
+	assert.Equal(t, 200.0, result.Validation.TotalCredit)
+	assert.Equal(t, 100.0, result.Validation.TotalDebit)
+	assert.Equal(t, july, result.Validation.FirstDate)
+	assert.False(t, result.Validation.BalancesVerified)
+	assert.Equal(t, []string{
+		"transaction 3 has a zero amount",
+		"transaction 3 is dated in the future (2025-08-10)",
+		"transaction ID 2 is repeated",
+	}, result.Validation.Warnings)
+}
+
+func TestDryRunService_Preview_ReadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileReader := mocks.NewMockFileReader(ctrl)
+	emailSender := adapters.NewPreviewEmailSender()
+
+	service := NewDryRunService(mockFileReader, emailSender, adapters.NewNoopTransactionRepository(), nil, nil)
+
+	readErr := errors.New("unreadable")
+	mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(nil, readErr)
+
+	result, err := service.Preview(context.Background(), "transactions.csv", "user@example.com", "acc123")
+
+	assert.ErrorIs(t, err, readErr)
+	assert.Nil(t, result)
+	assert.Empty(t, emailSender.Previews())
+}
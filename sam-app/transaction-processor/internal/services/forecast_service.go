@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// ErrNoTransactionHistory is returned when an account has no stored transactions to forecast from
+var ErrNoTransactionHistory = errors.New("no transaction history available for forecasting")
+
+// ForecastService orchestrates the cash-flow forecasting use case
+type ForecastService struct {
+	transactionRepository ports.TransactionRepository
+}
+
+// NewForecastService creates a new ForecastService
+func NewForecastService(transactionRepository ports.TransactionRepository) *ForecastService {
+	return &ForecastService{
+		transactionRepository: transactionRepository,
+	}
+}
+
+// GetForecast projects next month's closing balance from the stored transactions of an account
+func (s *ForecastService) GetForecast(ctx context.Context, accountID string) (*model.Forecast, error) {
+	transactions, err := s.transactionRepository.GetTransactions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	account := model.NewAccount()
+	for _, tx := range transactions {
+		account.AddTransaction(tx)
+	}
+
+	forecast := account.Forecast()
+	if forecast == nil {
+		return nil, ErrNoTransactionHistory
+	}
+
+	return forecast, nil
+}
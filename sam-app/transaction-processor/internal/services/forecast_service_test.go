@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestForecastService_GetForecast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	service := NewForecastService(mockRepo)
+
+	accountID := "acc123"
+	transactions := []*model.Transaction{
+		{ID: "1", Date: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), Amount: 1000, IsCredit: true},
+		{ID: "2", Date: time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC), Amount: 400, IsCredit: false},
+		{ID: "3", Date: time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC), Amount: 100, IsCredit: false},
+		{ID: "4", Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), Amount: 1000, IsCredit: true},
+		{ID: "5", Date: time.Date(2025, time.February, 5, 0, 0, 0, 0, time.UTC), Amount: 400, IsCredit: false},
+		{ID: "6", Date: time.Date(2025, time.February, 18, 0, 0, 0, 0, time.UTC), Amount: 300, IsCredit: false},
+	}
+
+	mockRepo.EXPECT().GetTransactions(gomock.Any(), accountID).Return(transactions, nil)
+
+	forecast, err := service.GetForecast(context.Background(), accountID)
+	assert.NoError(t, err)
+
+	// Salary and rent are recurring, the remaining spending averages 200 with a 141.42 deviation
+	assert.Equal(t, time.March, forecast.Month)
+	assert.Equal(t, 2025, forecast.Year)
+	assert.Len(t, forecast.RecurringItems, 2)
+	assert.InDelta(t, 800, forecast.OpeningBalance, 0.001)
+	assert.InDelta(t, 1000, forecast.ProjectedCredit, 0.001)
+	assert.InDelta(t, 600, forecast.ProjectedDebit, 0.001)
+	assert.InDelta(t, 1200, forecast.ProjectedBalance, 0.001)
+	assert.InDelta(t, 1200-1.96*141.4214, forecast.LowerBound, 0.01)
+	assert.InDelta(t, 1200+1.96*141.4214, forecast.UpperBound, 0.01)
+	assert.False(t, forecast.IsProjectedNegative())
+	assert.False(t, forecast.MayGoNegative())
+}
+
+func TestForecastService_GetForecast_NegativeProjection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	service := NewForecastService(mockRepo)
+
+	transactions := []*model.Transaction{
+		{ID: "1", Date: time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), Amount: 60.5, IsCredit: true},
+		{ID: "2", Date: time.Date(2025, time.July, 28, 0, 0, 0, 0, time.UTC), Amount: 100, IsCredit: false},
+	}
+
+	mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc123").Return(transactions, nil)
+
+	forecast, err := service.GetForecast(context.Background(), "acc123")
+	assert.NoError(t, err)
+	assert.Equal(t, time.August, forecast.Month)
+	assert.InDelta(t, -79, forecast.ProjectedBalance, 0.001)
+	assert.True(t, forecast.IsProjectedNegative())
+	assert.True(t, forecast.MayGoNegative())
+}
+
+func TestForecastService_GetForecast_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	service := NewForecastService(mockRepo)
+
+	t.Run("no history", func(t *testing.T) {
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc123").Return(nil, nil)
+
+		_, err := service.GetForecast(context.Background(), "acc123")
+		assert.ErrorIs(t, err, ErrNoTransactionHistory)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc123").Return(nil, errors.New("scan failed"))
+
+		_, err := service.GetForecast(context.Background(), "acc123")
+		assert.EqualError(t, err, "scan failed")
+	})
+}
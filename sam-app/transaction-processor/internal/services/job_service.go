@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// jobProgressInterval is the number of processed transactions between saves of the progress of a job
+const jobProgressInterval = 50
+
+// ErrJobNotFound is returned when a job does not exist
+var ErrJobNotFound = errors.New("job not found")
+
+// JobRunner processes the statement of a job, notifying its progress
+type JobRunner func(ctx context.Context, job *model.Job, progress ProgressFunc) error
+
+// JobService orchestrates the asynchronous processing of statements, queueing jobs for a worker,
+// tracking their status and dead-lettering the jobs that keep failing
+type JobService struct {
+	jobRepository   ports.JobRepository
+	jobQueue        ports.JobQueue
+	deadLetterQueue ports.JobDeadLetterQueue
+	maxAttempts     int
+	now             func() time.Time
+}
+
+// NewJobService creates a new JobService. Jobs failing maxAttempts times are marked failed and set
+// aside in the dead-letter queue.
+func NewJobService(jobRepository ports.JobRepository, jobQueue ports.JobQueue, deadLetterQueue ports.JobDeadLetterQueue, maxAttempts int) *JobService {
+	return &JobService{
+		jobRepository:   jobRepository,
+		jobQueue:        jobQueue,
+		deadLetterQueue: deadLetterQueue,
+		maxAttempts:     maxAttempts,
+		now:             time.Now,
+	}
+}
+
+// SubmitJob records a queued job processing a statement file for an account and queues it for the
+// worker. The customer is nil for anonymous requests.
+func (s *JobService) SubmitJob(ctx context.Context, accountID string, customer *model.Customer, email, csvProfile, source string) (*model.Job, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	job := model.NewJob(id, accountID, source, s.now().UTC())
+	job.Email = email
+	job.CSVProfile = csvProfile
+	if customer != nil {
+		job.CustomerID = customer.ID
+	}
+
+	if err := s.jobRepository.SaveJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// Jobs that cannot be queued are recorded as failed so their status does not stay queued
+	if err := s.jobQueue.Enqueue(ctx, job.ID); err != nil {
+		job.Fail(err, s.now().UTC())
+		if saveErr := s.jobRepository.SaveJob(ctx, job); saveErr != nil {
+			log.Printf("Error saving job %s: %v", job.ID, saveErr)
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetJob returns a job by ID
+func (s *JobService) GetJob(ctx context.Context, jobID string) (*model.Job, error) {
+	job, err := s.jobRepository.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+	return job, nil
+}
+
+// RunJob runs the given attempt of a queued job, counted from 1, and records its progress and outcome.
+// Finished jobs are skipped, so queue messages delivered more than once are harmless, while jobs
+// interrupted while running are run again. A failed attempt is returned so the job is retried, until
+// the last attempt fails and the job is dead-lettered instead. Attempts past the last one, left when
+// the last attempt was interrupted, dead-letter the job without running it.
+func (s *JobService) RunJob(ctx context.Context, jobID string, attempt int, run JobRunner) error {
+	job, err := s.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.IsFinished() {
+		log.Printf("Skipping job %s, already %s", job.ID, job.Status)
+		return nil
+	}
+
+	if attempt > s.maxAttempts {
+		cause := errors.New("attempt did not finish")
+		if job.Error != "" {
+			cause = errors.New(job.Error)
+		}
+		return s.deadLetter(ctx, job, cause, attempt-1)
+	}
+
+	job.Start(attempt, s.now().UTC())
+	if err := s.jobRepository.SaveJob(ctx, job); err != nil {
+		return err
+	}
+
+	// Progress is saved periodically rather than for every transaction
+	saved := 0
+	progress := func(processed, total int) {
+		job.Progress(processed, total, s.now().UTC())
+		if processed-saved < jobProgressInterval && processed < total {
+			return
+		}
+		saved = processed
+		if err := s.jobRepository.SaveJob(ctx, job); err != nil {
+			log.Printf("Error saving progress of job %s: %v", job.ID, err)
+		}
+	}
+
+	err = run(ctx, job, progress)
+	if err != nil && attempt >= s.maxAttempts {
+		return s.deadLetter(ctx, job, err, attempt)
+	}
+	if err != nil {
+		job.Retry(err, s.now().UTC())
+	} else {
+		job.Succeed(s.now().UTC())
+	}
+	if saveErr := s.jobRepository.SaveJob(ctx, job); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
+
+// deadLetter sets aside a job that failed its last attempt and records it as failed with the reason.
+// When the dead-letter queue cannot take the job, it stays queued and the error is returned so the
+// job is dead-lettered again on its next delivery.
+func (s *JobService) deadLetter(ctx context.Context, job *model.Job, cause error, attempts int) error {
+	reason := fmt.Sprintf("gave up after %d attempts: %v", attempts, cause)
+
+	if s.deadLetterQueue != nil {
+		if err := s.deadLetterQueue.DeadLetter(ctx, job.ID, reason, attempts); err != nil {
+			job.Retry(cause, s.now().UTC())
+			if saveErr := s.jobRepository.SaveJob(ctx, job); saveErr != nil {
+				return errors.Join(err, saveErr)
+			}
+			return err
+		}
+	}
+
+	log.Printf("Dead-lettering job %s: %s", job.ID, reason)
+	job.Fail(errors.New(reason), s.now().UTC())
+	return s.jobRepository.SaveJob(ctx, job)
+}
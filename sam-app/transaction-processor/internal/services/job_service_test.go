@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJobService_SubmitJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockQueue := mocks.NewMockJobQueue(ctrl)
+
+	service := NewJobService(mockJobRepo, mockQueue, nil, 3)
+
+	var saved *model.Job
+	mockJobRepo.EXPECT().
+		SaveJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job *model.Job) error {
+			assert.Equal(t, model.JobStatusQueued, job.Status)
+			saved = job
+			return nil
+		})
+	mockQueue.EXPECT().
+		Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, jobID string) error {
+			assert.Equal(t, saved.ID, jobID)
+			return nil
+		})
+
+	customer := &model.Customer{ID: "cust1", AccountIDs: []string{"acc123"}}
+	job, err := service.SubmitJob(context.Background(), "acc123", customer, "user@example.com", "eu-bank", "transactions.csv")
+
+	assert.NoError(t, err)
+	assert.Len(t, job.ID, 32)
+	assert.Equal(t, "acc123", job.AccountID)
+	assert.Equal(t, "cust1", job.CustomerID)
+	assert.Equal(t, "eu-bank", job.CSVProfile)
+	assert.Equal(t, "transactions.csv", job.Source)
+}
+
+func TestJobService_SubmitJob_EnqueueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	mockQueue := mocks.NewMockJobQueue(ctrl)
+
+	service := NewJobService(mockJobRepo, mockQueue, nil, 3)
+
+	gomock.InOrder(
+		mockJobRepo.EXPECT().SaveJob(gomock.Any(), gomock.Any()).Return(nil),
+		mockQueue.EXPECT().Enqueue(gomock.Any(), gomock.Any()).Return(errors.New("queue error")),
+		mockJobRepo.EXPECT().
+			SaveJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, job *model.Job) error {
+				assert.Equal(t, model.JobStatusFailed, job.Status)
+				assert.Equal(t, "queue error", job.Error)
+				return nil
+			}),
+	)
+
+	job, err := service.SubmitJob(context.Background(), "acc123", nil, "user@example.com", "", "transactions.csv")
+
+	assert.Error(t, err)
+	assert.Nil(t, job)
+}
+
+func TestJobService_GetJob_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockJobRepository(ctrl)
+	service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), nil, 3)
+
+	mockJobRepo.EXPECT().GetJob(gomock.Any(), "missing").Return(nil, nil)
+
+	_, err := service.GetJob(context.Background(), "missing")
+	assert.True(t, errors.Is(err, ErrJobNotFound), "expected ErrJobNotFound, got %v", err)
+}
+
+func TestJobService_RunJob(t *testing.T) {
+	newQueuedJob := func() *model.Job {
+		return model.NewJob("job1", "acc123", "transactions.csv", time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC))
+	}
+
+	t.Run("succeeded with periodic progress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), nil, 3)
+
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(newQueuedJob(), nil)
+
+		var saves []model.Job
+		mockJobRepo.EXPECT().
+			SaveJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, job *model.Job) error {
+				saves = append(saves, *job)
+				return nil
+			}).
+			AnyTimes()
+
+		err := service.RunJob(context.Background(), "job1", 1, func(ctx context.Context, job *model.Job, progress ProgressFunc) error {
+			for processed := 0; processed <= 120; processed++ {
+				progress(processed, 120)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+
+		// Saves when started, every jobProgressInterval transactions, when done and when finished
+		var processed []int
+		for _, save := range saves {
+			processed = append(processed, save.Processed)
+		}
+		assert.Equal(t, []int{0, 50, 100, 120, 120}, processed)
+		assert.Equal(t, model.JobStatusRunning, saves[0].Status)
+		assert.Equal(t, model.JobStatusSucceeded, saves[len(saves)-1].Status)
+		assert.Equal(t, 120, saves[len(saves)-1].Total)
+	})
+
+	t.Run("failed attempt is retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		mockDeadLetters := mocks.NewMockJobDeadLetterQueue(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), mockDeadLetters, 3)
+
+		processErr := errors.New("connection reset")
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(newQueuedJob(), nil)
+		gomock.InOrder(
+			mockJobRepo.EXPECT().SaveJob(gomock.Any(), gomock.Any()).Return(nil),
+			mockJobRepo.EXPECT().
+				SaveJob(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, job *model.Job) error {
+					assert.Equal(t, model.JobStatusQueued, job.Status)
+					assert.Equal(t, 2, job.Attempts)
+					assert.Equal(t, "connection reset", job.Error)
+					return nil
+				}),
+		)
+
+		err := service.RunJob(context.Background(), "job1", 2, func(context.Context, *model.Job, ProgressFunc) error { return processErr })
+		assert.True(t, errors.Is(err, processErr))
+	})
+
+	t.Run("last failed attempt is dead-lettered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		mockDeadLetters := mocks.NewMockJobDeadLetterQueue(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), mockDeadLetters, 3)
+
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(newQueuedJob(), nil)
+		gomock.InOrder(
+			mockJobRepo.EXPECT().SaveJob(gomock.Any(), gomock.Any()).Return(nil),
+			mockDeadLetters.EXPECT().DeadLetter(gomock.Any(), "job1", "gave up after 3 attempts: invalid amount", 3).Return(nil),
+			mockJobRepo.EXPECT().
+				SaveJob(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, job *model.Job) error {
+					assert.Equal(t, model.JobStatusFailed, job.Status)
+					assert.Equal(t, 3, job.Attempts)
+					assert.Equal(t, "gave up after 3 attempts: invalid amount", job.Error)
+					return nil
+				}),
+		)
+
+		err := service.RunJob(context.Background(), "job1", 3, func(context.Context, *model.Job, ProgressFunc) error { return errors.New("invalid amount") })
+		assert.NoError(t, err)
+	})
+
+	t.Run("dead-letter queue error keeps the job queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		mockDeadLetters := mocks.NewMockJobDeadLetterQueue(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), mockDeadLetters, 3)
+
+		queueErr := errors.New("queue unavailable")
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(newQueuedJob(), nil)
+		gomock.InOrder(
+			mockJobRepo.EXPECT().SaveJob(gomock.Any(), gomock.Any()).Return(nil),
+			mockDeadLetters.EXPECT().DeadLetter(gomock.Any(), "job1", gomock.Any(), 3).Return(queueErr),
+			mockJobRepo.EXPECT().
+				SaveJob(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, job *model.Job) error {
+					assert.Equal(t, model.JobStatusQueued, job.Status)
+					assert.Equal(t, "invalid amount", job.Error)
+					return nil
+				}),
+		)
+
+		err := service.RunJob(context.Background(), "job1", 3, func(context.Context, *model.Job, ProgressFunc) error { return errors.New("invalid amount") })
+		assert.True(t, errors.Is(err, queueErr))
+	})
+
+	t.Run("attempt past the last is dead-lettered without running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		mockDeadLetters := mocks.NewMockJobDeadLetterQueue(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), mockDeadLetters, 3)
+
+		job := newQueuedJob()
+		job.Start(3, time.Now())
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(job, nil)
+		mockDeadLetters.EXPECT().DeadLetter(gomock.Any(), "job1", "gave up after 3 attempts: attempt did not finish", 3).Return(nil)
+		mockJobRepo.EXPECT().
+			SaveJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, job *model.Job) error {
+				assert.Equal(t, model.JobStatusFailed, job.Status)
+				return nil
+			})
+
+		err := service.RunJob(context.Background(), "job1", 4, func(context.Context, *model.Job, ProgressFunc) error {
+			t.Error("Job past its last attempt should not run")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("finished jobs are skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockJobRepo := mocks.NewMockJobRepository(ctrl)
+		service := NewJobService(mockJobRepo, mocks.NewMockJobQueue(ctrl), nil, 3)
+
+		job := newQueuedJob()
+		job.Succeed(time.Now())
+		mockJobRepo.EXPECT().GetJob(gomock.Any(), "job1").Return(job, nil)
+
+		err := service.RunJob(context.Background(), "job1", 1, func(context.Context, *model.Job, ProgressFunc) error {
+			t.Error("Finished job should be skipped")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
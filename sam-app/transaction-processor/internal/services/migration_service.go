@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"transaction-processor/internal/ports"
+)
+
+// migrationPageSize is the number of legacy transactions copied at a time
+const migrationPageSize = 100
+
+// MigrationResult is the outcome of a run of the migration of the legacy transactions. When the run
+// stopped before the deadline, Next is the ID to resume the migration after.
+type MigrationResult struct {
+	Copied  int
+	Skipped int
+	Next    string
+	Done    bool
+}
+
+// MigrationService backfills the transactions table partitioned by account from the legacy transactions
+// table, which stored the transactions of a single account keyed by ID only
+type MigrationService struct {
+	migrator ports.TransactionMigrator
+}
+
+// NewMigrationService creates a new MigrationService
+func NewMigrationService(migrator ports.TransactionMigrator) *MigrationService {
+	return &MigrationService{
+		migrator: migrator,
+	}
+}
+
+// MigrateLegacyTransactions copies the legacy transactions to the account, page by page, starting after
+// the given ID or at the first transaction when it is empty. The run stops ahead of the deadline of ctx
+// and returns where to resume; transactions copied before are skipped, so resuming is always safe.
+func (s *MigrationService) MigrateLegacyTransactions(ctx context.Context, accountID, startAfter string) (MigrationResult, error) {
+	stop, cancel := withDeadlineMargin(ctx)
+	defer cancel()
+
+	result := MigrationResult{Next: startAfter}
+	for stop.Err() == nil {
+		copied, skipped, next, err := s.migrator.MigrateTransactions(ctx, accountID, result.Next, migrationPageSize)
+		result.Copied += copied
+		result.Skipped += skipped
+		if err != nil {
+			return result, err
+		}
+
+		result.Next = next
+		if next == "" {
+			result.Done = true
+			return result, nil
+		}
+	}
+
+	log.Printf("Migration of legacy transactions stopped before the deadline after %s", result.Next)
+	return result, nil
+}
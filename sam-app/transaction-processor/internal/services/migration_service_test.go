@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMigrationService_MigrateLegacyTransactions(t *testing.T) {
+	t.Run("copies pages until the legacy table is done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMigrator := mocks.NewMockTransactionMigrator(ctrl)
+		service := NewMigrationService(mockMigrator)
+
+		gomock.InOrder(
+			mockMigrator.EXPECT().MigrateTransactions(gomock.Any(), "acc1", "", migrationPageSize).Return(100, 0, "100", nil),
+			mockMigrator.EXPECT().MigrateTransactions(gomock.Any(), "acc1", "100", migrationPageSize).Return(30, 2, "", nil),
+		)
+
+		result, err := service.MigrateLegacyTransactions(context.Background(), "acc1", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, MigrationResult{Copied: 130, Skipped: 2, Done: true}, result)
+	})
+
+	t.Run("resumes after the given ID and reports where a failed page starts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMigrator := mocks.NewMockTransactionMigrator(ctrl)
+		service := NewMigrationService(mockMigrator)
+
+		gomock.InOrder(
+			mockMigrator.EXPECT().MigrateTransactions(gomock.Any(), "acc1", "50", migrationPageSize).Return(100, 0, "150", nil),
+			mockMigrator.EXPECT().MigrateTransactions(gomock.Any(), "acc1", "150", migrationPageSize).Return(3, 0, "", errors.New("throttled")),
+		)
+
+		result, err := service.MigrateLegacyTransactions(context.Background(), "acc1", "50")
+
+		assert.Error(t, err)
+		assert.Equal(t, MigrationResult{Copied: 103, Next: "150"}, result)
+	})
+}
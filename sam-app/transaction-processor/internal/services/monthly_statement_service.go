@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+const (
+	// statementPageSize is the number of accounts read from the accounts table at a time
+	statementPageSize = 100
+
+	// statementRunMargin is the time left before the deadline at which a run stops and checkpoints,
+	// enough to send the statement of one more account
+	statementRunMargin = 30 * time.Second
+)
+
+// ErrStatementRunIncomplete is returned when a run reaches its deadline before every account was handled
+var ErrStatementRunIncomplete = fmt.Errorf("statement run stopped before every account was handled: %w", ErrDeadlineReached)
+
+// ErrStatementRunFailed is returned when every account was handled but the statements of some failed.
+// The run is left running, so the next invocation retries them.
+var ErrStatementRunFailed = errors.New("statement run failed to send the statements of some accounts")
+
+// MonthlyStatementService sends the statement of a calendar month to the owner of every account,
+// built from the stored transactions. Runs are checkpointed after each account, so a run interrupted
+// by a timeout resumes where it left off instead of sending the statements again. Accounts whose
+// statement failed are kept in the run and retried by the next invocation.
+type MonthlyStatementService struct {
+	transactionRepository ports.TransactionRepository
+	customerRepository    ports.CustomerRepository
+	budgetRepository      ports.BudgetRepository
+	emailSender           ports.EmailSender
+	runRepository         ports.StatementRunRepository
+	now                   func() time.Time
+}
+
+// NewMonthlyStatementService creates a new MonthlyStatementService.
+// The customer and budget repositories may be nil.
+func NewMonthlyStatementService(
+	transactionRepository ports.TransactionRepository,
+	customerRepository ports.CustomerRepository,
+	budgetRepository ports.BudgetRepository,
+	emailSender ports.EmailSender,
+	runRepository ports.StatementRunRepository,
+) *MonthlyStatementService {
+	return &MonthlyStatementService{
+		transactionRepository: transactionRepository,
+		customerRepository:    customerRepository,
+		budgetRepository:      budgetRepository,
+		emailSender:           emailSender,
+		runRepository:         runRepository,
+		now:                   time.Now,
+	}
+}
+
+// SendMonthlyStatements sends the statements of a period to every account, resuming the run of the
+// period after its checkpoint. The accounts that failed earlier are retried first. A completed run is
+// not sent again. When the deadline of ctx is close, the run stops and ErrStatementRunIncomplete is
+// returned; without a deadline it runs until every account is handled. The run is only completed once
+// no account failed, and ErrStatementRunFailed is returned otherwise.
+func (s *MonthlyStatementService) SendMonthlyStatements(ctx context.Context, period model.StatementPeriod) (*model.StatementRun, error) {
+	deadline, hasDeadline := ctx.Deadline()
+
+	run, err := s.runRepository.GetStatementRun(ctx, period.Key())
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		run = model.NewStatementRun(period.Key(), s.now().UTC())
+		if err := s.runRepository.SaveStatementRun(ctx, run); err != nil {
+			return nil, err
+		}
+	} else if run.IsCompleted() {
+		log.Printf("Skipping statement run %s, already completed", run.Period)
+		return run, nil
+	} else {
+		log.Printf("Resuming statement run %s after account %s", run.Period, run.Cursor)
+	}
+
+	// Accounts that failed earlier were already passed by the cursor, so they are retried first
+	for _, accountID := range append([]string(nil), run.FailedAccounts...) {
+		if hasDeadline && s.now().Add(statementRunMargin).After(deadline) {
+			return run, ErrStatementRunIncomplete
+		}
+
+		owner, err := s.transactionRepository.GetAccountOwner(ctx, accountID)
+		if err != nil {
+			return run, err
+		}
+		if owner == nil {
+			owner = &model.AccountOwner{AccountID: accountID}
+		}
+		if err := s.handleAccount(ctx, run, *owner, period); err != nil {
+			return run, err
+		}
+	}
+
+	cursor := run.Cursor
+	for {
+		owners, next, err := s.transactionRepository.ListAccounts(ctx, cursor, statementPageSize)
+		if err != nil {
+			return run, err
+		}
+
+		for _, owner := range owners {
+			if hasDeadline && s.now().Add(statementRunMargin).After(deadline) {
+				return run, ErrStatementRunIncomplete
+			}
+			if err := s.handleAccount(ctx, run, owner, period); err != nil {
+				return run, err
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(run.FailedAccounts) > 0 {
+		return run, ErrStatementRunFailed
+	}
+
+	run.Complete(s.now().UTC())
+	if err := s.runRepository.SaveStatementRun(ctx, run); err != nil {
+		return run, err
+	}
+	return run, nil
+}
+
+// handleAccount sends the statement of an account and checkpoints the run with its outcome. Only an
+// error saving the checkpoint is returned; a failed statement is recorded in the run.
+func (s *MonthlyStatementService) handleAccount(ctx context.Context, run *model.StatementRun, owner model.AccountOwner, period model.StatementPeriod) error {
+	sent, err := s.sendStatement(ctx, owner, period)
+	switch {
+	case err != nil:
+		log.Printf("Error sending statement %s of account %s: %v", run.Period, owner.AccountID, err)
+		run.MarkFailed(owner.AccountID, err, s.now().UTC())
+	case sent:
+		run.MarkSent(owner.AccountID, s.now().UTC())
+	default:
+		run.MarkSkipped(owner.AccountID, s.now().UTC())
+	}
+	return s.runRepository.SaveStatementRun(ctx, run)
+}
+
+// sendStatement sends the statement of a period to the owner of an account. It reports false when
+// there is nothing to send: the account has no customer address or no transactions in the period.
+func (s *MonthlyStatementService) sendStatement(ctx context.Context, owner model.AccountOwner, period model.StatementPeriod) (bool, error) {
+	recipient, err := ownerRecipient(ctx, s.customerRepository, owner)
+	if err != nil {
+		return false, err
+	}
+	if recipient == "" {
+		log.Printf("Skipping statement of account %s, no customer email", owner.AccountID)
+		return false, nil
+	}
+
+	transactions, err := s.transactionRepository.GetTransactions(ctx, owner.AccountID)
+	if err != nil {
+		return false, err
+	}
+
+	// Earlier transactions make up the opening balance, later ones are left for the next statement
+	account := model.NewAccount()
+	for _, tx := range transactions {
+		switch {
+		case period.IsAfter(tx.Date):
+			continue
+		case period.IsBefore(tx.Date):
+			account.CarryForward(tx)
+		default:
+			account.AddTransaction(tx)
+		}
+	}
+	if len(account.Transactions) == 0 {
+		return false, nil
+	}
+
+	summary, err := summarizeAccount(ctx, s.budgetRepository, owner.AccountID, account)
+	if err != nil {
+		return false, err
+	}
+	summary.Period = &period
+
+	// A statement queued by a run interrupted before checkpointing it is not queued again on resume
+	ctx = ports.WithNotificationKey(ctx, "statement/"+period.Key()+"/"+owner.AccountID)
+	if err := s.emailSender.SendSummaryEmail(ctx, recipient, summary); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ownerRecipient returns the email of the customer owning an account. Accounts without a customer only
+// hold the address their last summary was requested for, which anyone could have entered, so they have
+// no recipient and an empty address is returned. The customer repository may be nil.
+func ownerRecipient(ctx context.Context, customerRepository ports.CustomerRepository, owner model.AccountOwner) (string, error) {
+	if owner.CustomerID == "" || customerRepository == nil {
+		return "", nil
+	}
+
+	customer, err := customerRepository.GetCustomer(ctx, owner.CustomerID)
+	if err != nil {
+		return "", err
+	}
+	if customer == nil || !customer.OwnsAccount(owner.AccountID) {
+		return "", nil
+	}
+	return customer.Email, nil
+}
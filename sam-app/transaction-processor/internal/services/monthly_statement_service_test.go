@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+	"transaction-processor/internal/ports"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMonthlyStatementService_SendMonthlyStatements(t *testing.T) {
+	period := model.NewMonthlyStatementPeriod(2025, time.June)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("sends the statements of every account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockCustomers := mocks.NewMockCustomerRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRuns := mocks.NewMockStatementRunRepository(ctrl)
+
+		service := NewMonthlyStatementService(mockRepo, mockCustomers, nil, mockEmailSender, mockRuns)
+
+		var saves []model.StatementRun
+		mockRuns.EXPECT().GetStatementRun(gomock.Any(), "2025-06").Return(nil, nil)
+		mockRuns.EXPECT().
+			SaveStatementRun(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, run *model.StatementRun) error {
+				saves = append(saves, *run)
+				return nil
+			}).
+			AnyTimes()
+
+		gomock.InOrder(
+			mockRepo.EXPECT().ListAccounts(gomock.Any(), "", statementPageSize).Return([]model.AccountOwner{
+				{AccountID: "acc1", Email: "anonymous@example.com"},
+				{AccountID: "acc2", CustomerID: "cust1", Email: "old@example.com"},
+			}, "acc2", nil),
+			mockRepo.EXPECT().ListAccounts(gomock.Any(), "acc2", statementPageSize).Return([]model.AccountOwner{
+				{AccountID: "acc3"},
+				{AccountID: "acc4", CustomerID: "cust2"},
+				{AccountID: "acc5", CustomerID: "cust3"},
+			}, "", nil),
+		)
+
+		// Customer accounts are sent to the customer email. Transactions before the period make up the
+		// opening balance, later ones are left out.
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust1").Return(&model.Customer{ID: "cust1", Email: "jane@example.com", AccountIDs: []string{"acc2"}}, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc2").Return([]*model.Transaction{
+			{ID: "1", Date: date(time.May, 20), Amount: 100, IsCredit: true},
+			{ID: "2", Date: date(time.June, 10), Amount: 30, IsCredit: false},
+			{ID: "3", Date: date(time.July, 1), Amount: 500, IsCredit: true},
+		}, nil)
+		mockEmailSender.EXPECT().
+			SendSummaryEmail(gomock.Any(), "jane@example.com", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+				assert.InDelta(t, 70, summary.TotalBalance, 0.001)
+				assert.Equal(t, map[string]int{"June": 1}, summary.MonthlyTransactionCounts)
+				assert.Equal(t, date(time.June, 30), summary.Period.End)
+				return nil
+			})
+
+		// Accounts without a customer are skipped, even with the address of their last summary, and so
+		// are accounts of customers no longer owning them or without transactions in the period
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust2").Return(&model.Customer{ID: "cust2", Email: "former@example.com"}, nil)
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust3").Return(&model.Customer{ID: "cust3", Email: "dormant@example.com", AccountIDs: []string{"acc5"}}, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc5").Return([]*model.Transaction{
+			{ID: "1", Date: date(time.March, 2), Amount: 10, IsCredit: true},
+		}, nil)
+
+		run, err := service.SendMonthlyStatements(context.Background(), period)
+
+		assert.NoError(t, err)
+		assert.True(t, run.IsCompleted())
+		assert.Equal(t, 1, run.Sent)
+		assert.Equal(t, 4, run.Skipped)
+
+		// Checkpoints when started, after each account and when completed
+		var cursors []string
+		for _, save := range saves {
+			cursors = append(cursors, save.Cursor)
+		}
+		assert.Equal(t, []string{"", "acc1", "acc2", "acc3", "acc4", "acc5", "acc5"}, cursors)
+	})
+
+	t.Run("resumes after the checkpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockCustomers := mocks.NewMockCustomerRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRuns := mocks.NewMockStatementRunRepository(ctrl)
+
+		service := NewMonthlyStatementService(mockRepo, mockCustomers, nil, mockEmailSender, mockRuns)
+
+		run := model.NewStatementRun("2025-06", date(time.July, 1))
+		run.MarkSent("acc1", date(time.July, 1))
+		mockRuns.EXPECT().GetStatementRun(gomock.Any(), "2025-06").Return(run, nil)
+		mockRuns.EXPECT().SaveStatementRun(gomock.Any(), gomock.Any()).Return(nil)
+
+		mockRepo.EXPECT().ListAccounts(gomock.Any(), "acc1", statementPageSize).Return([]model.AccountOwner{
+			{AccountID: "acc2", CustomerID: "cust1"},
+		}, "", nil)
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust1").Return(&model.Customer{ID: "cust1", Email: "user@example.com", AccountIDs: []string{"acc2"}}, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc2").Return(nil, errors.New("throttled"))
+
+		run, err := service.SendMonthlyStatements(context.Background(), period)
+
+		// The failed account is kept for the next invocation instead of completing the run
+		assert.True(t, errors.Is(err, ErrStatementRunFailed), "expected ErrStatementRunFailed, got %v", err)
+		assert.False(t, run.IsCompleted())
+		assert.Equal(t, 1, run.Sent)
+		assert.Equal(t, 1, run.Failed)
+		assert.Equal(t, []string{"acc2"}, run.FailedAccounts)
+		assert.Equal(t, "acc2", run.Cursor)
+		assert.Equal(t, "throttled", run.Error)
+	})
+
+	t.Run("retries the failed accounts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockCustomers := mocks.NewMockCustomerRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRuns := mocks.NewMockStatementRunRepository(ctrl)
+
+		service := NewMonthlyStatementService(mockRepo, mockCustomers, nil, mockEmailSender, mockRuns)
+
+		run := model.NewStatementRun("2025-06", date(time.July, 1))
+		run.MarkFailed("acc1", errors.New("throttled"), date(time.July, 1))
+		run.MarkSent("acc2", date(time.July, 1))
+		mockRuns.EXPECT().GetStatementRun(gomock.Any(), "2025-06").Return(run, nil)
+		mockRuns.EXPECT().SaveStatementRun(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "acc1").Return(&model.AccountOwner{AccountID: "acc1", CustomerID: "cust1"}, nil)
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust1").Return(&model.Customer{ID: "cust1", Email: "user@example.com", AccountIDs: []string{"acc1"}}, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc1").Return([]*model.Transaction{
+			{ID: "1", Date: date(time.June, 15), Amount: 250, IsCredit: true},
+		}, nil)
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).Return(nil)
+
+		// The scan resumes after the cursor, which the retry leaves in place
+		mockRepo.EXPECT().ListAccounts(gomock.Any(), "acc2", statementPageSize).Return(nil, "", nil)
+
+		run, err := service.SendMonthlyStatements(context.Background(), period)
+
+		assert.NoError(t, err)
+		assert.True(t, run.IsCompleted())
+		assert.Equal(t, 2, run.Sent)
+		assert.Equal(t, 0, run.Failed)
+		assert.Empty(t, run.FailedAccounts)
+		assert.Empty(t, run.Error)
+		assert.Equal(t, "acc2", run.Cursor)
+	})
+
+	t.Run("completed runs are not sent again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRuns := mocks.NewMockStatementRunRepository(ctrl)
+		service := NewMonthlyStatementService(mocks.NewMockTransactionRepository(ctrl), nil, nil, mocks.NewMockEmailSender(ctrl), mockRuns)
+
+		run := model.NewStatementRun("2025-06", date(time.July, 1))
+		run.Complete(date(time.July, 1))
+		mockRuns.EXPECT().GetStatementRun(gomock.Any(), "2025-06").Return(run, nil)
+
+		_, err := service.SendMonthlyStatements(context.Background(), period)
+		assert.NoError(t, err)
+	})
+
+	t.Run("stops before the deadline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockRuns := mocks.NewMockStatementRunRepository(ctrl)
+
+		service := NewMonthlyStatementService(mockRepo, nil, nil, mocks.NewMockEmailSender(ctrl), mockRuns)
+		service.now = func() time.Time { return date(time.July, 1) }
+
+		mockRuns.EXPECT().GetStatementRun(gomock.Any(), "2025-06").Return(nil, nil)
+		mockRuns.EXPECT().SaveStatementRun(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ListAccounts(gomock.Any(), "", statementPageSize).Return([]model.AccountOwner{
+			{AccountID: "acc1", Email: "user@example.com"},
+		}, "", nil)
+
+		ctx, cancel := context.WithDeadline(context.Background(), date(time.July, 1).Add(10*time.Second))
+		defer cancel()
+
+		run, err := service.SendMonthlyStatements(ctx, period)
+
+		assert.True(t, errors.Is(err, ErrStatementRunIncomplete), "expected ErrStatementRunIncomplete, got %v", err)
+		assert.True(t, errors.Is(err, ErrDeadlineReached), "expected ErrDeadlineReached, got %v", err)
+		assert.False(t, run.IsCompleted())
+		assert.Empty(t, run.Cursor)
+	})
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+const (
+	// outboxBatchSize is the number of due notifications delivered per sweep of the outbox
+	outboxBatchSize = 100
+
+	// outboxLease is how long a claimed notification is held back from other sweeps, so one
+	// interrupted before recording its outcome is attempted again after it
+	outboxLease = 5 * time.Minute
+
+	// outboxBackoff is the delay before the second attempt of a notification, doubled with each attempt
+	outboxBackoff = time.Minute
+)
+
+// OutboxEmailSender implements the EmailSender port by writing the emails to the outbox instead of
+// sending them, so a processing run does not depend on the mail server being available. The emails
+// are delivered by an OutboxDispatcher.
+type OutboxEmailSender struct {
+	outboxRepository ports.OutboxRepository
+	now              func() time.Time
+}
+
+// NewOutboxEmailSender creates a new OutboxEmailSender
+func NewOutboxEmailSender(outboxRepository ports.OutboxRepository) *OutboxEmailSender {
+	return &OutboxEmailSender{
+		outboxRepository: outboxRepository,
+		now:              time.Now,
+	}
+}
+
+// SendSummaryEmail writes a summary email to the outbox
+func (s *OutboxEmailSender) SendSummaryEmail(ctx context.Context, recipient string, summary ports.EmailSummary) error {
+	return s.write(ctx, model.NotificationKindSummary, recipient, summary)
+}
+
+// SendConsolidatedSummaryEmail writes a consolidated summary email to the outbox
+func (s *OutboxEmailSender) SendConsolidatedSummaryEmail(ctx context.Context, recipient string, summary ports.ConsolidatedSummary) error {
+	return s.write(ctx, model.NotificationKindConsolidatedSummary, recipient, summary)
+}
+
+// write saves a pending notification of the given kind with the summary it is rendered from. The
+// notification of a ports.NotificationKey is only written once: writing it again, as a retried run
+// does, leaves the queued one in place.
+func (s *OutboxEmailSender) write(ctx context.Context, kind model.NotificationKind, recipient string, summary interface{}) error {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("error encoding notification: %w", err)
+	}
+
+	key := ports.NotificationKey(ctx)
+	id, err := notificationID(kind, key)
+	if err != nil {
+		return err
+	}
+
+	err = s.outboxRepository.SaveNotification(ctx, model.NewNotification(id, kind, recipient, payload, s.now().UTC()))
+	if key != "" && errors.Is(err, model.ErrNotificationChanged) {
+		log.Printf("Skipping notification %s, already queued", id)
+		return nil
+	}
+	return err
+}
+
+// notificationID returns the ID of the notification of a kind and key: the hexadecimal SHA-256 of both
+// when there is a key, or a new random ID otherwise
+func notificationID(kind model.NotificationKind, key string) (string, error) {
+	if key == "" {
+		return newID()
+	}
+	sum := sha256.Sum256([]byte(string(kind) + "\x00" + key))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// OutboxDispatchResult counts the outcomes of the notifications of a sweep of the outbox
+type OutboxDispatchResult struct {
+	Delivered int
+	Retrying  int
+	Failed    int
+	Skipped   int
+}
+
+// OutboxDispatcher delivers the notifications of the outbox with an email sender. Failed deliveries are
+// retried with a growing backoff, up to maxAttempts times.
+type OutboxDispatcher struct {
+	outboxRepository ports.OutboxRepository
+	emailSender      ports.EmailSender
+	maxAttempts      int
+	now              func() time.Time
+}
+
+// NewOutboxDispatcher creates a new OutboxDispatcher. Notifications failing maxAttempts times are
+// marked failed and kept in the outbox for inspection.
+func NewOutboxDispatcher(outboxRepository ports.OutboxRepository, emailSender ports.EmailSender, maxAttempts int) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxRepository: outboxRepository,
+		emailSender:      emailSender,
+		maxAttempts:      maxAttempts,
+		now:              time.Now,
+	}
+}
+
+// Dispatch sweeps the outbox, delivering a batch of the due notifications. Each notification is claimed
+// before it is sent, so sweeps running concurrently skip it instead of sending it twice. The sweep stops
+// ahead of the deadline of ctx and leaves the rest to the next one.
+func (d *OutboxDispatcher) Dispatch(ctx context.Context) (OutboxDispatchResult, error) {
+	var result OutboxDispatchResult
+
+	stop, cancel := withDeadlineMargin(ctx)
+	defer cancel()
+
+	notifications, err := d.outboxRepository.ListDueNotifications(ctx, d.now().UTC(), outboxBatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for i, notification := range notifications {
+		if stop.Err() != nil {
+			log.Printf("Outbox sweep stopped before the deadline with %d notifications left", len(notifications)-i)
+			break
+		}
+
+		status, err := d.deliver(ctx, notification)
+		switch {
+		case errors.Is(err, model.ErrNotificationChanged):
+			result.Skipped++
+			continue
+		case err != nil:
+			errs = append(errs, err)
+		}
+
+		switch status {
+		case model.NotificationStatusDelivered:
+			result.Delivered++
+		case model.NotificationStatusFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// deliver claims a notification, sends it and records the outcome, returning the status it was left
+// in. It returns model.ErrNotificationChanged, without sending, when the notification could not be claimed.
+func (d *OutboxDispatcher) deliver(ctx context.Context, notification *model.Notification) (model.NotificationStatus, error) {
+	notification.Claim(d.now().UTC(), outboxLease)
+	if err := d.outboxRepository.SaveNotification(ctx, notification); err != nil {
+		return notification.Status, err
+	}
+
+	if err := d.send(ctx, notification); err != nil {
+		log.Printf("Error delivering attempt %d of notification %s: %v", notification.Attempts, notification.ID, err)
+		notification.Fail(err, d.now().UTC(), d.maxAttempts, outboxBackoff)
+	} else {
+		notification.Deliver(d.now().UTC())
+	}
+
+	// A notification whose outcome is not saved is attempted again once its lease ends
+	if err := d.outboxRepository.SaveNotification(ctx, notification); err != nil {
+		return notification.Status, fmt.Errorf("error recording outcome of notification %s: %w", notification.ID, err)
+	}
+
+	return notification.Status, nil
+}
+
+// send decodes the summary of a notification and sends it as the email of its kind
+func (d *OutboxDispatcher) send(ctx context.Context, notification *model.Notification) error {
+	switch notification.Kind {
+	case model.NotificationKindSummary:
+		var summary ports.EmailSummary
+		if err := json.Unmarshal(notification.Payload, &summary); err != nil {
+			return fmt.Errorf("error decoding summary: %w", err)
+		}
+		return d.emailSender.SendSummaryEmail(ctx, notification.Recipient, summary)
+	case model.NotificationKindConsolidatedSummary:
+		var summary ports.ConsolidatedSummary
+		if err := json.Unmarshal(notification.Payload, &summary); err != nil {
+			return fmt.Errorf("error decoding consolidated summary: %w", err)
+		}
+		return d.emailSender.SendConsolidatedSummaryEmail(ctx, notification.Recipient, summary)
+	default:
+		return fmt.Errorf("unknown notification kind %q", notification.Kind)
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+	"transaction-processor/internal/ports"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxEmailSender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	sender := NewOutboxEmailSender(mockOutbox)
+	now := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	sender.now = func() time.Time { return now }
+
+	summary := ports.EmailSummary{
+		TotalBalance:             150,
+		MonthlyTransactionCounts: map[string]int{"January": 2},
+		Period:                   &model.StatementPeriod{Start: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var saved []model.Notification
+	mockOutbox.EXPECT().
+		SaveNotification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+			saved = append(saved, *notification)
+			return nil
+		}).
+		Times(2)
+
+	assert.NoError(t, sender.SendSummaryEmail(context.Background(), "user@example.com", summary))
+	assert.NoError(t, sender.SendConsolidatedSummaryEmail(context.Background(), "user@example.com", ports.NewConsolidatedSummary("Ana", []ports.AccountSummary{{AccountID: "acc1", Summary: summary}})))
+
+	// Notifications are pending and due right away, with the summary they are rendered from
+	assert.Len(t, saved, 2)
+	assert.Equal(t, model.NotificationKindSummary, saved[0].Kind)
+	assert.Equal(t, model.NotificationKindConsolidatedSummary, saved[1].Kind)
+	assert.NotEqual(t, saved[0].ID, saved[1].ID)
+	assert.True(t, saved[0].IsDue(now))
+
+	var decoded ports.EmailSummary
+	assert.NoError(t, json.Unmarshal(saved[0].Payload, &decoded))
+	assert.Equal(t, summary, decoded)
+}
+
+func TestOutboxEmailSender_SaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	sender := NewOutboxEmailSender(mockOutbox)
+
+	mockOutbox.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(errors.New("table not found"))
+
+	err := sender.SendSummaryEmail(context.Background(), "user@example.com", ports.EmailSummary{})
+
+	assert.EqualError(t, err, "table not found")
+}
+
+func TestOutboxEmailSender_NotificationKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+
+	sender := NewOutboxEmailSender(mockOutbox)
+
+	// The notification of a key keeps its ID, so writing it again finds the queued one
+	var ids []string
+	gomock.InOrder(
+		mockOutbox.EXPECT().
+			SaveNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+				ids = append(ids, notification.ID)
+				return nil
+			}),
+		mockOutbox.EXPECT().
+			SaveNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+				ids = append(ids, notification.ID)
+				return fmt.Errorf("error saving notification %s: %w", notification.ID, model.ErrNotificationChanged)
+			}),
+		mockOutbox.EXPECT().
+			SaveNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+				ids = append(ids, notification.ID)
+				return nil
+			}),
+	)
+
+	ctx := ports.WithNotificationKey(context.Background(), "acc1/abc")
+	assert.NoError(t, sender.SendSummaryEmail(ctx, "user@example.com", ports.EmailSummary{}))
+	assert.NoError(t, sender.SendSummaryEmail(ctx, "user@example.com", ports.EmailSummary{}))
+	assert.NoError(t, sender.SendSummaryEmail(ports.WithNotificationKey(context.Background(), "acc1/def"), "user@example.com", ports.EmailSummary{}))
+
+	assert.Len(t, ids, 3)
+	assert.Equal(t, ids[0], ids[1])
+	assert.NotEqual(t, ids[0], ids[2])
+}
+
+func TestOutboxDispatcher_Dispatch(t *testing.T) {
+	now := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	notification := func(id string, kind model.NotificationKind, summary interface{}) *model.Notification {
+		payload, err := json.Marshal(summary)
+		assert.NoError(t, err)
+		return model.NewNotification(id, kind, "user@example.com", payload, now.Add(-time.Minute))
+	}
+
+	t.Run("delivers the due notifications", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		dispatcher := NewOutboxDispatcher(mockOutbox, mockEmailSender, 3)
+		dispatcher.now = func() time.Time { return now }
+
+		summary := ports.EmailSummary{TotalBalance: 150}
+		consolidated := ports.NewConsolidatedSummary("Ana", []ports.AccountSummary{{AccountID: "acc1", Summary: summary}})
+		mockOutbox.EXPECT().
+			ListDueNotifications(gomock.Any(), now, outboxBatchSize).
+			Return([]*model.Notification{
+				notification("n1", model.NotificationKindSummary, summary),
+				notification("n2", model.NotificationKindConsolidatedSummary, consolidated),
+			}, nil)
+
+		// Each notification is claimed before it is sent and saved again once delivered
+		var saved []model.Notification
+		mockOutbox.EXPECT().
+			SaveNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, notification *model.Notification) error {
+				saved = append(saved, *notification)
+				return nil
+			}).
+			Times(4)
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", summary).Return(nil)
+		mockEmailSender.EXPECT().SendConsolidatedSummaryEmail(gomock.Any(), "user@example.com", consolidated).Return(nil)
+
+		result, err := dispatcher.Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, OutboxDispatchResult{Delivered: 2}, result)
+		assert.Equal(t, model.NotificationStatusPending, saved[0].Status)
+		assert.Equal(t, now.Add(outboxLease), saved[0].NextAttemptAt)
+		assert.Equal(t, 1, saved[0].Attempts)
+		assert.Equal(t, model.NotificationStatusDelivered, saved[1].Status)
+	})
+
+	t.Run("retries failed deliveries with a growing backoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		dispatcher := NewOutboxDispatcher(mockOutbox, mockEmailSender, 3)
+		dispatcher.now = func() time.Time { return now }
+
+		retried := notification("n1", model.NotificationKindSummary, ports.EmailSummary{})
+		retried.Attempts = 1
+		exhausted := notification("n2", model.NotificationKindSummary, ports.EmailSummary{})
+		exhausted.Attempts = 2
+		mockOutbox.EXPECT().
+			ListDueNotifications(gomock.Any(), now, outboxBatchSize).
+			Return([]*model.Notification{retried, exhausted}, nil)
+		mockOutbox.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+		mockEmailSender.EXPECT().
+			SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+			Return(fmt.Errorf("dial tcp: connection refused")).
+			Times(2)
+
+		result, err := dispatcher.Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, OutboxDispatchResult{Retrying: 1, Failed: 1}, result)
+
+		// The second attempt failed and the third waits twice the backoff
+		assert.Equal(t, model.NotificationStatusPending, retried.Status)
+		assert.Equal(t, 2, retried.Attempts)
+		assert.Equal(t, now.Add(2*outboxBackoff), retried.NextAttemptAt)
+		assert.Equal(t, "dial tcp: connection refused", retried.Error)
+
+		// The last attempt failed for good
+		assert.Equal(t, model.NotificationStatusFailed, exhausted.Status)
+		assert.Equal(t, 3, exhausted.Attempts)
+	})
+
+	t.Run("skips notifications claimed by another sweep", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		dispatcher := NewOutboxDispatcher(mockOutbox, mockEmailSender, 3)
+		dispatcher.now = func() time.Time { return now }
+
+		mockOutbox.EXPECT().
+			ListDueNotifications(gomock.Any(), now, outboxBatchSize).
+			Return([]*model.Notification{notification("n1", model.NotificationKindSummary, ports.EmailSummary{})}, nil)
+		mockOutbox.EXPECT().
+			SaveNotification(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("error saving notification n1: %w", model.ErrNotificationChanged))
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		result, err := dispatcher.Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, OutboxDispatchResult{Skipped: 1}, result)
+	})
+
+	t.Run("reports outcomes that could not be recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOutbox := mocks.NewMockOutboxRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		dispatcher := NewOutboxDispatcher(mockOutbox, mockEmailSender, 3)
+		dispatcher.now = func() time.Time { return now }
+
+		mockOutbox.EXPECT().
+			ListDueNotifications(gomock.Any(), now, outboxBatchSize).
+			Return([]*model.Notification{notification("n1", model.NotificationKindSummary, ports.EmailSummary{})}, nil)
+		gomock.InOrder(
+			mockOutbox.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(nil),
+			mockOutbox.EXPECT().SaveNotification(gomock.Any(), gomock.Any()).Return(errors.New("throttled")),
+		)
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := dispatcher.Dispatch(context.Background())
+
+		assert.EqualError(t, err, "error recording outcome of notification n1: throttled")
+		assert.Equal(t, OutboxDispatchResult{Delivered: 1}, result)
+	})
+}
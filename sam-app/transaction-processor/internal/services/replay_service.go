@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+var (
+	// ErrAccountNotFound is returned when replaying an account without stored transactions or summary
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrNoRecipient is returned when the summary of a replayed account has no address to be sent to
+	ErrNoRecipient = errors.New("no recipient for the account summary")
+)
+
+// ReplayOptions restricts a replay to a date range and controls whether its summary is emailed.
+// From and To are inclusive days and either may be zero to leave the range open.
+type ReplayOptions struct {
+	From        time.Time
+	To          time.Time
+	ResendEmail bool
+
+	// Recipient overrides the address of the account owner when the summary is resent
+	Recipient string
+}
+
+// ReplayResult is the outcome of replaying the stored transactions of an account
+type ReplayResult struct {
+	Owner            model.AccountOwner
+	TransactionCount int
+	Summary          ports.EmailSummary
+	Saved            bool
+	Recipient        string
+}
+
+// ReplayService rebuilds the summary of an account from its stored transactions, without the file they
+// were read from. It is used to regenerate the summaries after fixing how they are calculated.
+type ReplayService struct {
+	transactionRepository ports.TransactionRepository
+	customerRepository    ports.CustomerRepository
+	budgetRepository      ports.BudgetRepository
+	emailSender           ports.EmailSender
+}
+
+// NewReplayService creates a new ReplayService. The customer and budget repositories may be nil.
+func NewReplayService(
+	transactionRepository ports.TransactionRepository,
+	customerRepository ports.CustomerRepository,
+	budgetRepository ports.BudgetRepository,
+	emailSender ports.EmailSender,
+) *ReplayService {
+	return &ReplayService{
+		transactionRepository: transactionRepository,
+		customerRepository:    customerRepository,
+		budgetRepository:      budgetRepository,
+		emailSender:           emailSender,
+	}
+}
+
+// Replay recomputes the account and summary of an account from its stored transactions and, if requested,
+// emails it to the owner of the account. The summary of an open-ended replay is saved to the accounts
+// table; the summary of a range is only returned. Transactions before the range make up the opening
+// balance and later ones are left out. Generated interest and fees are stored
+// with the transactions, so they are replayed rather than charged again. The declared balance of the last
+// statement file is not stored, so the saved summary goes without it.
+func (s *ReplayService) Replay(ctx context.Context, accountID string, options ReplayOptions) (*ReplayResult, error) {
+	stored, err := s.transactionRepository.GetAccountOwner(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.transactionRepository.GetTransactions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil && len(transactions) == 0 {
+		return nil, ErrAccountNotFound
+	}
+
+	owner := model.AccountOwner{AccountID: accountID}
+	if stored != nil {
+		owner = *stored
+	}
+
+	account := model.NewAccount()
+	for _, tx := range transactions {
+		switch {
+		case !options.To.IsZero() && !tx.Date.Before(options.To.AddDate(0, 0, 1)):
+			continue
+		case !options.From.IsZero() && tx.Date.Before(options.From):
+			account.CarryForward(tx)
+		default:
+			account.AddTransaction(tx)
+		}
+	}
+
+	summary, err := summarizeAccount(ctx, s.budgetRepository, accountID, account)
+	if err != nil {
+		return nil, err
+	}
+	if !options.From.IsZero() && !options.To.IsZero() {
+		summary.Period = &model.StatementPeriod{Start: options.From, End: options.To}
+	}
+
+	result := &ReplayResult{
+		Owner:            owner,
+		TransactionCount: len(account.Transactions),
+		Summary:          summary,
+	}
+
+	// The accounts table holds the summary of every transaction, which the summary of a range would replace
+	if options.From.IsZero() && options.To.IsZero() {
+		if err := s.transactionRepository.SaveAccount(ctx, owner, summary); err != nil {
+			return nil, err
+		}
+		result.Saved = true
+	}
+	if !options.ResendEmail {
+		return result, nil
+	}
+
+	recipient := options.Recipient
+	if recipient == "" {
+		if recipient, err = ownerRecipient(ctx, s.customerRepository, owner); err != nil {
+			return nil, err
+		}
+	}
+	if recipient == "" {
+		return nil, ErrNoRecipient
+	}
+
+	if err := s.emailSender.SendSummaryEmail(ctx, recipient, summary); err != nil {
+		return nil, err
+	}
+	result.Recipient = recipient
+
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+	"transaction-processor/internal/ports"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayService_Replay(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	transactions := []*model.Transaction{
+		{ID: "1", AccountID: "acc1", Date: date(time.May, 20), Amount: 100, IsCredit: true},
+		{ID: "2", AccountID: "acc1", Date: date(time.June, 5), Amount: 40, IsCredit: false},
+		{ID: "3", AccountID: "acc1", Date: date(time.June, 30), Amount: 10, IsCredit: true},
+		{ID: "4", AccountID: "acc1", Date: date(time.July, 1), Amount: 500, IsCredit: true},
+	}
+
+	t.Run("recomputes the summary of every transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		service := NewReplayService(mockRepo, nil, nil, mockEmailSender)
+
+		owner := model.AccountOwner{AccountID: "acc1", Email: "user@example.com"}
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "acc1").Return(&owner, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc1").Return(transactions, nil)
+		mockRepo.EXPECT().
+			SaveAccount(gomock.Any(), owner, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.AccountOwner, summary ports.EmailSummary) error {
+				assert.Equal(t, 570.0, summary.TotalBalance)
+				return nil
+			})
+
+		result, err := service.Replay(context.Background(), "acc1", ReplayOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.TransactionCount)
+		assert.Equal(t, map[string]int{"May": 1, "June": 2, "July": 1}, result.Summary.MonthlyTransactionCounts)
+		assert.Nil(t, result.Summary.Period)
+		assert.True(t, result.Saved)
+		assert.Empty(t, result.Recipient)
+	})
+
+	t.Run("replays a date range and resends the email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockCustomers := mocks.NewMockCustomerRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		service := NewReplayService(mockRepo, mockCustomers, nil, mockEmailSender)
+
+		owner := model.AccountOwner{AccountID: "acc1", CustomerID: "cust1", Email: "old@example.com"}
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "acc1").Return(&owner, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc1").Return(transactions, nil)
+		mockCustomers.EXPECT().GetCustomer(gomock.Any(), "cust1").Return(&model.Customer{ID: "cust1", Email: "customer@example.com", AccountIDs: []string{"acc1"}}, nil)
+
+		// The summary of a range is sent but not saved over the summary of the account
+		var sent ports.EmailSummary
+		mockEmailSender.EXPECT().
+			SendSummaryEmail(gomock.Any(), "customer@example.com", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+				sent = summary
+				return nil
+			})
+
+		result, err := service.Replay(context.Background(), "acc1", ReplayOptions{
+			From:        date(time.June, 1),
+			To:          date(time.June, 30),
+			ResendEmail: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.TransactionCount)
+		assert.Equal(t, "customer@example.com", result.Recipient)
+
+		// May is carried forward into the balance and July is left out
+		assert.Equal(t, 70.0, sent.TotalBalance)
+		assert.Equal(t, map[string]int{"June": 2}, sent.MonthlyTransactionCounts)
+		assert.Equal(t, &model.StatementPeriod{Start: date(time.June, 1), End: date(time.June, 30)}, sent.Period)
+		assert.Equal(t, sent, result.Summary)
+		assert.False(t, result.Saved)
+	})
+
+	t.Run("resends to the given recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		service := NewReplayService(mockRepo, nil, nil, mockEmailSender)
+
+		// Accounts missing from the accounts table are replayed from their transactions
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "acc1").Return(nil, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc1").Return(transactions, nil)
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), model.AccountOwner{AccountID: "acc1"}, gomock.Any()).Return(nil)
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "ops@example.com", gomock.Any()).Return(nil)
+
+		result, err := service.Replay(context.Background(), "acc1", ReplayOptions{ResendEmail: true, Recipient: "ops@example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ops@example.com", result.Recipient)
+	})
+
+	t.Run("account without a recipient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		service := NewReplayService(mockRepo, nil, nil, mockEmailSender)
+
+		// The address of the last summary of an account without a customer is not resent to
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "acc1").Return(&model.AccountOwner{AccountID: "acc1", Email: "anonymous@example.com"}, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "acc1").Return(transactions, nil)
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := service.Replay(context.Background(), "acc1", ReplayOptions{ResendEmail: true})
+
+		assert.True(t, errors.Is(err, ErrNoRecipient))
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+		service := NewReplayService(mockRepo, nil, nil, mockEmailSender)
+
+		mockRepo.EXPECT().GetAccountOwner(gomock.Any(), "missing").Return(nil, nil)
+		mockRepo.EXPECT().GetTransactions(gomock.Any(), "missing").Return(nil, nil)
+
+		result, err := service.Replay(context.Background(), "missing", ReplayOptions{})
+
+		assert.True(t, errors.Is(err, ErrAccountNotFound))
+		assert.Nil(t, result)
+	})
+}
@@ -1,15 +1,53 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
 	"transaction-processor/internal/domain/model"
 	"transaction-processor/internal/ports"
 )
 
+// storedBatchSize is the maximum number of transaction IDs whose stored versions are read at once
+const storedBatchSize = 100
+
+// ProgressFunc reports how many of the transactions of a statement have been processed
+type ProgressFunc func(processed, total int)
+
+// ReconciliationMode sets what happens when the stored transactions of a statement do not reconcile
+// with the file
+type ReconciliationMode string
+
+const (
+	// ReconciliationFlag records the mismatches with the account summary and carries on
+	ReconciliationFlag ReconciliationMode = "flag"
+
+	// ReconciliationFail fails processing before the account summary is saved and the email sent
+	ReconciliationFail ReconciliationMode = "fail"
+)
+
 // TransactionService orchestrates the transaction processing use case
 type TransactionService struct {
 	fileReader            ports.FileReader
 	emailSender           ports.EmailSender
 	transactionRepository ports.TransactionRepository
+	budgetRepository      ports.BudgetRepository
+	chargesEngine         *model.ChargesEngine
+	checkpointRepository  ports.CheckpointRepository
+	checkpointInterval    int
+	csvProfile            string
+	mimeType              string
+	reconciliationMode    ReconciliationMode
+	progress              ProgressFunc
+	workers               int
 }
 
 // NewTransactionService creates a new TransactionService
@@ -17,45 +55,539 @@ func NewTransactionService(
 	fileReader ports.FileReader,
 	emailSender ports.EmailSender,
 	transactionRepository ports.TransactionRepository,
+	budgetRepository ports.BudgetRepository,
+	chargesEngine *model.ChargesEngine,
 ) *TransactionService {
 	return &TransactionService{
 		fileReader:            fileReader,
 		emailSender:           emailSender,
 		transactionRepository: transactionRepository,
+		budgetRepository:      budgetRepository,
+		chargesEngine:         chargesEngine,
 	}
 }
 
-// ProcessTransactionsAndSendSummary processes a transaction file and sends a summary email
-func (s *TransactionService) ProcessTransactionsAndSendSummary(filePath, emailRecipient, accountID string) error {
+// SetProgress sets the function notified as the transactions of a statement are processed
+func (s *TransactionService) SetProgress(progress ProgressFunc) {
+	s.progress = progress
+}
+
+// SetWorkers sets how many transactions are persisted concurrently, one at a time by default
+func (s *TransactionService) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+// SetCheckpoints sets the repository the progress of a file is checkpointed to every interval
+// transactions, so processing a file again resumes after the transactions already persisted
+func (s *TransactionService) SetCheckpoints(checkpointRepository ports.CheckpointRepository, interval int) {
+	s.checkpointRepository = checkpointRepository
+	s.checkpointInterval = interval
+}
+
+// SetFileFormat records the CSV profile and the MIME type files are read with. A file only resumes
+// from the checkpoint of a run that read it the same way, since another reader may split it into other
+// transactions.
+func (s *TransactionService) SetFileFormat(csvProfile, mimeType string) {
+	s.csvProfile = csvProfile
+	s.mimeType = mimeType
+}
+
+// SetReconciliation sets processing to reconcile the stored transactions with the file and the totals
+// it declares once they are persisted, which requires a repository
+func (s *TransactionService) SetReconciliation(mode ReconciliationMode) {
+	s.reconciliationMode = mode
+}
+
+// ProcessTransactionsAndSendSummary processes a transaction file for an account and sends a summary email.
+// When the account belongs to a customer with several accounts, a consolidated summary is sent instead.
+// When the deadline of ctx is close, no more transactions are persisted and a *PartialResultError is
+// returned without sending the summary; processing the file again resumes it.
+func (s *TransactionService) ProcessTransactionsAndSendSummary(ctx context.Context, filePath, emailRecipient, accountID string, customer *model.Customer) error {
 	// Read transactions from file
-	transactions, err := s.fileReader.ReadTransactions(filePath)
+	statement, err := s.readStatement(ctx, filePath)
 	if err != nil {
 		return err
 	}
+	transactions := statement.Transactions
 
-	// Create account and add transactions
-	account := model.NewAccount()
 	for _, tx := range transactions {
-		account.AddTransaction(tx)
+		tx.AccountID = accountID
+	}
+
+	// Settle and save transactions to database if repository is provided, resuming after the
+	// checkpoint of a previous run of the file
+	var account *model.Account
+	var checkpoint *model.ProcessingCheckpoint
+	var reconciliation *model.Reconciliation
+	if s.transactionRepository != nil {
+		checkpoint, err = s.loadCheckpoint(ctx, accountID, filePath, len(transactions))
+		if err != nil {
+			return err
+		}
+
+		stop, cancel := withDeadlineMargin(ctx)
+		defer cancel()
+		account, err = s.persistTransactions(ctx, stop, transactions, checkpoint)
+		if err != nil {
+			return err
+		}
 
-		// Save transaction to database if repository is provided
-		if s.transactionRepository != nil {
-			if err := s.transactionRepository.SaveTransaction(tx); err != nil {
-				return err
+		if reconciliation, err = s.reconcile(ctx, stop, accountID, statement); err != nil {
+			return err
+		}
+	} else {
+		// Without a repository, the transactions are processed once added to the account
+		s.reportProgress(0, len(transactions))
+		account = model.NewAccount()
+		for i, tx := range transactions {
+			account.AddTransaction(tx)
+			s.reportProgress(i+1, len(transactions))
+		}
+	}
+
+	// Apply interest and fees over the statement period if an engine is provided
+	if s.chargesEngine != nil {
+		if period, ok := model.NewStatementPeriodFromAccount(account); ok {
+			for _, charge := range s.chargesEngine.Apply(account, period) {
+				charge.AccountID = accountID
+				if s.transactionRepository != nil {
+					if err := s.transactionRepository.SaveTransaction(ctx, charge); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
 
 	// Create email summary
-	summary := ports.NewEmailSummaryFromAccount(account)
+	summary, err := s.summarize(ctx, accountID, account)
+	if err != nil {
+		return err
+	}
+	summary.StatementBalance = statement.ClosingBalance
+	summary.StatementFiles = ports.NewStatementFileSummaries(statement)
+	summary.Reconciliation = reconciliation
+
+	// Summaries of customer accounts go to the address of the customer, not the one of the request
+	emailRecipient = summaryRecipient(emailRecipient, customer)
 
-	// Save account summary to database if repository is provided
+	// Save account summary and the owner receiving its monthly statements if repository is provided
 	if s.transactionRepository != nil {
-		if err := s.transactionRepository.SaveAccount(accountID, summary); err != nil {
+		owner := model.AccountOwner{AccountID: accountID, Email: emailRecipient}
+		if customer != nil {
+			owner.CustomerID = customer.ID
+		}
+		if err := s.transactionRepository.SaveAccount(ctx, owner, summary); err != nil {
+			return err
+		}
+	}
+
+	if err := s.sendSummary(ports.WithNotificationKey(ctx, s.notificationKey(accountID, filePath, checkpoint)), emailRecipient, accountID, customer, summary); err != nil {
+		return err
+	}
+
+	// The checkpoint is kept until the summary was sent, so a retry after a failed send does not
+	// persist the transactions again. Checkpoints left behind expire on their own.
+	if checkpoint != nil {
+		if err := s.checkpointRepository.DeleteCheckpoint(ctx, checkpoint.AccountID, checkpoint.Fingerprint); err != nil {
+			log.Printf("Error deleting checkpoint of account %s: %v", accountID, err)
+		}
+	}
+	return nil
+}
+
+// reconcile compares the stored transactions of the account with the statement when reconciliation is
+// enabled. Mismatches fail processing in ReconciliationFail mode and are only logged otherwise. Only the
+// transactions of the file are read, in batches run like persisting them: no batch is started once stop
+// is done, and a *PartialResultError is returned when stop ends the reads early.
+func (s *TransactionService) reconcile(ctx, stop context.Context, accountID string, statement *model.Statement) (*model.Reconciliation, error) {
+	if s.reconciliationMode == "" {
+		return nil, nil
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, tx := range statement.Transactions {
+		if !seen[tx.ID] {
+			seen[tx.ID] = true
+			ids = append(ids, tx.ID)
+		}
+	}
+
+	batches := (len(ids) + storedBatchSize - 1) / storedBatchSize
+	found := make([]map[string]*model.Transaction, batches)
+	err := runBounded(stop, max(s.workers, 1), batches, func(batch int) error {
+		stored, err := s.transactionRepository.GetTransactionsByID(ctx, accountID, ids[batch*storedBatchSize:min((batch+1)*storedBatchSize, len(ids))])
+		if err != nil {
+			return fmt.Errorf("error reading persisted transactions to reconcile: %w", err)
+		}
+		found[batch] = stored
+		return nil
+	})
+	if err != nil && stoppedEarly(ctx, stop) && onlyStopped(err, stop) {
+		total := len(statement.Transactions)
+		return nil, &PartialResultError{Processed: total, Total: total, Err: fmt.Errorf("reconciliation stopped: %w", err)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var persisted []*model.Transaction
+	for i, id := range ids {
+		if tx, ok := found[i/storedBatchSize][id]; ok {
+			persisted = append(persisted, tx)
+		}
+	}
+
+	reconciliation := model.Reconcile(statement, persisted)
+	if err := reconciliation.Err(); err != nil {
+		if s.reconciliationMode == ReconciliationFail {
+			return nil, err
+		}
+		log.Printf("Account %s: %v", accountID, err)
+	}
+
+	return &reconciliation, nil
+}
+
+// notificationKey returns the key of the summary email of a file for an account: the account and the
+// fingerprint of the file, so a retried run queues its summary once. It is empty when the file cannot
+// be fingerprinted.
+func (s *TransactionService) notificationKey(accountID, filePath string, checkpoint *model.ProcessingCheckpoint) string {
+	if checkpoint != nil {
+		return accountID + "/" + checkpoint.Fingerprint
+	}
+
+	fingerprint, err := fingerprintFile(filePath, s.csvProfile, s.mimeType)
+	if err != nil {
+		log.Printf("Error fingerprinting file of account %s for its summary: %v", accountID, err)
+		return ""
+	}
+	return accountID + "/" + fingerprint
+}
+
+// summaryRecipient returns the address the summary of an account is sent to: the stored email of the
+// customer owning it, if any, or else the address given with the request
+func summaryRecipient(emailRecipient string, customer *model.Customer) string {
+	if customer != nil && customer.Email != "" {
+		return customer.Email
+	}
+	return emailRecipient
+}
+
+// sendSummary sends the summary email, or a consolidated summary to customers owning several accounts
+func (s *TransactionService) sendSummary(ctx context.Context, emailRecipient, accountID string, customer *model.Customer, summary ports.EmailSummary) error {
+	if customer != nil && len(customer.AccountIDs) > 1 {
+		consolidated, err := s.consolidate(ctx, customer, accountID, summary)
+		if err != nil {
 			return err
 		}
+		return s.emailSender.SendConsolidatedSummaryEmail(ctx, emailRecipient, consolidated)
+	}
+
+	return s.emailSender.SendSummaryEmail(ctx, emailRecipient, summary)
+}
+
+// loadCheckpoint returns the checkpoint of a file for an account, identified by the fingerprint of its
+// contents and the format it is read with, or a new one at its first row. It returns nil when
+// checkpoints are disabled. Checkpoints past the end of the file, left by a reader that splits it
+// differently, are started over.
+func (s *TransactionService) loadCheckpoint(ctx context.Context, accountID, filePath string, rows int) (*model.ProcessingCheckpoint, error) {
+	if s.checkpointRepository == nil {
+		return nil, nil
+	}
+
+	fingerprint, err := fingerprintFile(filePath, s.csvProfile, s.mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := s.checkpointRepository.GetCheckpoint(ctx, accountID, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil || checkpoint.Row > rows {
+		return model.NewProcessingCheckpoint(accountID, fingerprint, time.Now().UTC()), nil
+	}
+
+	log.Printf("Resuming file of account %s at row %d of %d", accountID, checkpoint.Row, rows)
+	return checkpoint, nil
+}
+
+// advanceCheckpoint returns a copy of the checkpoint advanced to the persisted prefix of the file, so it
+// can be saved while the account and the persisted rows keep changing
+func advanceCheckpoint(checkpoint *model.ProcessingCheckpoint, row int, account *model.Account, done []bool) *model.ProcessingCheckpoint {
+	var persisted []int
+	for i := row; i < len(done); i++ {
+		if done[i] {
+			persisted = append(persisted, i)
+		}
+	}
+
+	advanced := *checkpoint
+	advanced.Advance(row, account, persisted, time.Now().UTC())
+	return &advanced
+}
+
+// saveCheckpoint saves a checkpoint. Failures are only logged, since they make a retry redo more work
+// but do not affect this run.
+func (s *TransactionService) saveCheckpoint(ctx context.Context, checkpoint *model.ProcessingCheckpoint) {
+	if err := s.checkpointRepository.SaveCheckpoint(ctx, checkpoint); err != nil {
+		log.Printf("Error saving checkpoint of account %s at row %d: %v", checkpoint.AccountID, checkpoint.Row, err)
+	}
+}
+
+// fingerprintFile returns the hexadecimal SHA-256 of the CSV profile and the MIME type a file is read
+// with, followed by its contents
+func fingerprintFile(filePath, csvProfile, mimeType string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	// The settings are NUL-terminated, so no two of them hash the same
+	hash := sha256.New()
+	hash.Write([]byte(csvProfile + "\x00" + mimeType + "\x00"))
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("error fingerprinting file: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// persistTransactions settles the transactions previously stored as pending and saves them, with up
+// to the configured number of workers, and returns the account holding them. Transactions sharing an
+// ID are persisted in file order by the same worker, since a later one may settle an earlier one.
+// Failures are reported in file order. No more transactions are started once stop is done, while those
+// in flight are saved with ctx; when stop ends the work early and nothing else failed, a
+// *PartialResultError is returned.
+//
+// The account is built in file order as the transactions are persisted. With a checkpoint, the rows it
+// covers are skipped and the account resumes from its aggregates, and the checkpoint advances every
+// checkpoint interval and when persisting stops.
+func (s *TransactionService) persistTransactions(ctx, stop context.Context, transactions []*model.Transaction, checkpoint *model.ProcessingCheckpoint) (*model.Account, error) {
+	account := model.NewAccount()
+	done := make([]bool, len(transactions))
+	row := 0
+	processed := 0
+	if checkpoint != nil {
+		row = checkpoint.Row
+		account = model.ResumeAccount(checkpoint.Aggregates, transactions[:row])
+		for i := range transactions {
+			if checkpoint.IsPersisted(i) {
+				done[i] = true
+				processed++
+			}
+		}
+	}
+	s.reportProgress(processed, len(transactions))
+
+	var groups [][]int
+	groupByID := make(map[string]int)
+	for i, tx := range transactions {
+		if done[i] {
+			continue
+		}
+		group, ok := groupByID[tx.ID]
+		if !ok {
+			group = len(groups)
+			groupByID[tx.ID] = group
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], i)
+	}
+
+	// Progress is reported and the account built under a lock, so neither happens concurrently.
+	// Checkpoints are copied under the lock and saved once it is released, so the other workers do
+	// not wait on the write; saves are serialized and a copy older than the last one saved is dropped.
+	var mu, saveMu sync.Mutex
+	saved, lastSaved := row, row
+	complete := func(i int) {
+		mu.Lock()
+		done[i] = true
+		processed++
+		s.reportProgress(processed, len(transactions))
+
+		for row < len(transactions) && done[row] {
+			account.AddTransaction(transactions[row])
+			row++
+		}
+		var advanced *model.ProcessingCheckpoint
+		if checkpoint != nil && row > saved && row-saved >= s.checkpointInterval {
+			advanced = advanceCheckpoint(checkpoint, row, account, done)
+			saved = row
+		}
+		mu.Unlock()
+
+		if advanced != nil {
+			saveMu.Lock()
+			defer saveMu.Unlock()
+			if advanced.Row > lastSaved {
+				s.saveCheckpoint(ctx, advanced)
+				lastSaved = advanced.Row
+			}
+		}
+	}
+
+	// Groups are persisted in batches, whose stored versions are read with a single request. Batches hold
+	// up to storedBatchSize groups and are made smaller to spread small files over the workers.
+	workers := max(s.workers, 1)
+	batchSize := min(max((len(groups)+workers-1)/workers, 1), storedBatchSize)
+	batches := (len(groups) + batchSize - 1) / batchSize
+
+	err := runBounded(stop, workers, batches, func(batch int) error {
+		batchGroups := groups[batch*batchSize : min((batch+1)*batchSize, len(groups))]
+
+		ids := make([]string, 0, len(batchGroups))
+		for _, group := range batchGroups {
+			ids = append(ids, transactions[group[0]].ID)
+		}
+		stored, err := s.transactionRepository.GetTransactionsByID(ctx, transactions[batchGroups[0][0]].AccountID, ids)
+		if err != nil {
+			return fmt.Errorf("error reading stored transactions: %w", err)
+		}
+
+		var errs []error
+		for g, group := range batchGroups {
+			if stop.Err() != nil {
+				errs = append(errs, fmt.Errorf("%d of %d groups of transactions not started: %w", len(batchGroups)-g, len(batchGroups), stop.Err()))
+				break
+			}
+			if err := s.persistGroup(ctx, transactions, group, stored[transactions[group[0]].ID], complete); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+
+	// The final checkpoint lets a retry skip every persisted transaction, including when the run
+	// fails after persisting them all
+	if checkpoint != nil && (row > saved || row < len(transactions)) {
+		s.saveCheckpoint(ctx, advanceCheckpoint(checkpoint, row, account, done))
+	}
+	if err != nil && stoppedEarly(ctx, stop) && onlyStopped(err, stop) {
+		return nil, &PartialResultError{Processed: processed, Total: len(transactions), Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// reportProgress notifies the progress function, if any
+func (s *TransactionService) reportProgress(processed, total int) {
+	if s.progress != nil {
+		s.progress(processed, total)
+	}
+}
+
+// readStatement reads a statement file. Only readers implementing ports.StatementReader provide the
+// balances declared by the bank; other readers only provide the transactions.
+func (s *TransactionService) readStatement(ctx context.Context, filePath string) (*model.Statement, error) {
+	if statementReader, ok := s.fileReader.(ports.StatementReader); ok {
+		return statementReader.ReadStatement(ctx, filePath)
+	}
+
+	transactions, err := s.fileReader.ReadTransactions(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Statement{Transactions: transactions}, nil
+}
+
+// summarize creates the email summary of an account, including its budgets if repository is provided
+func (s *TransactionService) summarize(ctx context.Context, accountID string, account *model.Account) (ports.EmailSummary, error) {
+	return summarizeAccount(ctx, s.budgetRepository, accountID, account)
+}
+
+// summarizeAccount creates the email summary of an account, including its budgets if repository is provided
+func summarizeAccount(ctx context.Context, budgetRepository ports.BudgetRepository, accountID string, account *model.Account) (ports.EmailSummary, error) {
+	summary := ports.NewEmailSummaryFromAccount(account)
+
+	// Evaluate category budgets if repository is provided
+	if budgetRepository != nil {
+		budgets, err := budgetRepository.GetBudgets(ctx, accountID)
+		if err != nil {
+			return ports.EmailSummary{}, err
+		}
+		summary.Budgets = account.EvaluateBudgets(budgets)
+	}
+
+	return summary, nil
+}
+
+// consolidate builds the summary of every account of a customer. The processed account uses its fresh
+// summary while the other accounts are rebuilt from their stored transactions.
+func (s *TransactionService) consolidate(ctx context.Context, customer *model.Customer, processedAccountID string, processed ports.EmailSummary) (ports.ConsolidatedSummary, error) {
+	accounts := make([]ports.AccountSummary, 0, len(customer.AccountIDs))
+	for _, accountID := range customer.AccountIDs {
+		if accountID == processedAccountID {
+			accounts = append(accounts, ports.AccountSummary{AccountID: accountID, Summary: processed})
+			continue
+		}
+
+		// Other accounts can only be summarized from the database
+		if s.transactionRepository == nil {
+			continue
+		}
+
+		transactions, err := s.transactionRepository.GetTransactions(ctx, accountID)
+		if err != nil {
+			return ports.ConsolidatedSummary{}, err
+		}
+
+		account := model.NewAccount()
+		for _, tx := range transactions {
+			account.AddTransaction(tx)
+		}
+
+		summary, err := s.summarize(ctx, accountID, account)
+		if err != nil {
+			return ports.ConsolidatedSummary{}, err
+		}
+		accounts = append(accounts, ports.AccountSummary{AccountID: accountID, Summary: summary})
+	}
+
+	return ports.NewConsolidatedSummary(customer.Name, accounts), nil
+}
+
+// persistGroup settles and saves the transactions of a file sharing an ID, in file order, starting from
+// their stored version. Each transaction settles the one saved before it.
+func (s *TransactionService) persistGroup(ctx context.Context, transactions []*model.Transaction, group []int, stored *model.Transaction, complete func(i int)) error {
+	for _, i := range group {
+		tx, err := applyStatusTransition(transactions[i], stored, time.Now())
+		if err != nil {
+			return fmt.Errorf("error settling transaction %s: %w", transactions[i].ID, err)
+		}
+		transactions[i] = tx
+
+		if err := s.transactionRepository.SaveTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("error saving transaction %s: %w", tx.ID, err)
+		}
+
+		complete(i)
+		stored = tx
+	}
+	return nil
+}
+
+// applyStatusTransition merges a transaction read from a file with its stored version, if any.
+// The stored transaction is moved to the status of the new one, keeping its history, which lets a
+// later file post a pending authorization by ID. The amount and date are taken from the file since
+// settlement may change them.
+func applyStatusTransition(tx, stored *model.Transaction, now time.Time) (*model.Transaction, error) {
+	if stored == nil {
+		tx.InitStatusHistory(now)
+		return tx, nil
+	}
+
+	target := tx.GetStatus()
+	stored.InitStatusHistory(now)
+	tx.Status = stored.GetStatus()
+	tx.StatusHistory = append([]model.StatusChange(nil), stored.StatusHistory...)
+	if err := tx.TransitionTo(target, now); err != nil {
+		return nil, err
 	}
 
-	// Send summary email
-	return s.emailSender.SendSummaryEmail(emailRecipient, summary)
+	return tx, nil
 }
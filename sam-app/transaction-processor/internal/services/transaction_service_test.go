@@ -1,12 +1,20 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
+	"transaction-processor/internal/adapters"
 	"transaction-processor/internal/domain/model"
 	"transaction-processor/internal/mocks"
+	"transaction-processor/internal/ports"
 )
 
 func TestTransactionService_ProcessTransactionsAndSendSummary(t *testing.T) {
@@ -17,7 +25,7 @@ func TestTransactionService_ProcessTransactionsAndSendSummary(t *testing.T) {
 	mockEmailSender := mocks.NewMockEmailSender(ctrl)
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
 
-	service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo)
+	service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, nil)
 
 	filePath := "transactions.csv"
 	email := "user@example.com"
@@ -29,19 +37,854 @@ func TestTransactionService_ProcessTransactionsAndSendSummary(t *testing.T) {
 	// Mock: leer archivo
 	mockFileReader.
 		EXPECT().
-		ReadTransactions(filePath).
+		ReadTransactions(gomock.Any(), filePath).
 		Return([]*model.Transaction{tx1, tx2}, nil)
 
+	// Mock: buscar transacciones previas
+	mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), accountID, []string{"tx1", "tx2"}).Return(nil, nil)
+
 	// Mock: guardar transacciones
-	mockRepo.EXPECT().SaveTransaction(tx1).Return(nil)
-	mockRepo.EXPECT().SaveTransaction(tx2).Return(nil)
+	mockRepo.EXPECT().SaveTransaction(gomock.Any(), tx1).Return(nil)
+	mockRepo.EXPECT().SaveTransaction(gomock.Any(), tx2).Return(nil)
 
 	// Mock: guardar resumen
 	gomock.InOrder(
-		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any()).Return(nil),
-		mockEmailSender.EXPECT().SendSummaryEmail(email, gomock.Any()).Return(nil),
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), email, gomock.Any()).Return(nil),
 	)
 
-	err := service.ProcessTransactionsAndSendSummary(filePath, email, accountID)
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), filePath, email, accountID, nil)
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Budgets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileReader := mocks.NewMockFileReader(ctrl)
+	mockEmailSender := mocks.NewMockEmailSender(ctrl)
+	budgetRepo := adapters.NewInMemoryBudgetRepository()
+
+	service := NewTransactionService(mockFileReader, mockEmailSender, nil, budgetRepo, nil)
+
+	accountID := "acc123"
+	assert.NoError(t, budgetRepo.SaveBudget(context.Background(), model.NewBudget(accountID, "Groceries", 100, 0)))
+	assert.NoError(t, budgetRepo.SaveBudget(context.Background(), model.NewBudget(accountID, "Leisure", 100, 0)))
+	assert.NoError(t, budgetRepo.SaveBudget(context.Background(), model.NewBudget(accountID, "Transport", 100, 0)))
+
+	july := time.Date(2025, time.July, 10, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC)
+	mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{
+		{ID: "1", Date: june, Amount: 500, IsCredit: false, Category: "Transport"},
+		{ID: "2", Date: july, Amount: 120, IsCredit: false, Category: "groceries"},
+		{ID: "3", Date: july, Amount: 85, IsCredit: false, Category: "Leisure"},
+		{ID: "4", Date: july, Amount: 1000, IsCredit: true, Category: "Transport"},
+	}, nil)
+
+	mockEmailSender.EXPECT().
+		SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+			assert.Len(t, summary.Budgets, 3)
+			assert.Equal(t, model.BudgetStateOverspent, summary.Budgets[0].State)
+			assert.InDelta(t, -20, summary.Budgets[0].Remaining, 0.001)
+			assert.Equal(t, model.BudgetStateNearLimit, summary.Budgets[1].State)
+			assert.Equal(t, model.BudgetStateOnTrack, summary.Budgets[2].State)
+			assert.Equal(t, 0.0, summary.Budgets[2].Spent)
+			assert.Len(t, summary.GetBudgetWarnings(), 2)
+			return nil
+		})
+
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", accountID, nil)
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Charges(t *testing.T) {
+	july := func(day int) time.Time {
+		return time.Date(2025, time.July, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	fees := model.FeeConfig{
+		OverdraftFee:             25,
+		PerTransactionFee:        0.5,
+		MonthlyMaintenanceFee:    5,
+		MaintenanceWaiverBalance: 500,
+	}
+
+	tests := []struct {
+		name             string
+		config           model.ChargesConfig
+		expectedInterest float64
+		expectedFees     float64
+		expectedCharges  int
+	}{
+		{
+			name:             "simple interest with APR",
+			config:           model.ChargesConfig{Interest: model.InterestConfig{Rate: 0.365, RateType: model.RateTypeAPR, Method: model.InterestMethodSimple}},
+			expectedInterest: 21.10,
+			expectedCharges:  1,
+		},
+		{
+			name:             "compound interest with APR",
+			config:           model.ChargesConfig{Interest: model.InterestConfig{Rate: 0.365, RateType: model.RateTypeAPR, Method: model.InterestMethodCompound}},
+			expectedInterest: 21.41,
+			expectedCharges:  1,
+		},
+		{
+			name:             "simple interest with APY",
+			config:           model.ChargesConfig{Interest: model.InterestConfig{Rate: 0.365, RateType: model.RateTypeAPY, Method: model.InterestMethodSimple}},
+			expectedInterest: 17.99,
+			expectedCharges:  1,
+		},
+		{
+			name:            "overdraft, per-transaction and maintenance fees",
+			config:          model.ChargesConfig{Fees: fees},
+			expectedFees:    30.5,
+			expectedCharges: 3,
+		},
+		{
+			name:            "maintenance fee charged below waiver balance",
+			config:          model.ChargesConfig{Fees: model.FeeConfig{MonthlyMaintenanceFee: 5, MaintenanceWaiverBalance: 100}},
+			expectedFees:    5,
+			expectedCharges: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFileReader := mocks.NewMockFileReader(ctrl)
+			mockEmailSender := mocks.NewMockEmailSender(ctrl)
+			mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+			service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, model.NewChargesEngine(tt.config))
+
+			mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{
+				{ID: "1", Date: july(1), Amount: 1000, IsCredit: true},
+				{ID: "2", Date: july(20), Amount: 1200, IsCredit: false},
+				{ID: "3", Date: july(25), Amount: 500, IsCredit: true},
+			}, nil)
+
+			// Statement and generated transactions are all persisted
+			mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Len(3)).Return(nil, nil)
+			mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(3 + tt.expectedCharges)
+			mockRepo.EXPECT().SaveAccount(gomock.Any(), model.AccountOwner{AccountID: "acc123", Email: "user@example.com"}, gomock.Any()).Return(nil)
+
+			mockEmailSender.EXPECT().
+				SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+					assert.InDelta(t, tt.expectedInterest, summary.InterestEarned, 0.001)
+					assert.InDelta(t, tt.expectedFees, summary.FeesCharged, 0.001)
+					assert.Len(t, summary.Charges, tt.expectedCharges)
+					assert.InDelta(t, 300+tt.expectedInterest-tt.expectedFees, summary.TotalBalance, 0.001)
+					assert.Equal(t, map[string]int{"July": 3}, summary.MonthlyTransactionCounts)
+					return nil
+				})
+
+			err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", "acc123", nil)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_StatusTransitions(t *testing.T) {
+	authorizedAt := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		stored            *model.Transaction
+		incoming          *model.Transaction
+		expectedStatus    model.TransactionStatus
+		expectedHistory   []model.TransactionStatus
+		expectedBalance   float64
+		expectedAvailable float64
+		wantErr           bool
+	}{
+		{
+			name:              "new pending transaction holds available balance",
+			incoming:          &model.Transaction{ID: "tx1", Date: date, Amount: 40, Status: model.TransactionStatusPending},
+			expectedStatus:    model.TransactionStatusPending,
+			expectedHistory:   []model.TransactionStatus{model.TransactionStatusPending},
+			expectedBalance:   0,
+			expectedAvailable: -40,
+		},
+		{
+			name: "stored pending transaction is posted by a later file",
+			stored: &model.Transaction{ID: "tx1", Date: date, Amount: 40, Status: model.TransactionStatusPending,
+				StatusHistory: []model.StatusChange{{Status: model.TransactionStatusPending, Timestamp: authorizedAt}}},
+			incoming:          &model.Transaction{ID: "tx1", Date: date, Amount: 45},
+			expectedStatus:    model.TransactionStatusPosted,
+			expectedHistory:   []model.TransactionStatus{model.TransactionStatusPending, model.TransactionStatusPosted},
+			expectedBalance:   -45,
+			expectedAvailable: -45,
+		},
+		{
+			name:              "stored pending transaction is voided",
+			stored:            &model.Transaction{ID: "tx1", Date: date, Amount: 40, Status: model.TransactionStatusPending},
+			incoming:          &model.Transaction{ID: "tx1", Date: date, Amount: 40, Status: model.TransactionStatusVoided},
+			expectedStatus:    model.TransactionStatusVoided,
+			expectedHistory:   []model.TransactionStatus{model.TransactionStatusPending, model.TransactionStatusVoided},
+			expectedBalance:   0,
+			expectedAvailable: 0,
+		},
+		{
+			name:     "posted transaction cannot go back to pending",
+			stored:   &model.Transaction{ID: "tx1", Date: date, Amount: 40},
+			incoming: &model.Transaction{ID: "tx1", Date: date, Amount: 40, Status: model.TransactionStatusPending},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFileReader := mocks.NewMockFileReader(ctrl)
+			mockEmailSender := mocks.NewMockEmailSender(ctrl)
+			mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+			service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, nil)
+
+			mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{tt.incoming}, nil)
+			mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", []string{"tx1"}).Return(map[string]*model.Transaction{"tx1": tt.stored}, nil)
+
+			if !tt.wantErr {
+				mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+					assert.Equal(t, tt.expectedStatus, tx.GetStatus())
+					var history []model.TransactionStatus
+					for _, change := range tx.StatusHistory {
+						history = append(history, change.Status)
+					}
+					assert.Equal(t, tt.expectedHistory, history)
+					return nil
+				})
+				mockRepo.EXPECT().SaveAccount(gomock.Any(), model.AccountOwner{AccountID: "acc123", Email: "user@example.com"}, gomock.Any()).Return(nil)
+				mockEmailSender.EXPECT().
+					SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+						assert.InDelta(t, tt.expectedBalance, summary.TotalBalance, 0.001)
+						assert.InDelta(t, tt.expectedAvailable, summary.AvailableBalance, 0.001)
+						return nil
+					})
+			}
+
+			err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", "acc123", nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Consolidated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileReader := mocks.NewMockFileReader(ctrl)
+	mockEmailSender := mocks.NewMockEmailSender(ctrl)
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+	service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, nil)
+
+	customer := &model.Customer{ID: "cust1", Name: "Jane", Email: "jane@example.com", AccountIDs: []string{"checking", "savings"}}
+	date := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{
+		{ID: "1", Date: date, Amount: 100, IsCredit: true},
+	}, nil)
+	mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "checking", []string{"1"}).Return(nil, nil)
+	mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+		assert.Equal(t, "checking", tx.AccountID)
+		return nil
+	})
+	mockRepo.EXPECT().SaveAccount(gomock.Any(), model.AccountOwner{AccountID: "checking", CustomerID: customer.ID, Email: "jane@example.com"}, gomock.Any()).Return(nil)
+
+	// The other account is summarized from its stored transactions
+	mockRepo.EXPECT().GetTransactions(gomock.Any(), "savings").Return([]*model.Transaction{
+		{ID: "1", AccountID: "savings", Date: date, Amount: 2500, IsCredit: true},
+		{ID: "2", AccountID: "savings", Date: date, Amount: 500, IsCredit: false},
+	}, nil)
+
+	mockEmailSender.EXPECT().
+		SendConsolidatedSummaryEmail(gomock.Any(), "jane@example.com", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, summary ports.ConsolidatedSummary) error {
+			assert.Equal(t, "Jane", summary.CustomerName)
+			assert.Len(t, summary.Accounts, 2)
+			assert.Equal(t, "checking", summary.Accounts[0].AccountID)
+			assert.InDelta(t, 100, summary.Accounts[0].Summary.TotalBalance, 0.001)
+			assert.Equal(t, "savings", summary.Accounts[1].AccountID)
+			assert.InDelta(t, 2000, summary.Accounts[1].Summary.TotalBalance, 0.001)
+			assert.InDelta(t, 2100, summary.NetWorth, 0.001)
+			return nil
+		})
+
+	// The summary goes to the stored address of the customer, not the one of the request
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "someone@example.com", "checking", customer)
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_StatementBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementReader := mocks.NewMockStatementReader(ctrl)
+	mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+	service := NewTransactionService(mockStatementReader, mockEmailSender, nil, nil, nil)
+
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	ledger := &model.DeclaredBalance{Amount: 1150.25, Date: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)}
+
+	// Statement readers are read through ReadStatement to get the declared balances
+	mockStatementReader.EXPECT().ReadStatement(gomock.Any(), "statement.ofx").Return(&model.Statement{
+		AccountID: "123456789",
+		Transactions: []*model.Transaction{
+			{ID: "1", Date: date, Amount: 200, IsCredit: true},
+			{ID: "2", Date: date, Amount: 50, IsCredit: false},
+		},
+		ClosingBalance: ledger,
+	}, nil)
+
+	mockEmailSender.EXPECT().
+		SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+			assert.InDelta(t, 150, summary.TotalBalance, 0.001)
+			assert.Equal(t, ledger, summary.StatementBalance)
+			return nil
+		})
+
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), "statement.ofx", "user@example.com", "acc123", nil)
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Reconciliation(t *testing.T) {
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	statement := func() *model.Statement {
+		return &model.Statement{
+			Transactions: []*model.Transaction{
+				{ID: "1", Date: date, Amount: 200, IsCredit: true},
+				{ID: "2", Date: date, Amount: 50, IsCredit: false},
+			},
+			ControlTotals: &model.ControlTotals{Count: 2, TotalCredit: 200, TotalDebit: 50},
+		}
+	}
+
+	// setup expects the transactions to be persisted, while reading them back by ID only returns the stored ones
+	setup := func(t *testing.T, stored []*model.Transaction) (*TransactionService, *mocks.MockTransactionRepository, *mocks.MockEmailSender) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockStatementReader := mocks.NewMockStatementReader(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		byID := make(map[string]*model.Transaction)
+		for _, tx := range stored {
+			byID[tx.ID] = tx
+		}
+
+		mockStatementReader.EXPECT().ReadStatement(gomock.Any(), "statement.csv").Return(statement(), nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Len(2)).Return(nil, nil),
+			mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", []string{"1", "2"}).Return(byID, nil),
+		)
+		mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		return NewTransactionService(mockStatementReader, mockEmailSender, mockRepo, nil, nil), mockRepo, mockEmailSender
+	}
+
+	t.Run("reconciled statement", func(t *testing.T) {
+		service, mockRepo, mockEmailSender := setup(t, []*model.Transaction{
+			{ID: "1", Date: date, Amount: 200, IsCredit: true},
+			{ID: "2", Date: date, Amount: 50, IsCredit: false},
+		})
+		service.SetReconciliation(ReconciliationFail)
+
+		mockRepo.EXPECT().
+			SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.AccountOwner, summary ports.EmailSummary) error {
+				assert.True(t, summary.Reconciliation.IsReconciled())
+				assert.Equal(t, 2, summary.Reconciliation.Persisted.Count)
+				assert.Equal(t, 150.0, summary.Reconciliation.Persisted.Balance)
+				return nil
+			})
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).Return(nil)
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "statement.csv", "user@example.com", "acc123", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("mismatch is flagged", func(t *testing.T) {
+		service, mockRepo, mockEmailSender := setup(t, []*model.Transaction{
+			{ID: "1", Date: date, Amount: 200, IsCredit: true},
+		})
+		service.SetReconciliation(ReconciliationFlag)
+
+		mockRepo.EXPECT().
+			SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.AccountOwner, summary ports.EmailSummary) error {
+				assert.False(t, summary.Reconciliation.IsReconciled())
+				assert.Equal(t, []string{
+					"file has 2 transactions, 1 were persisted (missing 2)",
+					"file debits total 50.00, persisted debits total 0.00",
+					"file balance is 150.00, persisted balance is 200.00",
+				}, summary.Reconciliation.Mismatches)
+				return nil
+			})
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).Return(nil)
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "statement.csv", "user@example.com", "acc123", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("mismatch fails the run", func(t *testing.T) {
+		service, _, _ := setup(t, []*model.Transaction{
+			{ID: "1", Date: date, Amount: 200, IsCredit: true},
+			{ID: "2", Date: date, Amount: 5, IsCredit: false},
+		})
+		service.SetReconciliation(ReconciliationFail)
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "statement.csv", "user@example.com", "acc123", nil)
+		assert.True(t, errors.Is(err, model.ErrReconciliationMismatch), "expected ErrReconciliationMismatch, got %v", err)
+		assert.ErrorContains(t, err, "file debits total 50.00, persisted debits total 5.00")
+	})
+
+	t.Run("large files are read back in batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStatementReader := mocks.NewMockStatementReader(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		large := &model.Statement{}
+		stored := make(map[string]*model.Transaction)
+		for i := 0; i < 250; i++ {
+			tx := &model.Transaction{ID: fmt.Sprintf("tx%d", i), Date: date, Amount: 1, IsCredit: true}
+			large.Transactions = append(large.Transactions, tx)
+			stored[tx.ID] = tx
+		}
+
+		mockStatementReader.EXPECT().ReadStatement(gomock.Any(), "statement.csv").Return(large, nil)
+		mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(250)
+		var mu sync.Mutex
+		var reads []int
+		mockRepo.EXPECT().
+			GetTransactionsByID(gomock.Any(), "acc123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, ids []string) (map[string]*model.Transaction, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				reads = append(reads, len(ids))
+				// the first three reads check which transactions are already persisted
+				if len(reads) <= 3 {
+					return nil, nil
+				}
+				return stored, nil
+			}).
+			Times(6)
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).Return(nil)
+
+		service := NewTransactionService(mockStatementReader, mockEmailSender, mockRepo, nil, nil)
+		service.SetReconciliation(ReconciliationFail)
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "statement.csv", "user@example.com", "acc123", nil)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []int{100, 100, 50, 100, 100, 50}, reads)
+	})
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_ArchivedStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementReader := mocks.NewMockStatementReader(ctrl)
+	mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+	service := NewTransactionService(mockStatementReader, mockEmailSender, nil, nil, nil)
+
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	ledger := &model.DeclaredBalance{Amount: 1150.25, Date: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)}
+	checking := &model.Statement{
+		Source:         "january/checking.ofx",
+		Transactions:   []*model.Transaction{{ID: "1", Date: date, Amount: 200, IsCredit: true}},
+		ClosingBalance: ledger,
+	}
+	card := &model.Statement{
+		Source:       "january/card.csv",
+		Transactions: []*model.Transaction{{ID: "2", Date: date, Amount: 50, IsCredit: false}},
+	}
+
+	// Archives are read into a statement holding the transactions of every file and a part per file
+	mockStatementReader.EXPECT().ReadStatement(gomock.Any(), "statements.zip").Return(&model.Statement{
+		Transactions: append(append([]*model.Transaction{}, checking.Transactions...), card.Transactions...),
+		Parts:        []*model.Statement{checking, card},
+	}, nil)
+
+	mockEmailSender.EXPECT().
+		SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+			assert.InDelta(t, 150, summary.TotalBalance, 0.001)
+			assert.Nil(t, summary.StatementBalance)
+			assert.Equal(t, []ports.StatementFileSummary{
+				{Source: "january/checking.ofx", TransactionCount: 1, ClosingBalance: ledger},
+				{Source: "january/card.csv", TransactionCount: 1},
+			}, summary.StatementFiles)
+			return nil
+		})
+
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), "statements.zip", "user@example.com", "acc123", nil)
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Progress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFileReader := mocks.NewMockFileReader(ctrl)
+	mockEmailSender := mocks.NewMockEmailSender(ctrl)
+
+	service := NewTransactionService(mockFileReader, mockEmailSender, nil, nil, nil)
+
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return([]*model.Transaction{
+		{ID: "1", Date: date, Amount: 200, IsCredit: true},
+		{ID: "2", Date: date, Amount: 50, IsCredit: false},
+	}, nil)
+	mockEmailSender.EXPECT().SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).Return(nil)
+
+	var reported [][2]int
+	service.SetProgress(func(processed, total int) {
+		reported = append(reported, [2]int{processed, total})
+	})
+
+	err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", "acc123", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 2}, {1, 2}, {2, 2}}, reported)
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_ConcurrentPersistence(t *testing.T) {
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	newTransactions := func() []*model.Transaction {
+		var transactions []*model.Transaction
+		for i := 0; i < 20; i++ {
+			transactions = append(transactions, &model.Transaction{ID: fmt.Sprintf("tx%d", i), Date: date, Amount: 10, IsCredit: true})
+		}
+		// A later row posts the pending authorization of an earlier one
+		transactions[3].Status = model.TransactionStatusPending
+		return append(transactions, &model.Transaction{ID: "tx3", Date: date, Amount: 10, IsCredit: true, Status: model.TransactionStatusPosted})
+	}
+
+	t.Run("transactions sharing an ID are persisted in file order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFileReader := mocks.NewMockFileReader(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, nil)
+		service.SetWorkers(4)
+
+		mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(newTransactions(), nil)
+
+		var mu sync.Mutex
+		stored := map[string]*model.Transaction{}
+		// Stored versions are read once per batch of distinct IDs, not once per transaction
+		mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, ids []string) (map[string]*model.Transaction, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			found := map[string]*model.Transaction{}
+			for _, id := range ids {
+				if tx, ok := stored[id]; ok {
+					copied := *tx
+					found[id] = &copied
+				}
+			}
+			return found, nil
+		}).Times(4)
+		mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			copied := *tx
+			stored[tx.ID] = &copied
+			return nil
+		}).Times(21)
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockEmailSender.EXPECT().
+			SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+				assert.InDelta(t, 200, summary.TotalBalance, 0.001)
+				return nil
+			})
+
+		var reported []int
+		service.SetProgress(func(processed, total int) {
+			reported = append(reported, processed)
+		})
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", "acc123", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.TransactionStatusPosted, stored["tx3"].GetStatus())
+		assert.Len(t, stored["tx3"].StatusHistory, 2)
+		assert.Len(t, reported, 22)
+		assert.Equal(t, 21, reported[len(reported)-1])
+	})
+
+	t.Run("failures are aggregated in file order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFileReader := mocks.NewMockFileReader(ctrl)
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		service := NewTransactionService(mockFileReader, mocks.NewMockEmailSender(ctrl), mockRepo, nil, nil)
+		service.SetWorkers(4)
+
+		mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(newTransactions(), nil)
+		mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Any()).Return(nil, nil).AnyTimes()
+		mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+			if tx.ID == "tx12" || tx.ID == "tx5" {
+				return errors.New("throttled")
+			}
+			return nil
+		}).AnyTimes()
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), "transactions.csv", "user@example.com", "acc123", nil)
+
+		assert.EqualError(t, err, "error saving transaction tx5: throttled\nerror saving transaction tx12: throttled")
+	})
+
+	t.Run("cancelled context stops persisting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFileReader := mocks.NewMockFileReader(ctrl)
+
+		service := NewTransactionService(mockFileReader, mocks.NewMockEmailSender(ctrl), mocks.NewMockTransactionRepository(ctrl), nil, nil)
+		service.SetWorkers(4)
+
+		mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(newTransactions(), nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := service.ProcessTransactionsAndSendSummary(ctx, "transactions.csv", "user@example.com", "acc123", nil)
+
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+	})
+
+	t.Run("close deadline returns a partial result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFileReader := mocks.NewMockFileReader(ctrl)
+
+		service := NewTransactionService(mockFileReader, mocks.NewMockEmailSender(ctrl), mocks.NewMockTransactionRepository(ctrl), nil, nil)
+		service.SetWorkers(4)
+
+		mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(newTransactions(), nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin/2)
+		defer cancel()
+
+		err := service.ProcessTransactionsAndSendSummary(ctx, "transactions.csv", "user@example.com", "acc123", nil)
+
+		var partial *PartialResultError
+		if !errors.As(err, &partial) {
+			t.Fatalf("Expected a partial result, but got %v", err)
+		}
+		assert.True(t, errors.Is(err, ErrDeadlineReached))
+		assert.Equal(t, 0, partial.Processed)
+		assert.Equal(t, 21, partial.Total)
+	})
+
+	t.Run("deadline partway through returns a partial result", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			failID    string
+			wantError string
+		}{
+			{name: "only the deadline", wantError: "15 of 20 groups of transactions not started"},
+			{name: "failure before the deadline", failID: "tx2", wantError: "error saving transaction tx2: throttled"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockFileReader := mocks.NewMockFileReader(ctrl)
+				mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+				service := NewTransactionService(mockFileReader, mocks.NewMockEmailSender(ctrl), mockRepo, nil, nil)
+				service.SetWorkers(1)
+
+				ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin+100*time.Millisecond)
+				defer cancel()
+
+				// Saving tx4 outlasts the deadline margin, so the transactions after it are not started
+				mockFileReader.EXPECT().ReadTransactions(gomock.Any(), "transactions.csv").Return(newTransactions(), nil)
+				mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+					if tx.ID == tt.failID {
+						return errors.New("throttled")
+					}
+					if tx.ID == "tx4" {
+						time.Sleep(200 * time.Millisecond)
+					}
+					return nil
+				}).Times(6)
+
+				err := service.ProcessTransactionsAndSendSummary(ctx, "transactions.csv", "user@example.com", "acc123", nil)
+
+				assert.ErrorContains(t, err, tt.wantError)
+				var partial *PartialResultError
+				if tt.failID != "" {
+					// A failure of a started transaction is reported as such, not as the deadline
+					assert.False(t, errors.As(err, &partial), "expected a failure, got a partial result: %v", err)
+					assert.False(t, errors.Is(err, ErrDeadlineReached))
+					return
+				}
+				if !errors.As(err, &partial) {
+					t.Fatalf("Expected a partial result, but got %v", err)
+				}
+				assert.Equal(t, 6, partial.Processed)
+				assert.Equal(t, 21, partial.Total)
+			})
+		}
+	})
+}
+
+func TestFingerprintFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "statement")
+	if err := os.WriteFile(filePath, []byte("a large statement"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	fingerprint := func(csvProfile, mimeType string) string {
+		value, err := fingerprintFile(filePath, csvProfile, mimeType)
+		if err != nil {
+			t.Fatalf("Failed to fingerprint file: %v", err)
+		}
+		return value
+	}
+
+	// The same file read with another profile or MIME type does not resume from the same checkpoint
+	base := fingerprint("", "")
+	assert.Equal(t, base, fingerprint("", ""))
+	assert.NotEqual(t, base, fingerprint("bank-a", ""))
+	assert.NotEqual(t, base, fingerprint("", "text/csv"))
+	assert.NotEqual(t, fingerprint("bank-a", "text/csv"), fingerprint("bank-atext/csv", ""))
+}
+
+func TestTransactionService_ProcessTransactionsAndSendSummary_Checkpoints(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "transactions.csv")
+	if err := os.WriteFile(filePath, []byte("a large statement"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	newTransactions := func() []*model.Transaction {
+		var transactions []*model.Transaction
+		for i := 0; i < 10; i++ {
+			transactions = append(transactions, &model.Transaction{
+				ID:       fmt.Sprintf("tx%d", i),
+				Date:     time.Date(2025, time.Month(1+i%3), 10+i, 0, 0, 0, 0, time.UTC),
+				Amount:   0.1 * float64(i+1),
+				IsCredit: i%2 == 0,
+			})
+		}
+		transactions[5].Status = model.TransactionStatusPending
+		return transactions
+	}
+
+	// process runs the service over the file, failing to save one transaction, and returns the summary sent
+	process := func(t *testing.T, checkpoints ports.CheckpointRepository, failing string, saves int) (ports.EmailSummary, error) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockFileReader := mocks.NewMockFileReader(ctrl)
+		mockEmailSender := mocks.NewMockEmailSender(ctrl)
+		mockRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		service := NewTransactionService(mockFileReader, mockEmailSender, mockRepo, nil, nil)
+		if checkpoints != nil {
+			service.SetCheckpoints(checkpoints, 3)
+		}
+
+		mockFileReader.EXPECT().ReadTransactions(gomock.Any(), filePath).Return(newTransactions(), nil)
+		mockRepo.EXPECT().GetTransactionsByID(gomock.Any(), "acc123", gomock.Any()).Return(nil, nil).AnyTimes()
+		mockRepo.EXPECT().SaveTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *model.Transaction) error {
+			if tx.ID == failing {
+				return errors.New("throttled")
+			}
+			return nil
+		}).Times(saves)
+
+		var sent ports.EmailSummary
+		mockRepo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+		mockEmailSender.EXPECT().
+			SendSummaryEmail(gomock.Any(), "user@example.com", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, summary ports.EmailSummary) error {
+				sent = summary
+				return nil
+			}).
+			MaxTimes(1)
+
+		err := service.ProcessTransactionsAndSendSummary(context.Background(), filePath, "user@example.com", "acc123", nil)
+		return sent, err
+	}
+
+	expected, err := process(t, nil, "", 10)
+	if err != nil {
+		t.Fatalf("Processing without checkpoints failed: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var stored *model.ProcessingCheckpoint
+	mockCheckpoints := mocks.NewMockCheckpointRepository(ctrl)
+	mockCheckpoints.EXPECT().
+		GetCheckpoint(gomock.Any(), "acc123", gomock.Any()).
+		DoAndReturn(func(context.Context, string, string) (*model.ProcessingCheckpoint, error) {
+			if stored == nil {
+				return nil, nil
+			}
+			copied := *stored
+			return &copied, nil
+		}).
+		Times(2)
+	mockCheckpoints.EXPECT().
+		SaveCheckpoint(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, checkpoint *model.ProcessingCheckpoint) error {
+			copied := *checkpoint
+			stored = &copied
+			return nil
+		}).
+		AnyTimes()
+
+	// The first run stops at the failed transaction, with the later ones persisted ahead of it
+	_, err = process(t, mockCheckpoints, "tx7", 10)
+	assert.EqualError(t, err, "error saving transaction tx7: throttled")
+	if stored == nil {
+		t.Fatalf("Expected a checkpoint to be saved")
+	}
+	assert.Equal(t, 7, stored.Row)
+	assert.Equal(t, []int{8, 9}, stored.Persisted)
+
+	// The retry only persists the failed transaction and sends the same summary
+	mockCheckpoints.EXPECT().DeleteCheckpoint(gomock.Any(), "acc123", stored.Fingerprint).Return(nil)
+
+	resumed, err := process(t, mockCheckpoints, "", 1)
+
 	assert.NoError(t, err)
+	assert.Equal(t, expected, resumed)
+	assert.Equal(t, 10, stored.Row)
 }
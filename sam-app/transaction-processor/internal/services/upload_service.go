@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/ports"
+)
+
+// ErrUploadNotFound is returned when an uploaded object does not match a recorded upload
+var ErrUploadNotFound = errors.New("upload not found")
+
+// uploadLease is how long a claimed upload is held back from other notifications of its object, the
+// longest a function processing it can run, so one killed before recording the outcome is processed again
+const uploadLease = 15 * time.Minute
+
+// UploadProcessor processes the statement of an upload, downloaded into a local file
+type UploadProcessor func(ctx context.Context, upload *model.Upload, filePath string) error
+
+// UploadService orchestrates the upload of large statements through presigned object storage URLs
+type UploadService struct {
+	storage          ports.ObjectStorage
+	uploadRepository ports.UploadRepository
+	urlExpiry        time.Duration
+	now              func() time.Time
+}
+
+// NewUploadService creates a new UploadService issuing URLs valid for urlExpiry
+func NewUploadService(storage ports.ObjectStorage, uploadRepository ports.UploadRepository, urlExpiry time.Duration) *UploadService {
+	return &UploadService{
+		storage:          storage,
+		uploadRepository: uploadRepository,
+		urlExpiry:        urlExpiry,
+		now:              time.Now,
+	}
+}
+
+// CreateUpload records a pending upload of a statement for an account and returns it with the presigned
+// URL the statement must be PUT to. The customer is nil for anonymous requests. Account IDs that cannot
+// scope an object key return model.ErrInvalidAccountID.
+func (s *UploadService) CreateUpload(
+	ctx context.Context,
+	accountID string,
+	customer *model.Customer,
+	email, csvProfile, fileName, contentType string,
+) (*model.Upload, string, error) {
+	if fileName == "" {
+		return nil, "", fmt.Errorf("upload file name cannot be empty")
+	}
+	if err := model.ValidateUploadAccountID(accountID); err != nil {
+		return nil, "", err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	upload := model.NewUpload(id, accountID, fileName, s.now().UTC(), s.urlExpiry)
+	upload.Email = email
+	upload.CSVProfile = csvProfile
+	upload.ContentType = contentType
+	if customer != nil {
+		upload.CustomerID = customer.ID
+	}
+
+	url, err := s.storage.PresignUpload(ctx, upload.Key, contentType, s.urlExpiry)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.uploadRepository.SaveUpload(ctx, upload); err != nil {
+		return nil, "", err
+	}
+
+	return upload, url, nil
+}
+
+// ProcessUpload processes the statement uploaded under an object key and records the outcome. The upload
+// is claimed before it is processed, so repeated or concurrent storage notifications of the object skip
+// it. Processing stopped before the deadline of ctx returns the upload to pending and returns the
+// error, so the notification is retried.
+func (s *UploadService) ProcessUpload(ctx context.Context, key string, process UploadProcessor) error {
+	accountID, uploadID, ok := model.ParseUploadKey(key)
+	if !ok {
+		return fmt.Errorf("%w for object %s", ErrUploadNotFound, key)
+	}
+
+	upload, err := s.uploadRepository.GetUpload(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	if upload == nil || upload.AccountID != accountID || upload.Key != key {
+		return fmt.Errorf("%w for object %s", ErrUploadNotFound, key)
+	}
+	now := s.now().UTC()
+	if !upload.IsClaimable(now) {
+		log.Printf("Skipping upload %s, already %s", upload.ID, upload.Status)
+		return nil
+	}
+	upload.Claim(now, uploadLease)
+	err = s.uploadRepository.ClaimUpload(ctx, upload, now)
+	if errors.Is(err, model.ErrUploadClaimed) {
+		log.Printf("Skipping upload %s, claimed by another notification", upload.ID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Download the statement into a temporary directory, keeping its file name for format detection
+	dir, err := os.MkdirTemp("", "upload-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, upload.FileName)
+	err = s.storage.DownloadObject(ctx, key, filePath)
+	if err == nil {
+		err = process(ctx, upload, filePath)
+	}
+
+	// Uploads stopped before the deadline are released, so the retried notification resumes them
+	switch {
+	case errors.Is(err, ErrDeadlineReached):
+		upload.Release()
+	case err != nil:
+		upload.MarkFailed(err)
+	default:
+		upload.MarkProcessed()
+	}
+	if saveErr := s.uploadRepository.SaveUpload(ctx, upload); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
+
+// newID returns a random 128-bit hexadecimal ID for uploads, jobs and notifications
+func newID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("error generating ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+	"transaction-processor/internal/domain/model"
+	"transaction-processor/internal/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUploadService_CreateUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockObjectStorage(ctrl)
+	mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+
+	service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+	service.now = func() time.Time { return time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC) }
+
+	var key string
+	mockStorage.EXPECT().
+		PresignUpload(gomock.Any(), gomock.Any(), "text/csv", 15*time.Minute).
+		DoAndReturn(func(_ context.Context, k, _ string, _ time.Duration) (string, error) {
+			key = k
+			return "https://bucket.example.com/" + k, nil
+		})
+	mockUploadRepo.EXPECT().SaveUpload(gomock.Any(), gomock.Any()).Return(nil)
+
+	customer := &model.Customer{ID: "cust1", AccountIDs: []string{"acc123"}}
+	upload, url, err := service.CreateUpload(context.Background(), "acc123", customer, "user@example.com", "eu-bank", `C:\exports\statement.csv`, "text/csv")
+
+	assert.NoError(t, err)
+	assert.Len(t, upload.ID, 32)
+	assert.Equal(t, "uploads/acc123/"+upload.ID+"/statement.csv", upload.Key)
+	assert.Equal(t, key, upload.Key)
+	assert.Equal(t, "https://bucket.example.com/"+upload.Key, url)
+	assert.Equal(t, "cust1", upload.CustomerID)
+	assert.Equal(t, "eu-bank", upload.CSVProfile)
+	assert.Equal(t, model.UploadStatusPending, upload.Status)
+	assert.Equal(t, time.Date(2025, time.January, 15, 10, 15, 0, 0, time.UTC), upload.ExpiresAt)
+}
+
+func TestUploadService_CreateUpload_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockObjectStorage(ctrl)
+	mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+
+	service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+	// Uploads are not recorded when the URL cannot be presigned
+	mockStorage.EXPECT().PresignUpload(gomock.Any(), gomock.Any(), "", 15*time.Minute).Return("", errors.New("presign error"))
+
+	_, _, err := service.CreateUpload(context.Background(), "acc123", nil, "user@example.com", "", "statement.ofx", "")
+	assert.Error(t, err)
+
+	_, _, err = service.CreateUpload(context.Background(), "acc123", nil, "user@example.com", "", "", "")
+	assert.Error(t, err)
+
+	// Account IDs must not escape the key prefix of their account
+	for _, accountID := range []string{"acc/123", "..", "acc..123", `acc\123`} {
+		_, _, err = service.CreateUpload(context.Background(), accountID, nil, "user@example.com", "", "statement.ofx", "")
+		assert.ErrorIs(t, err, model.ErrInvalidAccountID, accountID)
+	}
+}
+
+func TestUploadService_ProcessUpload(t *testing.T) {
+	createdAt := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+	newPendingUpload := func() *model.Upload {
+		upload := model.NewUpload("u1", "acc123", "statement.csv", createdAt, 15*time.Minute)
+		upload.Email = "user@example.com"
+		return upload
+	}
+	key := "uploads/acc123/u1/statement.csv"
+
+	t.Run("processed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(newPendingUpload(), nil)
+		mockUploadRepo.EXPECT().
+			ClaimUpload(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, upload *model.Upload, at time.Time) error {
+				assert.Equal(t, model.UploadStatusProcessing, upload.Status)
+				assert.Equal(t, at.Add(uploadLease), upload.ClaimedUntil)
+				return nil
+			})
+		mockStorage.EXPECT().
+			DownloadObject(gomock.Any(), key, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, filePath string) error {
+				return os.WriteFile(filePath, []byte("Id,Date,Transaction\n"), 0644)
+			})
+		mockUploadRepo.EXPECT().
+			SaveUpload(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, upload *model.Upload) error {
+				assert.Equal(t, model.UploadStatusProcessed, upload.Status)
+				assert.True(t, upload.ClaimedUntil.IsZero())
+				return nil
+			})
+
+		err := service.ProcessUpload(context.Background(), key, func(ctx context.Context, upload *model.Upload, filePath string) error {
+			assert.Equal(t, "user@example.com", upload.Email)
+			content, err := os.ReadFile(filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, "Id,Date,Transaction\n", string(content))
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		processErr := errors.New("invalid amount")
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(newPendingUpload(), nil)
+		mockUploadRepo.EXPECT().ClaimUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockStorage.EXPECT().DownloadObject(gomock.Any(), key, gomock.Any()).Return(nil)
+		mockUploadRepo.EXPECT().
+			SaveUpload(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, upload *model.Upload) error {
+				assert.Equal(t, model.UploadStatusFailed, upload.Status)
+				assert.Equal(t, "invalid amount", upload.Error)
+				return nil
+			})
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error { return processErr })
+		assert.True(t, errors.Is(err, processErr))
+	})
+
+	t.Run("stopped before the deadline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(newPendingUpload(), nil)
+		mockUploadRepo.EXPECT().ClaimUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockStorage.EXPECT().DownloadObject(gomock.Any(), key, gomock.Any()).Return(nil)
+
+		// The claim is released so the retried notification processes the upload again
+		mockUploadRepo.EXPECT().
+			SaveUpload(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, upload *model.Upload) error {
+				assert.Equal(t, model.UploadStatusPending, upload.Status)
+				assert.True(t, upload.ClaimedUntil.IsZero())
+				return nil
+			})
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error {
+			return &PartialResultError{Processed: 10, Total: 25}
+		})
+		assert.True(t, errors.Is(err, ErrDeadlineReached))
+	})
+
+	t.Run("resumed by the retried notification after the deadline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		// The repository keeps the upload as saved, so the retried notification reads the released one
+		stored := newPendingUpload()
+		mockUploadRepo.EXPECT().
+			GetUpload(gomock.Any(), "u1").
+			DoAndReturn(func(context.Context, string) (*model.Upload, error) {
+				upload := *stored
+				return &upload, nil
+			}).
+			Times(2)
+		mockUploadRepo.EXPECT().ClaimUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockStorage.EXPECT().DownloadObject(gomock.Any(), key, gomock.Any()).Return(nil).Times(2)
+		mockUploadRepo.EXPECT().
+			SaveUpload(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, upload *model.Upload) error {
+				stored = upload
+				return nil
+			}).
+			Times(2)
+
+		// Rows are processed until the margin ahead of the deadline, and the next run resumes after them
+		const rows = 10
+		processed := 0
+		process := func(ctx context.Context, _ *model.Upload, _ string) error {
+			stop, cancel := withDeadlineMargin(ctx)
+			defer cancel()
+			for ; processed < rows; processed++ {
+				if stop.Err() != nil {
+					return &PartialResultError{Processed: processed, Total: rows}
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin+50*time.Millisecond)
+		defer cancel()
+		err := service.ProcessUpload(ctx, key, process)
+		assert.True(t, errors.Is(err, ErrDeadlineReached), "expected ErrDeadlineReached, got %v", err)
+		assert.Equal(t, model.UploadStatusPending, stored.Status)
+		assert.Greater(t, processed, 0)
+		assert.Less(t, processed, rows)
+
+		err = service.ProcessUpload(context.Background(), key, process)
+		assert.NoError(t, err)
+		assert.Equal(t, model.UploadStatusProcessed, stored.Status)
+		assert.Equal(t, rows, processed)
+	})
+
+	t.Run("claimed by a concurrent notification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(newPendingUpload(), nil)
+		mockUploadRepo.EXPECT().ClaimUpload(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.ErrUploadClaimed)
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error {
+			t.Error("Claimed upload should be skipped")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("processing under way", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+		service.now = func() time.Time { return createdAt.Add(time.Minute) }
+
+		upload := newPendingUpload()
+		upload.Claim(createdAt, uploadLease)
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(upload, nil)
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error {
+			t.Error("Upload being processed should be skipped")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim outlived its lease", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+		service.now = func() time.Time { return createdAt.Add(time.Hour) }
+
+		upload := newPendingUpload()
+		upload.Claim(createdAt, uploadLease)
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(upload, nil)
+		mockUploadRepo.EXPECT().ClaimUpload(gomock.Any(), gomock.Any(), createdAt.Add(time.Hour)).Return(nil)
+		mockStorage.EXPECT().DownloadObject(gomock.Any(), key, gomock.Any()).Return(nil)
+		mockUploadRepo.EXPECT().SaveUpload(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error { return nil })
+		assert.NoError(t, err)
+	})
+
+	t.Run("already processed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		upload := newPendingUpload()
+		upload.MarkProcessed()
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(upload, nil)
+
+		err := service.ProcessUpload(context.Background(), key, func(context.Context, *model.Upload, string) error {
+			t.Error("Processed upload should be skipped")
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown objects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockObjectStorage(ctrl)
+		mockUploadRepo := mocks.NewMockUploadRepository(ctrl)
+		service := NewUploadService(mockStorage, mockUploadRepo, 15*time.Minute)
+
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u1").Return(newPendingUpload(), nil)
+		mockUploadRepo.EXPECT().GetUpload(gomock.Any(), "u2").Return(nil, nil)
+
+		for _, objectKey := range []string{
+			"statements/statement.csv",
+			"uploads/other/u1/statement.csv",
+			"uploads/../u1/statement.csv",
+			"uploads/acc123/u2/statement.csv",
+		} {
+			err := service.ProcessUpload(context.Background(), objectKey, nil)
+			assert.True(t, errors.Is(err, ErrUploadNotFound), "expected ErrUploadNotFound for %s, got %v", objectKey, err)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// runBounded runs task for every index in [0, n) on up to workers goroutines. Tasks are started in
+// index order until ctx is cancelled, and the tasks left are reported as not started. Failures do
+// not stop the other tasks; they are returned joined in index order, so they are reported the same
+// way however the tasks were scheduled.
+func runBounded(ctx context.Context, workers, n int, task func(i int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = task(i)
+			}
+		}()
+	}
+
+	started := 0
+	for started < n && ctx.Err() == nil {
+		select {
+		case indexes <- started:
+			started++
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if started < n {
+		errs = append(errs, fmt.Errorf("%d of %d tasks not started: %w", n-started, n, ctx.Err()))
+	}
+	return errors.Join(errs...)
+}
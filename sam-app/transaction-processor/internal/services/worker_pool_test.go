@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBounded(t *testing.T) {
+	t.Run("runs every task with at most the given workers", func(t *testing.T) {
+		var running, peak, done atomic.Int32
+		err := runBounded(context.Background(), 3, 20, func(i int) error {
+			current := running.Add(1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(20), done.Load())
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+	})
+
+	t.Run("failures are joined in index order", func(t *testing.T) {
+		err := runBounded(context.Background(), 4, 10, func(i int) error {
+			if i%3 == 0 {
+				// Later tasks fail first
+				time.Sleep(time.Duration(10-i) * time.Millisecond)
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		})
+
+		assert.EqualError(t, err, "task 0 failed\ntask 3 failed\ntask 6 failed\ntask 9 failed")
+	})
+
+	t.Run("tasks are not started once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var started atomic.Int32
+		err := runBounded(ctx, 1, 10, func(i int) error {
+			started.Add(1)
+			if i == 2 {
+				cancel()
+			}
+			return nil
+		})
+
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+		assert.Less(t, started.Load(), int32(10))
+	})
+}
@@ -1,23 +1,141 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"transaction-processor/internal/config"
+	"transaction-processor/internal/factory"
 	"transaction-processor/internal/handlers"
+	"transaction-processor/internal/models"
 )
 
-func handler(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+// eventSource holds the source of the records of an AWS service event, or the operation of an admin event
+type eventSource struct {
+	Operation string `json:"operation"`
+	Source    string `json:"source"`
+	Records   []struct {
+		EventSource string `json:"eventSource"`
+	} `json:"Records"`
+}
+
+// route dispatches S3 notifications to the upload handler, SQS messages to the job worker, scheduled
+// EventBridge events to the monthly statement handler, replay and migration admin events to their
+// handlers, outbox sweeps to the outbox handler and every other event to the API handler
+func route(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	var source eventSource
+	if err := json.Unmarshal(event, &source); err != nil {
+		return nil, err
+	}
+
+	if source.Operation == "replay" {
+		var replayRequest models.ReplayRequest
+		if err := json.Unmarshal(event, &replayRequest); err != nil {
+			return nil, err
+		}
+		return handlers.NewReplayHandler(config.Load()).Handle(ctx, replayRequest)
+	}
+
+	if source.Operation == "migrate-transactions" {
+		var migrationRequest models.MigrationRequest
+		if err := json.Unmarshal(event, &migrationRequest); err != nil {
+			return nil, err
+		}
+		return handlers.NewMigrationHandler(config.Load()).Handle(ctx, migrationRequest)
+	}
+
+	// The outbox schedule replaces its EventBridge event with this operation
+	if source.Operation == "dispatch-outbox" {
+		return handlers.NewOutboxHandler(config.Load()).Handle(ctx)
+	}
+
+	if source.Source == "aws.events" {
+		var scheduledEvent events.CloudWatchEvent
+		if err := json.Unmarshal(event, &scheduledEvent); err != nil {
+			return nil, err
+		}
+		return nil, handlers.NewMonthlyStatementHandler(config.Load()).HandleScheduled(ctx, scheduledEvent)
+	}
+
+	if len(source.Records) > 0 {
+		switch source.Records[0].EventSource {
+		case "aws:s3":
+			var s3Event events.S3Event
+			if err := json.Unmarshal(event, &s3Event); err != nil {
+				return nil, err
+			}
+			return nil, handlers.NewUploadHandler(config.Load()).HandleObjectCreated(ctx, s3Event)
+		case "aws:sqs":
+			var sqsEvent events.SQSEvent
+			if err := json.Unmarshal(event, &sqsEvent); err != nil {
+				return nil, err
+			}
+			return sqsHandler(ctx, sqsEvent)
+		}
+	}
+
+	var request events.APIGatewayProxyRequest
+	if err := json.Unmarshal(event, &request); err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// sqsHandler runs the statement jobs of a batch of SQS messages, reporting the messages that failed
+func sqsHandler(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
+	return handlers.NewJobWorker(config.Load()).HandleSQS(ctx, event)
+}
+
+func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	// Load configuration from environment variables
 	cfg := config.Load()
 
+	// Route GET /forecast to the forecast handler
+	if request.HTTPMethod == http.MethodGet && request.Resource == "/forecast" {
+		return handlers.NewForecastHandler(cfg).Handle(ctx, request)
+	}
+
+	// Route PUT /budgets to the budget handler
+	if request.HTTPMethod == http.MethodPut && request.Resource == "/budgets" {
+		return handlers.NewBudgetHandler(cfg).Handle(ctx, request)
+	}
+
+	// Route POST /statements to the statement upload handler
+	if request.HTTPMethod == http.MethodPost && request.Resource == "/statements" {
+		return handlers.NewStatementHandler(cfg).Handle(ctx, request)
+	}
+
+	// Route POST /uploads to the presigned upload handler
+	if request.HTTPMethod == http.MethodPost && request.Resource == "/uploads" {
+		return handlers.NewUploadHandler(cfg).Handle(ctx, request)
+	}
+
+	// Route GET /jobs/{id} to the job status handler
+	if request.HTTPMethod == http.MethodGet && request.Resource == "/jobs/{id}" {
+		return handlers.NewJobHandler(cfg).Handle(ctx, request)
+	}
+
 	// Create transaction handler
 	transactionHandler := handlers.NewTransactionHandler(cfg)
 
 	// Handle the request
-	return transactionHandler.Handle(request)
+	return transactionHandler.Handle(ctx, request)
 }
 
 func main() {
-	lambda.Start(handler)
+	// Without an SQS queue, jobs are queued in memory and run by this process. Lambda freezes the process
+	// between invocations, so neither this consumer nor the outbox poller below is started there.
+	if cfg := config.Load(); cfg.JobsQueueURL == "" && cfg.LambdaFunctionName == "" {
+		factory.LocalJobQueue().Start(handlers.NewJobWorker(cfg).Run, cfg.JobMaxAttempts+1)
+	}
+
+	// Without a schedule, the outbox is swept by this process when a poll interval is configured
+	if cfg := config.Load(); cfg.OutboxTable != "" && cfg.OutboxPollInterval > 0 && cfg.LambdaFunctionName == "" {
+		factory.NewServiceFactory(cfg).CreateOutboxPoller().Start(handlers.NewOutboxHandler(cfg).Sweep)
+	}
+
+	lambda.Start(route)
 }
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -44,7 +47,7 @@ func TestHandler(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			response, err := handler(testCase.request)
+			response, err := handler(context.Background(), testCase.request)
 			if err != testCase.expectedError {
 				t.Errorf("Expected error %v, but got %v", testCase.expectedError, err)
 			}
@@ -55,3 +58,28 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSQSHandler_InvalidMessage(t *testing.T) {
+	event := events.SQSEvent{
+		Records: []events.SQSMessage{
+			{MessageId: "msg1", Body: `not json`},
+			{MessageId: "msg2", Body: `{"jobId": ""}`},
+		},
+	}
+
+	response, err := sqsHandler(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if len(response.BatchItemFailures) != 2 || response.BatchItemFailures[0].ItemIdentifier != "msg1" || response.BatchItemFailures[1].ItemIdentifier != "msg2" {
+		t.Errorf("Expected both messages reported as failed, but got %v", response.BatchItemFailures)
+	}
+}
+
+func TestRoute_InvalidReplay(t *testing.T) {
+	_, err := route(context.Background(), json.RawMessage(`{"operation": "replay", "from": "2025-06-01"}`))
+	if err == nil || !strings.Contains(err.Error(), "invalid replay request") {
+		t.Errorf("Expected an invalid replay request error, but got %v", err)
+	}
+}